@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"just-kanban/internal/app"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+)
+
+const (
+	flagFile     = "file"
+	flagImporter = "importer"
+)
+
+func main() {
+	file := flag.String(flagFile, "", "Path to Trello board export JSON, standard input is read if it's -")
+	importer := flag.String(flagImporter, "", "Username or email of user who becomes owner of imported board")
+	flag.Parse()
+	if *file == "" || *importer == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+		exportFile, openErr := os.Open(*file)
+		if openErr != nil {
+			log.Fatalln(openErr)
+		}
+		defer exportFile.Close()
+		input = exportFile
+	}
+	commandApp := app.NewCommandApp()
+	defer commandApp.DB.Close()
+	ctx := context.Background()
+	var user *models.User
+	var searchErr error
+	if strings.Contains(*importer, "@") {
+		user, searchErr = commandApp.UserService.FindByEmail(ctx, *importer)
+	} else {
+		user, searchErr = commandApp.UserService.FindByUsername(ctx, *importer)
+	}
+	if searchErr != nil {
+		log.Fatalln("Importer isn't found:", searchErr)
+	}
+	ctx = context.WithValue(ctx, contextkeys.KeyUserId, user.ID)
+	report, importErr := commandApp.Importer.Import(ctx, input)
+	if importErr != nil {
+		log.Fatalln(importErr)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if encodeErr := encoder.Encode(report); encodeErr != nil {
+		log.Fatalln(encodeErr)
+	}
+	log.Printf("Board %s is imported, %d parts are skipped\n", report.Board.ID, len(report.Skipped))
+}
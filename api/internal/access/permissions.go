@@ -0,0 +1,112 @@
+package access
+
+import "slices"
+
+// Permission is an action on board which member may be allowed to do
+type Permission string
+
+const (
+	// PermissionBoardRead allows to read board and all of its records
+	PermissionBoardRead Permission = "board.read"
+	// PermissionBoardUpdate allows to change board info
+	PermissionBoardUpdate Permission = "board.update"
+	// PermissionBoardDelete allows to delete board with all of its records
+	PermissionBoardDelete Permission = "board.delete"
+	// PermissionColumnManage allows to create, change, reorder and delete board columns
+	PermissionColumnManage Permission = "column.manage"
+	// PermissionLabelManage allows to create, change and delete board labels
+	PermissionLabelManage Permission = "label.manage"
+	// PermissionMemberInvite allows to add new members to board
+	PermissionMemberInvite Permission = "member.invite"
+	// PermissionMemberUpdate allows to change roles of board members
+	PermissionMemberUpdate Permission = "member.update"
+	// PermissionMemberRemove allows to remove other members from board, any member may leave board by himself
+	PermissionMemberRemove Permission = "member.remove"
+	// PermissionRoleManage allows to create, change and delete custom roles of board
+	PermissionRoleManage Permission = "role.manage"
+	// PermissionTaskCreate allows to create tasks
+	PermissionTaskCreate Permission = "task.create"
+	// PermissionTaskUpdate allows to change and move tasks, their checklists, attachments and dependencies
+	PermissionTaskUpdate Permission = "task.update"
+	// PermissionTaskDeleteOwn allows to delete tasks created by member
+	PermissionTaskDeleteOwn Permission = "task.delete.own"
+	// PermissionTaskDeleteAny allows to delete any task of board
+	PermissionTaskDeleteAny Permission = "task.delete.any"
+	// PermissionTaskOverrideWIPLimit allows to place tasks into columns which reached their work in progress limit
+	PermissionTaskOverrideWIPLimit Permission = "task.override_wip_limit"
+	// PermissionCommentCreate allows to comment tasks and change own comments
+	PermissionCommentCreate Permission = "comment.create"
+	// PermissionCommentManageAny allows to change and delete comments of other members
+	PermissionCommentManageAny Permission = "comment.manage.any"
+	// PermissionAttachmentDeleteAny allows to delete attachments uploaded by other members
+	PermissionAttachmentDeleteAny Permission = "attachment.delete.any"
+	// PermissionWebhookManage allows to register, change and delete webhooks of board and read their deliveries
+	PermissionWebhookManage Permission = "webhook.manage"
+)
+
+// Permissions are all permissions which may be granted to roles
+var Permissions = []Permission{
+	PermissionBoardRead,
+	PermissionBoardUpdate,
+	PermissionBoardDelete,
+	PermissionColumnManage,
+	PermissionLabelManage,
+	PermissionMemberInvite,
+	PermissionMemberUpdate,
+	PermissionMemberRemove,
+	PermissionRoleManage,
+	PermissionTaskCreate,
+	PermissionTaskUpdate,
+	PermissionTaskDeleteOwn,
+	PermissionTaskDeleteAny,
+	PermissionTaskOverrideWIPLimit,
+	PermissionCommentCreate,
+	PermissionCommentManageAny,
+	PermissionAttachmentDeleteAny,
+	PermissionWebhookManage,
+}
+
+var (
+	regularPermissions = []Permission{
+		PermissionBoardRead,
+		PermissionTaskCreate,
+		PermissionTaskUpdate,
+		PermissionTaskDeleteOwn,
+		PermissionCommentCreate,
+	}
+	managerPermissions = append(slices.Clone(regularPermissions),
+		PermissionBoardUpdate,
+		PermissionColumnManage,
+		PermissionLabelManage,
+		PermissionMemberInvite,
+		PermissionMemberUpdate,
+		PermissionMemberRemove,
+		PermissionTaskDeleteAny,
+		PermissionTaskOverrideWIPLimit,
+		PermissionCommentManageAny,
+		PermissionAttachmentDeleteAny,
+		PermissionWebhookManage,
+	)
+	ownerPermissions = append(slices.Clone(managerPermissions),
+		PermissionBoardDelete,
+		PermissionRoleManage,
+	)
+)
+
+// BuiltInRoles maps roles available on every board to their permissions
+var BuiltInRoles = map[Role][]Permission{
+	RoleOwner:   ownerPermissions,
+	RoleManager: managerPermissions,
+	RoleRegular: regularPermissions,
+}
+
+// IsKnown checks permission is one of Permissions
+func (p Permission) IsKnown() bool {
+	return slices.Contains(Permissions, p)
+}
+
+// IsBuiltIn checks role is one of BuiltInRoles, such role can't be redefined by board
+func (r Role) IsBuiltIn() bool {
+	_, ok := BuiltInRoles[r]
+	return ok
+}
@@ -0,0 +1,48 @@
+package access
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBuiltInRoles(t *testing.T) {
+	t.Run("Every role has permissions of lower roles", func(t *testing.T) {
+		for _, pair := range [][2]Role{{RoleOwner, RoleManager}, {RoleManager, RoleRegular}} {
+			higher, lower := BuiltInRoles[pair[0]], BuiltInRoles[pair[1]]
+			for _, permission := range lower {
+				if !slices.Contains(higher, permission) {
+					t.Fatalf("%s has no %s permission of %s", pair[0], permission, pair[1])
+				}
+			}
+		}
+	})
+
+	t.Run("Built-in roles have known permissions only", func(t *testing.T) {
+		for role, permissions := range BuiltInRoles {
+			for _, permission := range permissions {
+				if !permission.IsKnown() {
+					t.Fatalf("%s has unknown permission %s", role, permission)
+				}
+			}
+		}
+	})
+
+	t.Run("Only owner deletes board and manages roles", func(t *testing.T) {
+		for role, permissions := range BuiltInRoles {
+			for _, permission := range []Permission{PermissionBoardDelete, PermissionRoleManage} {
+				if slices.Contains(permissions, permission) != (role == RoleOwner) {
+					t.Fatalf("%s permission of %s is unexpected", permission, role)
+				}
+			}
+		}
+	})
+
+	t.Run("Custom role names aren't built-in", func(t *testing.T) {
+		if Role("reviewer").IsBuiltIn() {
+			t.Fatal("custom role is treated as built-in")
+		}
+		if !RoleManager.IsBuiltIn() {
+			t.Fatal("manager role isn't treated as built-in")
+		}
+	})
+}
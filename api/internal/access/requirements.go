@@ -0,0 +1,121 @@
+package access
+
+import "net/http"
+
+// Requirements are permissions of board members required for request methods,
+// methods which aren't listed aren't allowed at all
+type Requirements map[string]Permission
+
+// PermissionFor returns permission required for request method, false is returned if method isn't allowed
+func (req Requirements) PermissionFor(method string) (Permission, bool) {
+	permission, ok := req[method]
+	return permission, ok
+}
+
+var (
+	// ReadRequirements allow any board member to read records
+	ReadRequirements = Requirements{
+		http.MethodGet: PermissionBoardRead,
+	}
+	// BoardRequirements allow to read, change and delete board
+	BoardRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPatch:  PermissionBoardUpdate,
+		http.MethodDelete: PermissionBoardDelete,
+	}
+	// MembersRequirements allow to list and invite board members
+	MembersRequirements = Requirements{
+		http.MethodGet:  PermissionBoardRead,
+		http.MethodPost: PermissionMemberInvite,
+	}
+	// MemberRequirements allow to change board member, any member may leave board
+	// while handler checks whose membership is deleted
+	MemberRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPatch:  PermissionMemberUpdate,
+		http.MethodDelete: PermissionBoardRead,
+	}
+	// InvitationsRequirements allow to list, create and revoke board invitations
+	InvitationsRequirements = Requirements{
+		http.MethodGet:    PermissionMemberInvite,
+		http.MethodPost:   PermissionMemberInvite,
+		http.MethodDelete: PermissionMemberInvite,
+	}
+	// WebhooksRequirements allow to list, register, change and delete board webhooks along with reading their
+	// deliveries, webhooks aren't shown to other members since their urls may carry credentials
+	WebhooksRequirements = Requirements{
+		http.MethodGet:    PermissionWebhookManage,
+		http.MethodPost:   PermissionWebhookManage,
+		http.MethodPatch:  PermissionWebhookManage,
+		http.MethodDelete: PermissionWebhookManage,
+	}
+	// TransferOwnershipRequirements allow any board member to reach ownership transfer
+	// while service checks requester is board owner
+	TransferOwnershipRequirements = Requirements{
+		http.MethodPost: PermissionBoardRead,
+	}
+	// ArchiveRequirements allow to move board to archive and return it back
+	ArchiveRequirements = Requirements{
+		http.MethodPost:   PermissionBoardUpdate,
+		http.MethodDelete: PermissionBoardUpdate,
+	}
+	// CloneRequirements allow any board member to copy board into new one,
+	// handler checks whether members may be copied
+	CloneRequirements = Requirements{
+		http.MethodPost: PermissionBoardRead,
+	}
+	// ExportRequirements allow to export board with its members and tasks
+	ExportRequirements = Requirements{
+		http.MethodGet: PermissionBoardUpdate,
+	}
+	// RolesRequirements allow to list and manage board roles
+	RolesRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPost:   PermissionRoleManage,
+		http.MethodPatch:  PermissionRoleManage,
+		http.MethodDelete: PermissionRoleManage,
+	}
+	// ColumnsRequirements allow to read and manage board columns
+	ColumnsRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPost:   PermissionColumnManage,
+		http.MethodPut:    PermissionColumnManage,
+		http.MethodPatch:  PermissionColumnManage,
+		http.MethodDelete: PermissionColumnManage,
+	}
+	// LabelsRequirements allow to read and manage board labels
+	LabelsRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPost:   PermissionLabelManage,
+		http.MethodPatch:  PermissionLabelManage,
+		http.MethodDelete: PermissionLabelManage,
+	}
+	// TasksRequirements allow to list and create tasks
+	TasksRequirements = Requirements{
+		http.MethodGet:  PermissionBoardRead,
+		http.MethodPost: PermissionTaskCreate,
+	}
+	// TaskRequirements allow to read, change and delete task, any member reaches deletion
+	// while handler checks whether requester may delete own task or task of another member
+	TaskRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPatch:  PermissionTaskUpdate,
+		http.MethodDelete: PermissionBoardRead,
+	}
+	// TaskPartsRequirements allow to read and change parts of task like checklist, attachments or dependencies
+	TaskPartsRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPost:   PermissionTaskUpdate,
+		http.MethodPut:    PermissionTaskUpdate,
+		http.MethodPatch:  PermissionTaskUpdate,
+		http.MethodDelete: PermissionTaskUpdate,
+	}
+	// CommentsRequirements allow to read and write task comments,
+	// handler checks whether comment of another member may be changed
+	CommentsRequirements = Requirements{
+		http.MethodGet:    PermissionBoardRead,
+		http.MethodPost:   PermissionCommentCreate,
+		http.MethodPatch:  PermissionCommentCreate,
+		http.MethodDelete: PermissionCommentCreate,
+	}
+)
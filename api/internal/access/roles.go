@@ -1,13 +1,14 @@
 package access
 
-// Role is a status of board member, which on board management depends
+// Role is a status of board member, which on board management depends,
+// besides built-in roles board may define custom ones with chosen permissions
 type Role string
 
 const (
 	// RoleOwner is the one who has privileges for management administration and board delete
 	RoleOwner Role = "owner"
 	// RoleManager is a one who has possibility for tasks creation and regular members access management
-	RoleManager = "manager"
+	RoleManager Role = "manager"
 	// RoleRegular is a regular member of a board whose accesses defined by managers
-	RoleRegular = "regular"
+	RoleRegular Role = "regular"
 )
@@ -1,14 +1,23 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"just-kanban/internal/access"
 	"just-kanban/internal/config"
 	"just-kanban/internal/handlers"
+	"just-kanban/internal/importers/trello"
 	"just-kanban/internal/middlewares"
+	"just-kanban/internal/repositories/filesystem"
 	repositorysql "just-kanban/internal/repositories/sql"
 	"just-kanban/internal/services"
 	"just-kanban/pkg/database"
@@ -16,6 +25,9 @@ import (
 	"just-kanban/pkg/validation"
 )
 
+// shutdownTimeout is how long app waits for active requests to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
 type App struct {
 	*http.ServeMux
 	*sql.DB
@@ -29,16 +41,47 @@ type App struct {
 	*services.TokenService
 	*services.BoardService
 	*services.BoardMemberService
+	*services.BoardRoleService
+	*services.InvitationService
+	*services.CommentService
+	*services.LabelService
+	*services.ReminderService
+	*services.TrashService
+	*services.RetentionService
+	*services.BoardCloneService
+	*services.BoardExportService
+	*services.TaskImportService
+	*trello.Importer
+	*services.BoardEventHub
+	*services.BoardSessionHub
+	*services.WebhookService
+	*services.WebhookDispatcher
+	*services.ChecklistService
+	*services.TaskHistoryService
+	*services.AttachmentService
+	*services.TaskDependencyService
+	*services.SearchService
 }
 
 func NewApp() *App {
+	app := newServicesApp()
+	app.initRouter()
+	app.runBackgroundJobs()
+	app.runListen()
+	return app
+}
+
+// NewCommandApp creates app without http server and background jobs for command line tools working with app data
+func NewCommandApp() *App {
+	return newServicesApp()
+}
+
+func newServicesApp() *App {
 	env := config.NewEnv()
 	app := App{Env: env}
 	app.initDatabase()
 	app.initValidator()
 	app.initServices()
-	app.initRouter()
-	app.runListen()
 	return &app
 }
 
@@ -64,8 +107,38 @@ func (app *App) initValidator() {
 
 func (app *App) initServices() {
 	// WARNING! Right services init order is required
-	app.UserService = services.NewUserService(repositorysql.NewUserRepository(app.DB))
-	app.TaskService = services.NewTaskService(repositorysql.NewTaskRepository(app.DB))
+	paginator := services.NewKeysetPaginator(app.Env.CursorSecret, int(app.Env.PageMaxSize))
+	app.UserService = services.NewUserService(repositorysql.NewUserRepository(app.DB), paginator)
+	app.BoardEventHub = services.NewBoardEventHub(
+		services.SystemClock{},
+		services.DefaultBoardEventBufferSize,
+		services.DefaultBoardEventIdleTimeout,
+	)
+	app.WebhookService = services.NewWebhookService(repositorysql.NewWebhookRepository(app.DB))
+	app.WebhookDispatcher = services.NewWebhookDispatcher(
+		repositorysql.NewWebhookRepository(app.DB),
+		services.SystemClock{},
+	)
+	// hub numbers events before they're sent to webhooks
+	boardEventPublisher := services.BoardEventPublishers{app.BoardEventHub, app.WebhookDispatcher}
+	app.TaskService = services.NewTaskService(
+		repositorysql.NewTaskRepository(app.DB),
+		repositorysql.NewBoardColumnRepository(app.DB),
+		repositorysql.NewLabelRepository(app.DB),
+		repositorysql.NewChecklistItemRepository(app.DB),
+		repositorysql.NewTaskHistoryRepository(app.DB),
+		repositorysql.NewTaskDependencyRepository(app.DB),
+		repositorysql.NewBoardMemberRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
+		services.SystemClock{},
+		boardEventPublisher,
+	)
+	app.BoardSessionHub = services.NewBoardSessionHub(
+		services.SystemClock{},
+		services.DefaultPresenceTimeout,
+		services.DefaultTaskLockTimeout,
+	)
+	app.TaskHistoryService = services.NewTaskHistoryService(repositorysql.NewTaskHistoryRepository(app.DB))
 	app.TokenService = services.NewTokenService(
 		repositorysql.NewRefreshTokenRepository(app.DB),
 		app.Env.JWTSecret,
@@ -73,12 +146,93 @@ func (app *App) initServices() {
 	app.AuthService = services.NewAuthService(app.TokenService, app.UserService)
 	app.BoardService = services.NewBoardService(
 		repositorysql.NewBoardRepository(app.DB),
+		repositorysql.NewBoardColumnRepository(app.DB),
+		repositorysql.NewBoardMemberRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
 		app.TaskService,
+		paginator,
+		services.SystemClock{},
+	)
+	app.BoardRoleService = services.NewBoardRoleService(
+		repositorysql.NewBoardRoleRepository(app.DB),
+		repositorysql.NewBoardMemberRepository(app.DB),
 	)
 	app.BoardMemberService = services.NewBoardMemberService(
 		repositorysql.NewBoardMemberRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
 		app.BoardService,
+		app.BoardRoleService,
+		app.UserService,
+		boardEventPublisher,
+	)
+	app.InvitationService = services.NewInvitationService(
+		repositorysql.NewInvitationRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
+		app.BoardMemberService,
+		services.SystemClock{},
+	)
+	app.ReminderService = services.NewReminderService(
+		repositorysql.NewTaskRepository(app.DB),
+		services.LogReminderNotifier{},
+		services.SystemClock{},
+		services.DefaultReminderInterval,
+		services.DefaultReminderWindow,
+	)
+	app.TrashService = services.NewTrashService(app.BoardService, app.BoardMemberService)
+	app.BoardCloneService = services.NewBoardCloneService(
+		repositorysql.NewBoardRepository(app.DB),
+		repositorysql.NewBoardColumnRepository(app.DB),
+		repositorysql.NewLabelRepository(app.DB),
+		repositorysql.NewBoardRoleRepository(app.DB),
+		repositorysql.NewBoardMemberRepository(app.DB),
+		repositorysql.NewTaskRepository(app.DB),
+		repositorysql.NewChecklistItemRepository(app.DB),
+		repositorysql.NewTaskDependencyRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
+	)
+	app.BoardExportService = services.NewBoardExportService(
+		app.BoardCloneService,
+		repositorysql.NewCommentRepository(app.DB),
 		app.UserService,
+		services.SystemClock{},
+	)
+	app.TaskImportService = services.NewTaskImportService(app.TaskService, app.UserService)
+	app.Importer = trello.NewImporter(app.BoardExportService, app.Validate)
+	app.LabelService = services.NewLabelService(repositorysql.NewLabelRepository(app.DB))
+	app.ChecklistService = services.NewChecklistService(
+		repositorysql.NewChecklistItemRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
+		app.BoardMemberService,
+	)
+	blobStore, blobStoreErr := filesystem.NewBlobStore(app.Env.StorageDir)
+	if blobStoreErr != nil {
+		panic("Can't init blob store: " + blobStoreErr.Error())
+	}
+	app.AttachmentService = services.NewAttachmentService(
+		repositorysql.NewAttachmentRepository(app.DB),
+		blobStore,
+		app.BoardMemberService,
+		app.Env.AttachmentMaxSize,
+	)
+	app.RetentionService = services.NewRetentionService(
+		repositorysql.NewBoardRepository(app.DB),
+		repositorysql.NewTaskRepository(app.DB),
+		repositorysql.NewAttachmentRepository(app.DB),
+		blobStore,
+		services.SystemClock{},
+		services.DefaultRetentionInterval,
+		time.Duration(app.Env.TrashRetentionDays)*24*time.Hour,
+	)
+	app.TaskDependencyService = services.NewTaskDependencyService(
+		repositorysql.NewTaskDependencyRepository(app.DB),
+		repositorysql.NewTransactor(app.DB),
+		app.TaskService,
+		app.BoardMemberService,
+	)
+	app.SearchService = services.NewSearchService(repositorysql.NewSearchRepository(app.DB))
+	app.CommentService = services.NewCommentService(
+		repositorysql.NewCommentRepository(app.DB),
+		app.BoardMemberService,
 	)
 }
 
@@ -92,18 +246,24 @@ func (app *App) initSecureHandlers() {
 	secureRoutes := router.NewGroup(app.ServeMux, "")
 	secureRoutes.Use(
 		func(handler http.Handler) http.Handler {
-			return middlewares.Auth(handler, app.Env)
+			return middlewares.Auth(handler, app.Env, app.URLPaths.BoardSessionHandler)
 		},
 	)
 	secureRoutes.Handle(app.URLPaths.LogoutHandler, handlers.NewLogoutHandler(app.AuthService))
 	secureRoutes.Handle(app.URLPaths.UsersHandler, handlers.NewUserHandler(app.UserService, app.Validate))
 	secureRoutes.Handle(
 		app.URLPaths.BoardMembersHandler,
-		handlers.NewBoardMemberHandler(app.BoardMemberService, app.Validate),
+		app.boardAccess(
+			handlers.NewBoardMemberHandler(app.BoardMemberService, app.Validate),
+			access.MembersRequirements,
+		),
 	)
 	secureRoutes.Handle(
 		app.URLPaths.BoardMemberHandler,
-		handlers.NewBoardMemberHandler(app.BoardMemberService, app.Validate),
+		app.boardAccess(
+			handlers.NewBoardMemberHandler(app.BoardMemberService, app.Validate),
+			access.MemberRequirements,
+		),
 	)
 	secureRoutes.Handle(
 		app.URLPaths.BoardsHandler,
@@ -116,27 +276,285 @@ func (app *App) initSecureHandlers() {
 	)
 	secureRoutes.Handle(
 		app.URLPaths.BoardHandler,
-		handlers.NewBoardHandler(
-			app.TaskService,
-			app.BoardService,
-			app.BoardMemberService,
-			app.Validate,
+		app.boardAccess(
+			handlers.NewBoardHandler(
+				app.TaskService,
+				app.BoardService,
+				app.BoardMemberService,
+				app.Validate,
+			),
+			access.BoardRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardColumnsHandler,
+		app.boardAccess(
+			handlers.NewBoardColumnHandler(app.BoardService, app.BoardMemberService, app.Validate),
+			access.ColumnsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardColumnHandler,
+		app.boardAccess(
+			handlers.NewBoardColumnHandler(app.BoardService, app.BoardMemberService, app.Validate),
+			access.ColumnsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.LabelsHandler,
+		app.boardAccess(
+			handlers.NewLabelHandler(app.LabelService, app.BoardMemberService, app.Validate),
+			access.LabelsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.LabelHandler,
+		app.boardAccess(
+			handlers.NewLabelHandler(app.LabelService, app.BoardMemberService, app.Validate),
+			access.LabelsRequirements,
 		),
 	)
 	secureRoutes.Handle(
 		app.URLPaths.TasksHandler,
-		handlers.NewTaskHandler(
-			app.TaskService,
-			app.Validate,
+		app.boardAccess(
+			handlers.NewTaskHandler(
+				app.TaskService,
+				app.BoardMemberService,
+				app.Validate,
+			),
+			access.TasksRequirements,
 		),
 	)
 	secureRoutes.Handle(
 		app.URLPaths.TaskHandler,
-		handlers.NewTaskHandler(
-			app.TaskService,
-			app.Validate,
+		app.boardAccess(
+			handlers.NewTaskHandler(
+				app.TaskService,
+				app.BoardMemberService,
+				app.Validate,
+			),
+			access.TaskRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.CommentsHandler,
+		app.boardAccess(
+			handlers.NewCommentHandler(app.CommentService, app.TaskService, app.Validate),
+			access.CommentsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.CommentHandler,
+		app.boardAccess(
+			handlers.NewCommentHandler(app.CommentService, app.TaskService, app.Validate),
+			access.CommentsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.ChecklistHandler,
+		app.boardAccess(
+			handlers.NewChecklistHandler(app.ChecklistService, app.TaskService, app.Validate),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.ChecklistItemHandler,
+		app.boardAccess(
+			handlers.NewChecklistHandler(app.ChecklistService, app.TaskService, app.Validate),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TaskHistoryHandler,
+		app.boardAccess(
+			handlers.NewTaskHistoryHandler(app.TaskHistoryService, app.TaskService, app.BoardMemberService, app.Validate),
+			access.ReadRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardActivityHandler,
+		app.boardAccess(
+			handlers.NewTaskHistoryHandler(app.TaskHistoryService, app.TaskService, app.BoardMemberService, app.Validate),
+			access.ReadRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.AttachmentsHandler,
+		app.boardAccess(
+			handlers.NewAttachmentHandler(app.AttachmentService, app.TaskService),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.AttachmentHandler,
+		app.boardAccess(
+			handlers.NewAttachmentHandler(app.AttachmentService, app.TaskService),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TaskDependenciesHandler,
+		app.boardAccess(
+			handlers.NewTaskDependencyHandler(app.TaskDependencyService, app.Validate),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TaskDependencyHandler,
+		app.boardAccess(
+			handlers.NewTaskDependencyHandler(app.TaskDependencyService, app.Validate),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(app.URLPaths.SearchHandler, handlers.NewSearchHandler(app.SearchService, app.Validate))
+	secureRoutes.Handle(
+		app.URLPaths.TaskMoveHandler,
+		app.boardAccess(
+			handlers.NewTaskMoveHandler(
+				app.TaskService,
+				app.BoardMemberService,
+				app.Validate,
+			),
+			access.TaskPartsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardRolesHandler,
+		app.boardAccess(
+			handlers.NewBoardRoleHandler(app.BoardRoleService, app.BoardService, app.Validate),
+			access.RolesRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardRoleHandler,
+		app.boardAccess(
+			handlers.NewBoardRoleHandler(app.BoardRoleService, app.BoardService, app.Validate),
+			access.RolesRequirements,
 		),
 	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardInvitationsHandler,
+		app.boardAccess(
+			handlers.NewBoardInvitationHandler(app.InvitationService, app.Validate),
+			access.InvitationsRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardInvitationHandler,
+		app.boardAccess(
+			handlers.NewBoardInvitationHandler(app.InvitationService, app.Validate),
+			access.InvitationsRequirements,
+		),
+	)
+	secureRoutes.Handle(app.URLPaths.InvitationsHandler, handlers.NewInvitationHandler(app.InvitationService))
+	secureRoutes.Handle(
+		app.URLPaths.InvitationAcceptHandler,
+		handlers.NewInvitationAcceptHandler(app.InvitationService, app.Validate),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.InvitationDeclineHandler,
+		handlers.NewInvitationDeclineHandler(app.InvitationService, app.Validate),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TransferOwnershipHandler,
+		app.boardAccess(
+			handlers.NewTransferOwnershipHandler(app.BoardMemberService, app.Validate),
+			access.TransferOwnershipRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardArchiveHandler,
+		app.boardAccess(handlers.NewBoardArchiveHandler(app.BoardService), access.ArchiveRequirements),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TaskArchiveHandler,
+		app.boardAccess(handlers.NewTaskArchiveHandler(app.TaskService), access.TaskPartsRequirements),
+	)
+	secureRoutes.Handle(app.URLPaths.TrashHandler, handlers.NewTrashHandler(app.TrashService))
+	secureRoutes.Handle(app.URLPaths.TrashBoardHandler, handlers.NewTrashRestoreHandler(app.TrashService))
+	secureRoutes.Handle(app.URLPaths.TrashTaskHandler, handlers.NewTrashRestoreHandler(app.TrashService))
+	secureRoutes.Handle(
+		app.URLPaths.BoardCloneHandler,
+		app.boardAccess(
+			handlers.NewBoardCloneHandler(app.BoardCloneService, app.BoardMemberService, app.Validate),
+			access.CloneRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardTemplateHandler,
+		app.boardAccess(handlers.NewBoardTemplateHandler(app.BoardCloneService, app.Validate), access.CloneRequirements),
+	)
+	secureRoutes.Handle(app.URLPaths.TemplatesHandler, handlers.NewTemplateHandler(app.BoardCloneService, app.Validate))
+	secureRoutes.Handle(
+		app.URLPaths.TemplateBoardsHandler,
+		app.boardAccess(handlers.NewTemplateHandler(app.BoardCloneService, app.Validate), access.CloneRequirements),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardExportHandler,
+		app.boardAccess(handlers.NewBoardExportHandler(app.BoardExportService), access.ExportRequirements),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardImportHandler,
+		handlers.NewBoardImportHandler(app.BoardExportService, app.Validate, app.Env.ImportMaxSize),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TaskImportHandler,
+		app.boardAccess(
+			handlers.NewTaskImportHandler(app.TaskImportService, app.Validate, app.Env.ImportMaxSize),
+			access.TasksRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.TrelloImportHandler,
+		handlers.NewTrelloImportHandler(app.Importer, app.Env.ImportMaxSize),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardEventsHandler,
+		app.boardAccess(
+			handlers.NewBoardEventsHandler(app.BoardEventHub, handlers.DefaultHeartbeatInterval),
+			access.ReadRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardSessionHandler,
+		app.boardAccess(
+			handlers.NewBoardSessionHandler(
+				app.BoardSessionHub,
+				app.BoardEventHub,
+				app.TaskService,
+				app.Validate,
+				handlers.DefaultPingInterval,
+				app.AllowedOrigins,
+			),
+			access.ReadRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardWebhooksHandler,
+		app.boardAccess(
+			handlers.NewBoardWebhookHandler(app.WebhookService, app.BoardService, app.Validate),
+			access.WebhooksRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.BoardWebhookHandler,
+		app.boardAccess(
+			handlers.NewBoardWebhookHandler(app.WebhookService, app.BoardService, app.Validate),
+			access.WebhooksRequirements,
+		),
+	)
+	secureRoutes.Handle(
+		app.URLPaths.WebhookDeliveriesHandler,
+		app.boardAccess(
+			handlers.NewWebhookDeliveriesHandler(app.WebhookService, app.Validate),
+			access.WebhooksRequirements,
+		),
+	)
+}
+
+// boardAccess guards handler of board route, so only board members with roles meeting requirements reach it
+func (app *App) boardAccess(handler http.Handler, requirements access.Requirements) http.Handler {
+	return middlewares.BoardAccess(handler, app.BoardMemberService, requirements)
 }
 
 func (app *App) initPublicHandlers() {
@@ -155,27 +573,107 @@ func (app *App) initRouter() {
 	app.initSecureHandlers()
 }
 
+// runBackgroundJobs starts goroutines which work independently of http requests
+func (app *App) runBackgroundJobs() {
+	app.ReminderService.Start(context.Background())
+	app.RetentionService.Start(context.Background())
+	app.BoardEventHub.Start(context.Background())
+	app.BoardSessionHub.Start(context.Background())
+	app.WebhookDispatcher.Start(context.Background())
+}
+
+// stopBackgroundJobs stops goroutines started by runBackgroundJobs and waits for them
+func (app *App) stopBackgroundJobs() {
+	app.ReminderService.Stop()
+	app.RetentionService.Stop()
+	app.BoardEventHub.Stop()
+	app.BoardSessionHub.Stop()
+	app.WebhookDispatcher.Stop()
+}
+
 func (app *App) runListen() {
 	jsonHandler := middlewares.JSONResponse(app.ServeMux)
 	logHandler := middlewares.Log(jsonHandler)
 	corsHandler := middlewares.CORS(logHandler, map[string][]string{
-		app.URLPaths.RegistrationHandler:  app.AllowedHTTPMethods.RegistrationHandler,
-		app.URLPaths.LoginHandler:         app.AllowedHTTPMethods.LoginHandler,
-		app.URLPaths.LogoutHandler:        app.AllowedHTTPMethods.LogoutHandler,
-		app.URLPaths.RefreshAccessHandler: app.AllowedHTTPMethods.RefreshAccessHandler,
-		app.URLPaths.UsersHandler:         app.AllowedHTTPMethods.UsersHandler,
-		app.URLPaths.UserHandler:          app.AllowedHTTPMethods.UserHandler,
-		app.URLPaths.BoardsHandler:        app.AllowedHTTPMethods.BoardsHandler,
-		app.URLPaths.BoardHandler:         app.AllowedHTTPMethods.BoardHandler,
-		app.URLPaths.BoardMembersHandler:  app.AllowedHTTPMethods.BoardMembersHandler,
-		app.URLPaths.BoardMemberHandler:   app.AllowedHTTPMethods.BoardMemberHandler,
-	})
+		app.URLPaths.RegistrationHandler:      app.AllowedHTTPMethods.RegistrationHandler,
+		app.URLPaths.LoginHandler:             app.AllowedHTTPMethods.LoginHandler,
+		app.URLPaths.LogoutHandler:            app.AllowedHTTPMethods.LogoutHandler,
+		app.URLPaths.RefreshAccessHandler:     app.AllowedHTTPMethods.RefreshAccessHandler,
+		app.URLPaths.UsersHandler:             app.AllowedHTTPMethods.UsersHandler,
+		app.URLPaths.UserHandler:              app.AllowedHTTPMethods.UserHandler,
+		app.URLPaths.BoardsHandler:            app.AllowedHTTPMethods.BoardsHandler,
+		app.URLPaths.BoardHandler:             app.AllowedHTTPMethods.BoardHandler,
+		app.URLPaths.BoardMembersHandler:      app.AllowedHTTPMethods.BoardMembersHandler,
+		app.URLPaths.BoardMemberHandler:       app.AllowedHTTPMethods.BoardMemberHandler,
+		app.URLPaths.BoardColumnsHandler:      app.AllowedHTTPMethods.BoardColumnsHandler,
+		app.URLPaths.BoardColumnHandler:       app.AllowedHTTPMethods.BoardColumnHandler,
+		app.URLPaths.TaskMoveHandler:          app.AllowedHTTPMethods.TaskMoveHandler,
+		app.URLPaths.CommentsHandler:          app.AllowedHTTPMethods.CommentsHandler,
+		app.URLPaths.CommentHandler:           app.AllowedHTTPMethods.CommentHandler,
+		app.URLPaths.LabelsHandler:            app.AllowedHTTPMethods.LabelsHandler,
+		app.URLPaths.LabelHandler:             app.AllowedHTTPMethods.LabelHandler,
+		app.URLPaths.ChecklistHandler:         app.AllowedHTTPMethods.ChecklistHandler,
+		app.URLPaths.ChecklistItemHandler:     app.AllowedHTTPMethods.ChecklistItemHandler,
+		app.URLPaths.TaskHistoryHandler:       app.AllowedHTTPMethods.TaskHistoryHandler,
+		app.URLPaths.BoardActivityHandler:     app.AllowedHTTPMethods.BoardActivityHandler,
+		app.URLPaths.AttachmentsHandler:       app.AllowedHTTPMethods.AttachmentsHandler,
+		app.URLPaths.AttachmentHandler:        app.AllowedHTTPMethods.AttachmentHandler,
+		app.URLPaths.TaskDependenciesHandler:  app.AllowedHTTPMethods.TaskDependenciesHandler,
+		app.URLPaths.TaskDependencyHandler:    app.AllowedHTTPMethods.TaskDependencyHandler,
+		app.URLPaths.SearchHandler:            app.AllowedHTTPMethods.SearchHandler,
+		app.URLPaths.BoardRolesHandler:        app.AllowedHTTPMethods.BoardRolesHandler,
+		app.URLPaths.BoardRoleHandler:         app.AllowedHTTPMethods.BoardRoleHandler,
+		app.URLPaths.BoardInvitationsHandler:  app.AllowedHTTPMethods.BoardInvitationsHandler,
+		app.URLPaths.BoardInvitationHandler:   app.AllowedHTTPMethods.BoardInvitationHandler,
+		app.URLPaths.InvitationsHandler:       app.AllowedHTTPMethods.InvitationsHandler,
+		app.URLPaths.InvitationAcceptHandler:  app.AllowedHTTPMethods.InvitationAcceptHandler,
+		app.URLPaths.InvitationDeclineHandler: app.AllowedHTTPMethods.InvitationDeclineHandler,
+		app.URLPaths.TransferOwnershipHandler: app.AllowedHTTPMethods.TransferOwnershipHandler,
+		app.URLPaths.BoardArchiveHandler:      app.AllowedHTTPMethods.BoardArchiveHandler,
+		app.URLPaths.TaskArchiveHandler:       app.AllowedHTTPMethods.TaskArchiveHandler,
+		app.URLPaths.TrashHandler:             app.AllowedHTTPMethods.TrashHandler,
+		app.URLPaths.TrashBoardHandler:        app.AllowedHTTPMethods.TrashBoardHandler,
+		app.URLPaths.TrashTaskHandler:         app.AllowedHTTPMethods.TrashTaskHandler,
+		app.URLPaths.BoardCloneHandler:        app.AllowedHTTPMethods.BoardCloneHandler,
+		app.URLPaths.BoardTemplateHandler:     app.AllowedHTTPMethods.BoardTemplateHandler,
+		app.URLPaths.TemplatesHandler:         app.AllowedHTTPMethods.TemplatesHandler,
+		app.URLPaths.TemplateBoardsHandler:    app.AllowedHTTPMethods.TemplateBoardsHandler,
+		app.URLPaths.BoardExportHandler:       app.AllowedHTTPMethods.BoardExportHandler,
+		app.URLPaths.BoardImportHandler:       app.AllowedHTTPMethods.BoardImportHandler,
+		app.URLPaths.TaskImportHandler:        app.AllowedHTTPMethods.TaskImportHandler,
+		app.URLPaths.TrelloImportHandler:      app.AllowedHTTPMethods.TrelloImportHandler,
+		app.URLPaths.BoardEventsHandler:       app.AllowedHTTPMethods.BoardEventsHandler,
+		app.URLPaths.BoardSessionHandler:      app.AllowedHTTPMethods.BoardSessionHandler,
+		app.URLPaths.BoardWebhooksHandler:     app.AllowedHTTPMethods.BoardWebhooksHandler,
+		app.URLPaths.BoardWebhookHandler:      app.AllowedHTTPMethods.BoardWebhookHandler,
+		app.URLPaths.WebhookDeliveriesHandler: app.AllowedHTTPMethods.WebhookDeliveriesHandler,
+	}, app.AllowedOrigins)
+	server := &http.Server{
+		Addr:    fmt.Sprintf("0.0.0.0:%s", app.ServerPort),
+		Handler: corsHandler,
+	}
+	// event streams stay open until client leaves, so they're ended for shutdown not to wait for them
+	server.RegisterOnShutdown(app.BoardEventHub.Close)
+	// hijacked WebSocket connections aren't tracked by server, so sessions are closed explicitly
+	server.RegisterOnShutdown(app.BoardSessionHub.Close)
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+	shutdownDone := make(chan struct{})
+	go func() {
+		defer close(shutdownDone)
+		<-signalCtx.Done()
+		log.Println("Shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+			log.Println("Server shutdown failed:", shutdownErr)
+		}
+		app.stopBackgroundJobs()
+	}()
 	log.Println("Start listening on " + "0.0.0.0:" + app.ServerPort)
-	runErr := http.ListenAndServe(
-		fmt.Sprintf("0.0.0.0:%s", app.ServerPort),
-		corsHandler,
-	)
-	if runErr != nil {
+	runErr := server.ListenAndServe()
+	if runErr != nil && !errors.Is(runErr, http.ErrServerClosed) {
 		panic(runErr)
 	}
+	<-shutdownDone
 }
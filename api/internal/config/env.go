@@ -5,6 +5,7 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"os"
@@ -28,8 +29,31 @@ type Env struct {
 	DBPassword string
 	// DBName is name of database app works with
 	DBName string
+	// StorageDir is directory where uploaded files are stored
+	StorageDir string
+	// AttachmentMaxSize is max size of single uploaded task attachment in bytes
+	AttachmentMaxSize int64
+	// ImportMaxSize is max size of imported document in bytes
+	ImportMaxSize int64
+	// CursorSecret is secret string for signing page cursors, JWTSecret is used if it isn't set
+	CursorSecret string
+	// PageMaxSize is max count of records returned in single page of listings
+	PageMaxSize int64
+	// TrashRetentionDays is count of days boards and tasks are kept in trash before they're purged
+	TrashRetentionDays int64
+	// AllowedOrigins are origins of web clients hosted apart from app, they're comma separated in env.
+	// Any origin may send cross-origin requests if it's empty, but WebSocket connections are same-origin only then
+	AllowedOrigins []string
 }
 
+const (
+	defaultStorageDir         = "storage"
+	defaultAttachmentMaxSize  = 10 << 20
+	defaultImportMaxSize      = 20 << 20
+	defaultPageMaxSize        = 100
+	defaultTrashRetentionDays = 30
+)
+
 func loadEnvFile() {
 	for dirOutStepsCount := 0; dirOutStepsCount < 3; dirOutStepsCount++ {
 		envFilePath := filepath.Join(strings.Repeat("../", dirOutStepsCount), ".env")
@@ -52,13 +76,52 @@ func loadEnvFile() {
 // NewEnv loads env variables from .env file and returns structure with those fields
 func NewEnv() *Env {
 	return &Env{
-		JWTSecret:  os.Getenv("JWT_SECRET"),
-		ServerPort: os.Getenv("SERVER_PORT"),
-		ServerHost: os.Getenv("SERVER_HOST"),
-		DBHost:     os.Getenv("DB_HOST"),
-		DBPort:     os.Getenv("DB_PORT"),
-		DBUser:     os.Getenv("DB_USER"),
-		DBPassword: os.Getenv("DB_PASSWORD"),
-		DBName:     os.Getenv("DB_NAME"),
+		JWTSecret:          os.Getenv("JWT_SECRET"),
+		ServerPort:         os.Getenv("SERVER_PORT"),
+		ServerHost:         os.Getenv("SERVER_HOST"),
+		DBHost:             os.Getenv("DB_HOST"),
+		DBPort:             os.Getenv("DB_PORT"),
+		DBUser:             os.Getenv("DB_USER"),
+		DBPassword:         os.Getenv("DB_PASSWORD"),
+		DBName:             os.Getenv("DB_NAME"),
+		StorageDir:         getEnvOrDefault("STORAGE_DIR", defaultStorageDir),
+		AttachmentMaxSize:  getEnvInt64OrDefault("ATTACHMENT_MAX_SIZE", defaultAttachmentMaxSize),
+		ImportMaxSize:      getEnvInt64OrDefault("IMPORT_MAX_SIZE", defaultImportMaxSize),
+		CursorSecret:       getEnvOrDefault("CURSOR_SECRET", os.Getenv("JWT_SECRET")),
+		PageMaxSize:        getEnvInt64OrDefault("PAGE_MAX_SIZE", defaultPageMaxSize),
+		TrashRetentionDays: getEnvInt64OrDefault("TRASH_RETENTION_DAYS", defaultTrashRetentionDays),
+		AllowedOrigins:     getEnvList("ALLOWED_ORIGINS"),
+	}
+}
+
+// getEnvOrDefault returns env variable value or defaultValue if variable isn't set
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList returns comma separated values of env variable, empty values are skipped
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvInt64OrDefault returns env variable value as integer or defaultValue if variable isn't set
+func getEnvInt64OrDefault(key string, defaultValue int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsedValue, parseErr := strconv.ParseInt(value, 10, 64)
+	if parseErr != nil {
+		panic(fmt.Sprintf("env variable %s must be integer: %v", key, parseErr))
 	}
+	return parsedValue
 }
@@ -14,6 +14,26 @@ const (
 	ParamBoardMemberID = "boardMemberId"
 	// ParamTaskOrder is name of path param which represents order of task on board
 	ParamTaskOrder = "taskOrder"
+	// ParamColumnID is name of path param which represents board column identifier
+	ParamColumnID = "columnId"
+	// ParamCommentID is name of path param which represents task comment identifier
+	ParamCommentID = "commentId"
+	// ParamLabelID is name of path param which represents board label identifier
+	ParamLabelID = "labelId"
+	// ParamChecklistItemID is name of path param which represents task checklist item identifier
+	ParamChecklistItemID = "itemId"
+	// ParamAttachmentID is name of path param which represents task attachment identifier
+	ParamAttachmentID = "attachmentId"
+	// ParamLinkedTaskID is name of path param which represents identifier of task linked by dependency
+	ParamLinkedTaskID = "linkedTaskId"
+	// ParamRoleID is name of path param which represents custom board role identifier
+	ParamRoleID = "roleId"
+	// ParamInvitationID is name of path param which represents board invitation identifier
+	ParamInvitationID = "invitationId"
+	// ParamTaskID is name of path param which represents task identifier
+	ParamTaskID = "taskId"
+	// ParamWebhookID is name of path param which represents board webhook identifier
+	ParamWebhookID = "webhookId"
 )
 
 // URLPaths defines url paths which used by app router
@@ -21,33 +41,117 @@ type URLPaths struct {
 	// BoardsHandler is url path to handlers.BoardHandler methods for working with multiple records
 	BoardsHandler string
 	// BoardsHandler is url path to handlers.BoardHandler methods for working with single record
-	BoardHandler         string
-	BoardMembersHandler  string
-	BoardMemberHandler   string
-	RefreshAccessHandler string
-	RegistrationHandler  string
-	LoginHandler         string
-	LogoutHandler        string
-	TasksHandler         string
-	TaskHandler          string
-	UsersHandler         string
-	UserHandler          string
+	BoardHandler             string
+	BoardMembersHandler      string
+	BoardMemberHandler       string
+	BoardColumnsHandler      string
+	BoardColumnHandler       string
+	RefreshAccessHandler     string
+	RegistrationHandler      string
+	LoginHandler             string
+	LogoutHandler            string
+	TasksHandler             string
+	TaskHandler              string
+	TaskMoveHandler          string
+	CommentsHandler          string
+	CommentHandler           string
+	LabelsHandler            string
+	LabelHandler             string
+	ChecklistHandler         string
+	ChecklistItemHandler     string
+	TaskHistoryHandler       string
+	BoardActivityHandler     string
+	AttachmentsHandler       string
+	AttachmentHandler        string
+	TaskDependenciesHandler  string
+	TaskDependencyHandler    string
+	SearchHandler            string
+	BoardRolesHandler        string
+	BoardRoleHandler         string
+	BoardInvitationsHandler  string
+	BoardInvitationHandler   string
+	InvitationsHandler       string
+	InvitationAcceptHandler  string
+	InvitationDeclineHandler string
+	TransferOwnershipHandler string
+	BoardArchiveHandler      string
+	TaskArchiveHandler       string
+	TrashHandler             string
+	TrashBoardHandler        string
+	TrashTaskHandler         string
+	BoardCloneHandler        string
+	BoardTemplateHandler     string
+	TemplatesHandler         string
+	TemplateBoardsHandler    string
+	BoardExportHandler       string
+	BoardImportHandler       string
+	TaskImportHandler        string
+	TrelloImportHandler      string
+	BoardEventsHandler       string
+	BoardSessionHandler      string
+	BoardWebhooksHandler     string
+	BoardWebhookHandler      string
+	WebhookDeliveriesHandler string
+	UsersHandler             string
+	UserHandler              string
 }
 
 // AllowedHTTPMethods defines allowed http methods for handlers in URLPaths
 type AllowedHTTPMethods struct {
-	BoardsHandler        []string
-	BoardHandler         []string
-	BoardMembersHandler  []string
-	BoardMemberHandler   []string
-	LoginHandler         []string
-	LogoutHandler        []string
-	RefreshAccessHandler []string
-	RegistrationHandler  []string
-	UsersHandler         []string
-	UserHandler          []string
-	TasksHandler         []string
-	TaskHandler          []string
+	BoardsHandler            []string
+	BoardHandler             []string
+	BoardMembersHandler      []string
+	BoardMemberHandler       []string
+	BoardColumnsHandler      []string
+	BoardColumnHandler       []string
+	LoginHandler             []string
+	LogoutHandler            []string
+	RefreshAccessHandler     []string
+	RegistrationHandler      []string
+	UsersHandler             []string
+	UserHandler              []string
+	TasksHandler             []string
+	TaskHandler              []string
+	TaskMoveHandler          []string
+	CommentsHandler          []string
+	CommentHandler           []string
+	LabelsHandler            []string
+	LabelHandler             []string
+	ChecklistHandler         []string
+	ChecklistItemHandler     []string
+	TaskHistoryHandler       []string
+	BoardActivityHandler     []string
+	AttachmentsHandler       []string
+	AttachmentHandler        []string
+	TaskDependenciesHandler  []string
+	TaskDependencyHandler    []string
+	SearchHandler            []string
+	BoardRolesHandler        []string
+	BoardRoleHandler         []string
+	BoardInvitationsHandler  []string
+	BoardInvitationHandler   []string
+	InvitationsHandler       []string
+	InvitationAcceptHandler  []string
+	InvitationDeclineHandler []string
+	TransferOwnershipHandler []string
+	BoardArchiveHandler      []string
+	TaskArchiveHandler       []string
+	TrashHandler             []string
+	TrashBoardHandler        []string
+	TrashTaskHandler         []string
+	BoardCloneHandler        []string
+	BoardTemplateHandler     []string
+	TemplatesHandler         []string
+	TemplateBoardsHandler    []string
+	BoardExportHandler       []string
+	BoardImportHandler       []string
+	TaskImportHandler        []string
+	TrelloImportHandler      []string
+	BoardEventsHandler       []string
+	BoardSessionHandler      []string
+	BoardWebhooksHandler     []string
+	BoardWebhookHandler      []string
+	WebhookDeliveriesHandler []string
 }
 
 // NewHTTPPaths returns config for working with http routing in app
@@ -63,21 +167,125 @@ func NewHTTPPaths() (*URLPaths, *AllowedHTTPMethods) {
 		BoardHandler:         fmt.Sprintf("/boards/{%s}", ParamBoardID),
 		BoardMembersHandler:  fmt.Sprintf("/boards/{%s}/members", ParamBoardID),
 		BoardMemberHandler:   fmt.Sprintf("/boards/{%s}/members/{%s}", ParamBoardID, ParamBoardMemberID),
+		BoardColumnsHandler:  fmt.Sprintf("/boards/{%s}/columns", ParamBoardID),
+		BoardColumnHandler:   fmt.Sprintf("/boards/{%s}/columns/{%s}", ParamBoardID, ParamColumnID),
 		TasksHandler:         fmt.Sprintf("/boards/{%s}/tasks", ParamBoardID),
 		TaskHandler:          fmt.Sprintf("/boards/{%s}/tasks/{%s}", ParamBoardID, ParamTaskOrder),
+		TaskMoveHandler:      fmt.Sprintf("/boards/{%s}/tasks/{%s}/move", ParamBoardID, ParamTaskOrder),
+		CommentsHandler:      fmt.Sprintf("/boards/{%s}/tasks/{%s}/comments", ParamBoardID, ParamTaskOrder),
+		CommentHandler: fmt.Sprintf(
+			"/boards/{%s}/tasks/{%s}/comments/{%s}",
+			ParamBoardID,
+			ParamTaskOrder,
+			ParamCommentID,
+		),
+		LabelsHandler:    fmt.Sprintf("/boards/{%s}/labels", ParamBoardID),
+		LabelHandler:     fmt.Sprintf("/boards/{%s}/labels/{%s}", ParamBoardID, ParamLabelID),
+		ChecklistHandler: fmt.Sprintf("/boards/{%s}/tasks/{%s}/checklist", ParamBoardID, ParamTaskOrder),
+		ChecklistItemHandler: fmt.Sprintf(
+			"/boards/{%s}/tasks/{%s}/checklist/{%s}",
+			ParamBoardID,
+			ParamTaskOrder,
+			ParamChecklistItemID,
+		),
+		TaskHistoryHandler:   fmt.Sprintf("/boards/{%s}/tasks/{%s}/history", ParamBoardID, ParamTaskOrder),
+		BoardActivityHandler: fmt.Sprintf("/boards/{%s}/activity", ParamBoardID),
+		AttachmentsHandler:   fmt.Sprintf("/boards/{%s}/tasks/{%s}/attachments", ParamBoardID, ParamTaskOrder),
+		AttachmentHandler: fmt.Sprintf(
+			"/boards/{%s}/tasks/{%s}/attachments/{%s}",
+			ParamBoardID,
+			ParamTaskOrder,
+			ParamAttachmentID,
+		),
+		TaskDependenciesHandler: fmt.Sprintf("/boards/{%s}/tasks/{%s}/dependencies", ParamBoardID, ParamTaskOrder),
+		TaskDependencyHandler: fmt.Sprintf(
+			"/boards/{%s}/tasks/{%s}/dependencies/{%s}",
+			ParamBoardID,
+			ParamTaskOrder,
+			ParamLinkedTaskID,
+		),
+		SearchHandler:            "/search",
+		BoardRolesHandler:        fmt.Sprintf("/boards/{%s}/roles", ParamBoardID),
+		BoardRoleHandler:         fmt.Sprintf("/boards/{%s}/roles/{%s}", ParamBoardID, ParamRoleID),
+		BoardInvitationsHandler:  fmt.Sprintf("/boards/{%s}/invitations", ParamBoardID),
+		BoardInvitationHandler:   fmt.Sprintf("/boards/{%s}/invitations/{%s}", ParamBoardID, ParamInvitationID),
+		InvitationsHandler:       "/invitations",
+		InvitationAcceptHandler:  "/invitations/accept",
+		InvitationDeclineHandler: "/invitations/decline",
+		TransferOwnershipHandler: fmt.Sprintf("/boards/{%s}/transfer-ownership", ParamBoardID),
+		BoardArchiveHandler:      fmt.Sprintf("/boards/{%s}/archive", ParamBoardID),
+		TaskArchiveHandler:       fmt.Sprintf("/boards/{%s}/tasks/{%s}/archive", ParamBoardID, ParamTaskOrder),
+		TrashHandler:             "/trash",
+		TrashBoardHandler:        fmt.Sprintf("/trash/boards/{%s}/restore", ParamBoardID),
+		TrashTaskHandler:         fmt.Sprintf("/trash/tasks/{%s}/restore", ParamTaskID),
+		BoardCloneHandler:        fmt.Sprintf("/boards/{%s}/clone", ParamBoardID),
+		BoardTemplateHandler:     fmt.Sprintf("/boards/{%s}/template", ParamBoardID),
+		TemplatesHandler:         "/templates",
+		TemplateBoardsHandler:    fmt.Sprintf("/templates/{%s}/boards", ParamBoardID),
+		BoardExportHandler:       fmt.Sprintf("/boards/{%s}/export", ParamBoardID),
+		BoardImportHandler:       "/boards/import",
+		TaskImportHandler:        fmt.Sprintf("/boards/{%s}/tasks/import", ParamBoardID),
+		TrelloImportHandler:      "/boards/import/trello",
+		BoardEventsHandler:       fmt.Sprintf("/boards/{%s}/events", ParamBoardID),
+		BoardSessionHandler:      fmt.Sprintf("/boards/{%s}/session", ParamBoardID),
+		BoardWebhooksHandler:     fmt.Sprintf("/boards/{%s}/webhooks", ParamBoardID),
+		BoardWebhookHandler:      fmt.Sprintf("/boards/{%s}/webhooks/{%s}", ParamBoardID, ParamWebhookID),
+		WebhookDeliveriesHandler: fmt.Sprintf("/boards/{%s}/webhooks/{%s}/deliveries", ParamBoardID, ParamWebhookID),
 	}
 	allowedMethods := &AllowedHTTPMethods{
-		BoardsHandler:        []string{http.MethodGet, http.MethodPost},
-		BoardHandler:         []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
-		BoardMembersHandler:  []string{http.MethodGet, http.MethodPost},
-		BoardMemberHandler:   []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
-		LoginHandler:         []string{http.MethodPost},
-		LogoutHandler:        []string{http.MethodPost},
-		RefreshAccessHandler: []string{http.MethodPost},
-		RegistrationHandler:  []string{http.MethodPost},
-		UsersHandler:         []string{http.MethodGet},
-		UserHandler:          []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
-		TasksHandler:         []string{http.MethodGet, http.MethodPost, http.MethodDelete},
+		BoardsHandler:            []string{http.MethodGet, http.MethodPost},
+		BoardHandler:             []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		BoardMembersHandler:      []string{http.MethodGet, http.MethodPost},
+		BoardMemberHandler:       []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		BoardColumnsHandler:      []string{http.MethodGet, http.MethodPost, http.MethodPut},
+		BoardColumnHandler:       []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		LoginHandler:             []string{http.MethodPost},
+		LogoutHandler:            []string{http.MethodPost},
+		RefreshAccessHandler:     []string{http.MethodPost},
+		RegistrationHandler:      []string{http.MethodPost},
+		UsersHandler:             []string{http.MethodGet},
+		UserHandler:              []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		TasksHandler:             []string{http.MethodGet, http.MethodPost, http.MethodDelete},
+		TaskMoveHandler:          []string{http.MethodPost},
+		CommentsHandler:          []string{http.MethodGet, http.MethodPost},
+		CommentHandler:           []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		LabelsHandler:            []string{http.MethodGet, http.MethodPost},
+		LabelHandler:             []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		ChecklistHandler:         []string{http.MethodGet, http.MethodPost, http.MethodPut},
+		ChecklistItemHandler:     []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		TaskHistoryHandler:       []string{http.MethodGet},
+		BoardActivityHandler:     []string{http.MethodGet},
+		AttachmentsHandler:       []string{http.MethodGet, http.MethodPost},
+		AttachmentHandler:        []string{http.MethodGet, http.MethodDelete},
+		TaskDependenciesHandler:  []string{http.MethodGet, http.MethodPost},
+		TaskDependencyHandler:    []string{http.MethodDelete},
+		SearchHandler:            []string{http.MethodGet},
+		BoardRolesHandler:        []string{http.MethodGet, http.MethodPost},
+		BoardRoleHandler:         []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		BoardInvitationsHandler:  []string{http.MethodGet, http.MethodPost},
+		BoardInvitationHandler:   []string{http.MethodGet, http.MethodDelete},
+		InvitationsHandler:       []string{http.MethodGet},
+		InvitationAcceptHandler:  []string{http.MethodPost},
+		InvitationDeclineHandler: []string{http.MethodPost},
+		TransferOwnershipHandler: []string{http.MethodPost},
+		BoardArchiveHandler:      []string{http.MethodPost, http.MethodDelete},
+		TaskArchiveHandler:       []string{http.MethodPost, http.MethodDelete},
+		TrashHandler:             []string{http.MethodGet},
+		TrashBoardHandler:        []string{http.MethodPost},
+		TrashTaskHandler:         []string{http.MethodPost},
+		BoardCloneHandler:        []string{http.MethodPost},
+		BoardTemplateHandler:     []string{http.MethodPost},
+		TemplatesHandler:         []string{http.MethodGet},
+		TemplateBoardsHandler:    []string{http.MethodPost},
+		BoardExportHandler:       []string{http.MethodGet},
+		BoardImportHandler:       []string{http.MethodPost},
+		TaskImportHandler:        []string{http.MethodPost},
+		TrelloImportHandler:      []string{http.MethodPost},
+		BoardEventsHandler:       []string{http.MethodGet},
+		BoardSessionHandler:      []string{http.MethodGet},
+		BoardWebhooksHandler:     []string{http.MethodGet, http.MethodPost},
+		BoardWebhookHandler:      []string{http.MethodGet, http.MethodPatch, http.MethodDelete},
+		WebhookDeliveriesHandler: []string{http.MethodGet},
 	}
 	return paths, allowedMethods
 }
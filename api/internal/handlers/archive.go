@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+)
+
+// BoardArchiveHandler handles http requests for archiving boards with methods of services.BoardService
+type BoardArchiveHandler struct {
+	*services.BoardService
+}
+
+// NewBoardArchiveHandler creates new instance of BoardArchiveHandler
+func NewBoardArchiveHandler(bs *services.BoardService) *BoardArchiveHandler {
+	return &BoardArchiveHandler{bs}
+}
+
+func (bah *BoardArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	var board *models.Board
+	var archiveErr error
+	switch r.Method {
+	case http.MethodPost:
+		board, archiveErr = bah.ArchiveBoard(ctx, boardId)
+	case http.MethodDelete:
+		board, archiveErr = bah.UnarchiveBoard(ctx, boardId)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if archiveErr != nil {
+		http.Error(w, archiveErr.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(board)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// TaskArchiveHandler handles http requests for archiving tasks with methods of services.TaskService
+type TaskArchiveHandler struct {
+	*services.TaskService
+}
+
+// NewTaskArchiveHandler creates new instance of TaskArchiveHandler
+func NewTaskArchiveHandler(ts *services.TaskService) *TaskArchiveHandler {
+	return &TaskArchiveHandler{ts}
+}
+
+func (tah *TaskArchiveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	task, searchErr := tah.FindByOrder(ctx, boardId, uint(order))
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	var archivedTask *models.Task
+	var archiveErr error
+	if r.Method == http.MethodPost {
+		archivedTask, archiveErr = tah.ArchiveTask(ctx, task.ID)
+	} else {
+		archivedTask, archiveErr = tah.UnarchiveTask(ctx, task.ID)
+	}
+	if errors.Is(archiveErr, services.ErrorTaskArchived) || errors.Is(archiveErr, services.ErrorWIPLimitExceeded) {
+		http.Error(w, archiveErr.Error(), http.StatusConflict)
+		return
+	}
+	if archiveErr != nil {
+		http.Error(w, archiveErr.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(archivedTask)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
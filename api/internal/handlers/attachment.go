@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
+)
+
+const (
+	// formFieldFile is name of multipart form field which contains uploaded file
+	formFieldFile = "file"
+	// multipartOverhead is allowed size of multipart body besides file content
+	multipartOverhead = 1 << 20
+)
+
+var noFileFieldErr = errors.New("multipart form must contain file field")
+
+// AttachmentHandler handles http requests for working with methods of services.AttachmentService
+type AttachmentHandler struct {
+	*services.AttachmentService
+	*services.TaskService
+}
+
+// NewAttachmentHandler creates new instance of AttachmentHandler
+func NewAttachmentHandler(as *services.AttachmentService, ts *services.TaskService) *AttachmentHandler {
+	return &AttachmentHandler{as, ts}
+}
+
+func (ah *AttachmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := ah.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	task, searchErr := ah.TaskService.FindByOrder(ctx, boardId, uint(order))
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	attachmentIdParam := r.PathValue(config.ParamAttachmentID)
+	if attachmentIdParam == "" {
+		ah.handleMultipleAttachments(ctx, w, r, task)
+	} else {
+		ah.handleSingleAttachment(ctx, w, r, task, sqlddl.ID(attachmentIdParam))
+	}
+}
+
+func (ah *AttachmentHandler) handleMultipleAttachments(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		attachments, searchErr := ah.ListTaskAttachments(ctx, task)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(attachments)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, ah.MaxSize+multipartOverhead)
+		createdAttachment, uploadErr := ah.uploadFilePart(ctx, r, task)
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.Is(uploadErr, services.ErrorAttachmentTooLarge), errors.As(uploadErr, &maxBytesErr):
+			http.Error(w, services.ErrorAttachmentTooLarge.Error(), http.StatusRequestEntityTooLarge)
+			return
+		case errors.Is(uploadErr, services.ErrorAttachmentTypeNotAllowed):
+			http.Error(w, uploadErr.Error(), http.StatusUnsupportedMediaType)
+			return
+		case uploadErr != nil:
+			http.Error(w, uploadErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdAttachment)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (ah *AttachmentHandler) handleSingleAttachment(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+	attachmentId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		attachment, content, openErr := ah.OpenAttachment(ctx, task, attachmentId)
+		if openErr != nil {
+			http.Error(w, openErr.Error(), http.StatusNotFound)
+			return
+		}
+		defer content.Close()
+		w.Header().Set(tcp.HeaderContentType, attachment.ContentType)
+		w.Header().Set(tcp.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set(tcp.HeaderContentTypeOptions, tcp.ContentTypeOptionsNoSniff)
+		w.Header().Set(
+			tcp.HeaderContentDisposition,
+			mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		)
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, content)
+	case http.MethodDelete:
+		deleteErr := ah.DeleteAttachment(ctx, task, attachmentId)
+		if errors.Is(deleteErr, services.ErrorAttachmentNotAllowed) {
+			http.Error(w, deleteErr.Error(), http.StatusForbidden)
+			return
+		}
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// uploadFilePart streams file field of multipart request body to attachment storage without buffering it
+func (ah *AttachmentHandler) uploadFilePart(
+	ctx context.Context,
+	r *http.Request,
+	task *models.Task,
+) (*models.Attachment, error) {
+	reader, readerErr := r.MultipartReader()
+	if readerErr != nil {
+		return nil, readerErr
+	}
+	for {
+		part, partErr := reader.NextPart()
+		if errors.Is(partErr, io.EOF) {
+			return nil, noFileFieldErr
+		}
+		if partErr != nil {
+			return nil, partErr
+		}
+		if part.FormName() == formFieldFile {
+			defer part.Close()
+			return ah.UploadAttachment(ctx, task, part.FileName(), part)
+		}
+		part.Close()
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"just-kanban/internal/access"
@@ -10,6 +11,7 @@ import (
 	"just-kanban/internal/contextkeys"
 	"just-kanban/internal/services"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
 	"just-kanban/pkg/validation"
 )
 
@@ -50,13 +52,36 @@ func (bh *BoardHandler) handleMultipleBoards(
 ) {
 	switch r.Method {
 	case http.MethodGet:
-		boards, fetchErr := bh.FindAllBoards(ctx)
+		pageData, parseErr := parseKeysetPageData(r)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		archived, parseErr := parseArchived(r)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		listData := services.ListBoardsData{Archived: archived, KeysetPageData: *pageData}
+		if validationErr := bh.Validate.Struct(listData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		page, fetchErr := bh.ListBoardsPage(ctx, &listData)
+		if errors.Is(fetchErr, services.ErrorInvalidCursor) {
+			http.Error(w, fetchErr.Error(), http.StatusBadRequest)
+			return
+		}
 		if fetchErr != nil {
 			http.Error(w, fetchErr.Error(), http.StatusInternalServerError)
 			return
 		}
+		if page.NextCursor != "" {
+			w.Header().Set(tcp.HeaderLink, nextCursorLink(r, page.NextCursor))
+		}
 		w.WriteHeader(http.StatusOK)
-		encodeErr := json.NewEncoder(w).Encode(boards)
+		encodeErr := json.NewEncoder(w).Encode(page.Boards)
 		if encodeErr != nil {
 			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
 			return
@@ -77,18 +102,8 @@ func (bh *BoardHandler) handleMultipleBoards(
 			http.Error(w, creationErr.Error(), http.StatusInternalServerError)
 			return
 		}
-		_, ownerSetErr := bh.CreateBoardMember(ctx, board.ID, &services.CreateBoardMemberData{
-			UserId: userId,
-			Role:   access.RoleOwner,
-		})
-		if ownerSetErr != nil {
-			bh.DeleteBoard(ctx, board.ID)
-			http.Error(w, ownerSetErr.Error(), http.StatusInternalServerError)
-			return
-		}
-		updatedBoard, _ := bh.FindBoardByID(ctx, board.ID)
 		w.WriteHeader(http.StatusCreated)
-		encodeErr := json.NewEncoder(w).Encode(updatedBoard)
+		encodeErr := json.NewEncoder(w).Encode(board)
 		if encodeErr != nil {
 			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
 			return
@@ -125,7 +140,7 @@ func (bh *BoardHandler) handleSingleBoard(
 			return
 		}
 	case http.MethodDelete:
-		if !bh.IsUserBoardOwner(ctx, userId, boardId) {
+		if !bh.Can(ctx, userId, boardId, access.PermissionBoardDelete) {
 			http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
 			return
 		}
@@ -136,7 +151,7 @@ func (bh *BoardHandler) handleSingleBoard(
 		}
 		w.WriteHeader(http.StatusOK)
 	case http.MethodPatch:
-		if !bh.IsUserAllowedManageBoard(ctx, userId, boardId) {
+		if !bh.Can(ctx, userId, boardId, access.PermissionBoardUpdate) {
 			http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
 			return
 		}
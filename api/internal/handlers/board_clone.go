@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// BoardCloneHandler handles http requests for cloning boards with methods of services.BoardCloneService
+type BoardCloneHandler struct {
+	*services.BoardCloneService
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewBoardCloneHandler creates new instance of BoardCloneHandler
+func NewBoardCloneHandler(
+	bcs *services.BoardCloneService,
+	bms *services.BoardMemberService,
+	validate *validation.Validate,
+) *BoardCloneHandler {
+	return &BoardCloneHandler{bcs, bms, validate}
+}
+
+func (bch *BoardCloneHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	var cloneData services.CloneBoardData
+	if decodeErr := json.NewDecoder(r.Body).Decode(&cloneData); decodeErr != nil {
+		http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := bch.Validate.Struct(cloneData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	userId, _ := contextkeys.GetUserId(ctx)
+	if cloneData.Members && !bch.Can(ctx, userId, boardId, access.PermissionMemberInvite) {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	clone, cloneErr := bch.CloneBoard(ctx, boardId, &cloneData)
+	if cloneErr != nil {
+		http.Error(w, cloneErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeErr := json.NewEncoder(w).Encode(clone)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// BoardTemplateHandler handles http requests for saving boards as templates
+// with methods of services.BoardCloneService
+type BoardTemplateHandler struct {
+	*services.BoardCloneService
+	*validation.Validate
+}
+
+// NewBoardTemplateHandler creates new instance of BoardTemplateHandler
+func NewBoardTemplateHandler(bcs *services.BoardCloneService, validate *validation.Validate) *BoardTemplateHandler {
+	return &BoardTemplateHandler{bcs, validate}
+}
+
+func (bth *BoardTemplateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	var templateData services.SaveTemplateData
+	if decodeErr := json.NewDecoder(r.Body).Decode(&templateData); decodeErr != nil {
+		http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := bth.Validate.Struct(templateData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	template, saveErr := bth.SaveAsTemplate(ctx, boardId, &templateData)
+	if saveErr != nil {
+		http.Error(w, saveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeErr := json.NewEncoder(w).Encode(template)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// BoardColumnHandler handles http requests for working with board columns methods of services.BoardService
+type BoardColumnHandler struct {
+	*services.BoardService
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewBoardColumnHandler creates new instance of BoardColumnHandler
+func NewBoardColumnHandler(
+	bs *services.BoardService,
+	bms *services.BoardMemberService,
+	validate *validation.Validate,
+) *BoardColumnHandler {
+	return &BoardColumnHandler{bs, bms, validate}
+}
+
+func (bch *BoardColumnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	if _, searchErr := bch.FindBoardByID(ctx, boardId); searchErr != nil {
+		http.Error(w, boardNotExistErr.Error(), http.StatusNotFound)
+		return
+	}
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := bch.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet && !bch.Can(ctx, userId, boardId, access.PermissionColumnManage) {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	columnIdParam := r.PathValue(config.ParamColumnID)
+	if columnIdParam == "" {
+		bch.handleMultipleColumns(ctx, w, r, boardId)
+	} else {
+		bch.handleSingleColumn(ctx, w, r, boardId, sqlddl.ID(columnIdParam))
+	}
+}
+
+func (bch *BoardColumnHandler) handleMultipleColumns(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		columns, searchErr := bch.ListBoardColumns(ctx, boardId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(columns)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateBoardColumnData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bch.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdColumn, creationErr := bch.CreateBoardColumn(ctx, boardId, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdColumn)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPut:
+		var reorderData services.ReorderBoardColumnsData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&reorderData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bch.Validate.Struct(reorderData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		columns, reorderErr := bch.ReorderBoardColumns(ctx, boardId, &reorderData)
+		if reorderErr != nil {
+			http.Error(w, reorderErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(columns)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (bch *BoardColumnHandler) handleSingleColumn(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId,
+	columnId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		column, searchErr := bch.FindBoardColumnByID(ctx, boardId, columnId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(column)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateBoardColumnData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bch.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedColumn, updateErr := bch.UpdateBoardColumn(ctx, boardId, columnId, &updateData)
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedColumn)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := bch.DeleteBoardColumn(ctx, boardId, columnId)
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
+)
+
+// DefaultHeartbeatInterval is period of comments sent to idle event streams, so proxies don't close them
+const DefaultHeartbeatInterval = 15 * time.Second
+
+// eventResync tells client that some events are lost and board has to be reloaded
+const eventResync = "resync"
+
+// BoardEventsHandler streams board events of services.BoardEventHub to board members as Server-Sent Events.
+// Client may resume stream by Last-Event-ID header, resync event is sent first if events after it are lost
+type BoardEventsHandler struct {
+	*services.BoardEventHub
+	HeartbeatInterval time.Duration
+}
+
+// NewBoardEventsHandler creates new instance of BoardEventsHandler
+func NewBoardEventsHandler(hub *services.BoardEventHub, heartbeatInterval time.Duration) *BoardEventsHandler {
+	return &BoardEventsHandler{hub, heartbeatInterval}
+}
+
+func (beh *BoardEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	var lastEventId *uint64
+	if header := r.Header.Get(tcp.HeaderLastEventID); header != "" {
+		parsedId, parseErr := strconv.ParseUint(header, 10, 64)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		lastEventId = &parsedId
+	}
+	ctx := r.Context()
+	userId, _ := contextkeys.GetUserId(ctx)
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	subscription, missed, complete := beh.Subscribe(boardId, lastEventId)
+	defer subscription.Close()
+	controller := http.NewResponseController(w)
+	w.Header().Set(tcp.HeaderContentType, tcp.ContentTypeEventStream)
+	w.Header().Set(tcp.HeaderCacheControl, tcp.CacheControlNoCache)
+	w.WriteHeader(http.StatusOK)
+	if !complete {
+		fmt.Fprintf(w, "event: %s\ndata: {}\n\n", eventResync)
+	}
+	for _, event := range missed {
+		if writeErr := writeBoardEvent(w, &event); writeErr != nil {
+			return
+		}
+	}
+	if flushErr := controller.Flush(); flushErr != nil {
+		return
+	}
+	heartbeat := time.NewTicker(beh.HeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, writeErr := io.WriteString(w, ": heartbeat\n\n"); writeErr != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			// closed subscription means client is too slow or server shuts down, client reconnects then
+			if !ok {
+				return
+			}
+			if writeErr := writeBoardEvent(w, &event); writeErr != nil {
+				return
+			}
+			if member, ok := event.Data.(*models.BoardMember); ok &&
+				event.Type == models.BoardEventMemberRemoved && member.UserID == userId {
+				controller.Flush()
+				return
+			}
+		}
+		if flushErr := controller.Flush(); flushErr != nil {
+			return
+		}
+	}
+}
+
+// writeBoardEvent writes event in Server-Sent Events format, its identifier is used by client for resuming
+func writeBoardEvent(w io.Writer, event *models.BoardEvent) error {
+	data, marshalErr := json.Marshal(event)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	_, writeErr := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return writeErr
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
+	"just-kanban/pkg/validation"
+)
+
+// BoardExportHandler handles http requests for exporting boards with methods of services.BoardExportService
+type BoardExportHandler struct {
+	*services.BoardExportService
+}
+
+// NewBoardExportHandler creates new instance of BoardExportHandler
+func NewBoardExportHandler(bes *services.BoardExportService) *BoardExportHandler {
+	return &BoardExportHandler{bes}
+}
+
+func (beh *BoardExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	doc, exportErr := beh.ExportBoard(r.Context(), boardId)
+	if exportErr != nil {
+		http.Error(w, exportErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(
+		tcp.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": "board-" + string(boardId) + ".json"}),
+	)
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(doc)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// importTooLargeErr is returned when imported document is bigger than allowed
+var importTooLargeErr = errors.New("imported document is too large")
+
+// BoardImportHandler handles http requests for importing boards with methods of services.BoardExportService
+type BoardImportHandler struct {
+	*services.BoardExportService
+	*validation.Validate
+	// MaxSize is max size of imported document in bytes
+	MaxSize int64
+}
+
+// NewBoardImportHandler creates new instance of BoardImportHandler
+func NewBoardImportHandler(
+	bes *services.BoardExportService,
+	validate *validation.Validate,
+	maxSize int64,
+) *BoardImportHandler {
+	return &BoardImportHandler{bes, validate, maxSize}
+}
+
+func (bih *BoardImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, bih.MaxSize)
+	var doc models.BoardExport
+	if decodeErr := json.NewDecoder(r.Body).Decode(&doc); decodeErr != nil {
+		writeImportReadErr(w, decodeErr)
+		return
+	}
+	// version is checked before validation since documents of other versions may have different structure
+	if versionErr := services.CheckExportVersion(doc.Version); versionErr != nil {
+		http.Error(w, versionErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := bih.Validate.Struct(doc); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	report, importErr := bih.ImportBoard(r.Context(), &doc)
+	if errors.Is(importErr, services.ErrorInvalidExportedRole) {
+		http.Error(w, importErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if importErr != nil {
+		http.Error(w, importErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeErr := json.NewEncoder(w).Encode(report)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeImportReadErr responds to import whose body can't be read, body bigger than allowed is refused with 413
+func writeImportReadErr(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, importTooLargeErr.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 
+	"just-kanban/internal/access"
 	"just-kanban/internal/config"
 	"just-kanban/internal/contextkeys"
 	"just-kanban/internal/services"
@@ -78,7 +79,7 @@ func (bmh *BoardMemberHandler) handleMultipleMembers(
 			return
 		}
 	case http.MethodPost:
-		if !bmh.BoardMemberService.IsUserAllowedManageBoard(ctx, userId, boardId) {
+		if !bmh.Can(ctx, userId, boardId, access.PermissionMemberInvite) {
 			http.Error(w, notAllowedRequester.Error(), http.StatusBadRequest)
 			return
 		}
@@ -93,10 +94,18 @@ func (bmh *BoardMemberHandler) handleMultipleMembers(
 			json.NewEncoder(w).Encode(validation.FormatValidationErr(err))
 			return
 		}
+		if creationData.Role == access.RoleOwner {
+			http.Error(w, services.ErrorOwnershipTransferOnly.Error(), http.StatusBadRequest)
+			return
+		}
 		createdMember, creationErr := bmh.CreateBoardMember(ctx, boardId, &services.CreateBoardMemberData{
 			UserId: creationData.UserId,
 			Role:   creationData.Role,
 		})
+		if errors.Is(creationErr, services.ErrorRoleNotGrantable) {
+			http.Error(w, creationErr.Error(), http.StatusForbidden)
+			return
+		}
 		if creationErr != nil {
 			http.Error(w, creationErr.Error(), http.StatusBadRequest)
 			return
@@ -140,7 +149,7 @@ func (bmh *BoardMemberHandler) handleSingleMember(
 			return
 		}
 	case http.MethodPatch:
-		if !bmh.IsUserAllowedManageBoard(ctx, userId, boardId) {
+		if !bmh.Can(ctx, userId, boardId, access.PermissionMemberUpdate) {
 			http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
 			return
 		}
@@ -155,7 +164,17 @@ func (bmh *BoardMemberHandler) handleSingleMember(
 			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
 			return
 		}
-		updatedMember, roleChangeErr := bmh.ChangeBoardMemberRole(ctx, memberId, updateData.Role)
+		updatedMember, roleChangeErr := bmh.ChangeBoardMemberRole(ctx, boardId, memberId, updateData.Role)
+		if errors.Is(roleChangeErr, services.ErrorLastOwner) {
+			http.Error(w, roleChangeErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(roleChangeErr, services.ErrorOwnerChangeForbidden) ||
+			errors.Is(roleChangeErr, services.ErrorRoleNotGrantable) ||
+			errors.Is(roleChangeErr, services.ErrorOwnRoleChange) {
+			http.Error(w, roleChangeErr.Error(), http.StatusForbidden)
+			return
+		}
 		if roleChangeErr != nil {
 			http.Error(w, roleChangeErr.Error(), http.StatusBadRequest)
 			return
@@ -167,11 +186,24 @@ func (bmh *BoardMemberHandler) handleSingleMember(
 			return
 		}
 	case http.MethodDelete:
-		if !bmh.IsUserAllowedDeleteMember(ctx, userId, memberId) {
+		member, searchErr := bmh.FindBoardMemberByID(ctx, memberId)
+		if searchErr != nil || member.BoardID != boardId {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		if member.UserID != userId && !bmh.Can(ctx, userId, boardId, access.PermissionMemberRemove) {
 			http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
 			return
 		}
-		removeErr := bmh.RemoveBoardMember(ctx, memberId)
+		removeErr := bmh.RemoveBoardMember(ctx, boardId, memberId)
+		if errors.Is(removeErr, services.ErrorLastOwner) {
+			http.Error(w, removeErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(removeErr, services.ErrorOwnerChangeForbidden) {
+			http.Error(w, removeErr.Error(), http.StatusForbidden)
+			return
+		}
 		if removeErr != nil {
 			http.Error(w, removeErr.Error(), http.StatusInternalServerError)
 			return
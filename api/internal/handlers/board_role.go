@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// BoardRoleHandler handles http requests for working with methods of services.BoardRoleService
+type BoardRoleHandler struct {
+	*services.BoardRoleService
+	*services.BoardService
+	*validation.Validate
+}
+
+// NewBoardRoleHandler creates new instance of BoardRoleHandler
+func NewBoardRoleHandler(
+	brs *services.BoardRoleService,
+	bs *services.BoardService,
+	validate *validation.Validate,
+) *BoardRoleHandler {
+	return &BoardRoleHandler{brs, bs, validate}
+}
+
+func (brh *BoardRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	if _, searchErr := brh.FindBoardByID(ctx, boardId); searchErr != nil {
+		http.Error(w, boardNotExistErr.Error(), http.StatusNotFound)
+		return
+	}
+	roleIdParam := r.PathValue(config.ParamRoleID)
+	if roleIdParam == "" {
+		brh.handleMultipleRoles(ctx, w, r, boardId)
+	} else {
+		brh.handleSingleRole(ctx, w, r, boardId, sqlddl.ID(roleIdParam))
+	}
+}
+
+func (brh *BoardRoleHandler) handleMultipleRoles(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		roles, searchErr := brh.ListBoardRoles(ctx, boardId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(roles)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateBoardRoleData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := brh.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdRole, creationErr := brh.CreateBoardRole(ctx, boardId, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdRole)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (brh *BoardRoleHandler) handleSingleRole(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId,
+	roleId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		role, searchErr := brh.FindBoardRoleByID(ctx, boardId, roleId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(role)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateBoardRoleData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := brh.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedRole, updateErr := brh.UpdateBoardRole(ctx, boardId, roleId, &updateData)
+		if errors.Is(updateErr, services.ErrorRoleNotExists) {
+			http.Error(w, updateErr.Error(), http.StatusNotFound)
+			return
+		}
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedRole)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := brh.DeleteBoardRole(ctx, boardId, roleId)
+		if errors.Is(deleteErr, services.ErrorRoleNotExists) {
+			http.Error(w, deleteErr.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(deleteErr, services.ErrorRoleInUse) {
+			http.Error(w, deleteErr.Error(), http.StatusConflict)
+			return
+		}
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+	"just-kanban/pkg/websocket"
+)
+
+const (
+	// DefaultPingInterval is period of pings sent to session clients, it must be shorter than presence timeout
+	DefaultPingInterval = 20 * time.Second
+	// sessionWriteTimeout is how long client may not read before its connection is closed
+	sessionWriteTimeout = 10 * time.Second
+	// sessionReadLimit is max size of message sent by client
+	sessionReadLimit = 4 * 1024
+)
+
+// Close codes of board session besides standard ones
+const (
+	closeAccessRevoked   = 4403
+	closeSessionTimedOut = 4408
+)
+
+var taskNotOnBoardErr = errors.New("task isn't found on board")
+
+// BoardSessionHandler runs collaborative session of board member over WebSocket, see services.BoardSessionHub.
+// Browsers pass access token in access_token query parameter of handshake request.
+// Handshake is refused with 403 unless it comes from app origin or one of AllowedOrigins.
+//
+// Every message is JSON object with type field, other fields depend on type (models.SessionMessage).
+// Client sends:
+//   - {"type": "typing.started", "task_id": "...", "field": "description"} while user types in task field,
+//     field is one of name, description and comment. It's repeated every few seconds while typing goes on
+//   - {"type": "typing.stopped", "task_id": "...", "field": "description"} when user stops typing
+//   - {"type": "lock.acquire", "task_id": "..."} to lock task description for editing, lock expires unless
+//     it's acquired again before expires_at
+//   - {"type": "lock.release", "task_id": "..."} when editing is finished
+//
+// Server sends:
+//   - {"type": "presence.state", "session_id": "...", "viewers": [viewer], "locks": [lock]} first, session_id
+//     is identifier of own session, viewer is {"session_id", "user_id", "joined_at"},
+//     lock is {"task_id", "session_id", "user_id", "expires_at"}. Empty lists are omitted
+//   - {"type": "presence.joined", "viewer": viewer} and {"type": "presence.left", "viewer": viewer}
+//     when other sessions open or close, typing indicators of left viewer are cleared by client
+//   - {"type": "typing.started", "viewer": viewer, "task_id": "...", "field": "..."} and typing.stopped
+//     of other sessions, client hides indicator which isn't repeated for some seconds
+//   - {"type": "lock.acquired", "lock": lock} and {"type": "lock.released", "lock": lock} to all sessions,
+//     including the one whose lock has changed
+//   - {"type": "lock.denied", "task_id": "...", "lock": lock} when task is locked by another session
+//   - {"type": "error", "error": "...", "fields": {...}} when message can't be handled
+//
+// Locks are soft: they only tell clients who edits task, updates of task aren't blocked by them.
+// Server pings client every PingInterval, session without any message or pong for presence timeout is closed.
+// Close codes: 1001 on server shutdown, 1013 when client doesn't read messages fast enough,
+// 4403 when user is removed from board and 4408 on presence timeout
+type BoardSessionHandler struct {
+	*services.BoardSessionHub
+	*services.BoardEventHub
+	*services.TaskService
+	*validation.Validate
+	PingInterval time.Duration
+	// AllowedOrigins are origins of pages besides app one which may open session
+	AllowedOrigins []string
+}
+
+// NewBoardSessionHandler creates new instance of BoardSessionHandler
+func NewBoardSessionHandler(
+	sessionHub *services.BoardSessionHub,
+	eventHub *services.BoardEventHub,
+	ts *services.TaskService,
+	validate *validation.Validate,
+	pingInterval time.Duration,
+	allowedOrigins []string,
+) *BoardSessionHandler {
+	return &BoardSessionHandler{sessionHub, eventHub, ts, validate, pingInterval, allowedOrigins}
+}
+
+func (bsh *BoardSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, upgradeErr := websocket.Upgrade(w, r, bsh.AllowedOrigins)
+	if upgradeErr != nil {
+		return
+	}
+	defer conn.Close()
+	conn.ReadLimit = sessionReadLimit
+	conn.WriteTimeout = sessionWriteTimeout
+	ctx := r.Context()
+	userId, _ := contextkeys.GetUserId(ctx)
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	// board events tell when user loses access to board
+	events, _, _ := bsh.BoardEventHub.Subscribe(boardId, nil)
+	defer events.Close()
+	session := bsh.Join(boardId, userId)
+	defer session.Close()
+	conn.SetPongHandler(func([]byte) {
+		session.Touch()
+	})
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		bsh.readCommands(ctx, conn, session, boardId)
+	}()
+	code, reason := bsh.writeMessages(conn, session, events, userId, readDone)
+	// close frame isn't sent again if client has closed connection
+	conn.WriteClose(code, reason)
+	conn.Close()
+	<-readDone
+}
+
+// writeMessages sends messages of session and pings to client until session ends, close code is returned then
+func (bsh *BoardSessionHandler) writeMessages(
+	conn *websocket.Conn,
+	session *services.BoardSession,
+	events *services.BoardEventSubscription,
+	userId sqlddl.ID,
+	readDone <-chan struct{},
+) (int, string) {
+	ping := time.NewTicker(bsh.PingInterval)
+	defer ping.Stop()
+	boardEvents := events.Events()
+	for {
+		select {
+		case <-readDone:
+			return websocket.CloseNormal, ""
+		case message, ok := <-session.Messages():
+			if !ok {
+				return sessionCloseCode(session.Err())
+			}
+			data, marshalErr := json.Marshal(message)
+			if marshalErr != nil {
+				return websocket.CloseInternalError, ""
+			}
+			if writeErr := conn.WriteMessage(websocket.TextMessage, data); writeErr != nil {
+				return websocket.CloseGoingAway, ""
+			}
+		case <-ping.C:
+			if pingErr := conn.WritePing(nil); pingErr != nil {
+				return websocket.CloseGoingAway, ""
+			}
+		case event, ok := <-boardEvents:
+			// event stream is closed on shutdown, session is closed then too
+			if !ok {
+				boardEvents = nil
+				continue
+			}
+			if member, ok := event.Data.(*models.BoardMember); ok &&
+				event.Type == models.BoardEventMemberRemoved && member.UserID == userId {
+				return closeAccessRevoked, "removed from board"
+			}
+		}
+	}
+}
+
+// readCommands handles messages of client until connection is closed
+func (bsh *BoardSessionHandler) readCommands(
+	ctx context.Context,
+	conn *websocket.Conn,
+	session *services.BoardSession,
+	boardId sqlddl.ID,
+) {
+	// tasks are checked once per session, since clients repeat typing messages
+	boardTasks := make(map[sqlddl.ID]bool)
+	for {
+		_, data, readErr := conn.ReadMessage()
+		if readErr != nil {
+			return
+		}
+		session.Touch()
+		var command services.SessionCommandData
+		if decodeErr := json.Unmarshal(data, &command); decodeErr != nil {
+			bsh.Reply(session, models.SessionMessage{Type: models.SessionMessageError, Error: decodeErr.Error()})
+			continue
+		}
+		if validationErr := bsh.Validate.Struct(command); validationErr != nil {
+			formatted := validation.FormatValidationErr(validationErr)
+			bsh.Reply(session, models.SessionMessage{
+				Type:   models.SessionMessageError,
+				Error:  formatted.Message,
+				Fields: formatted.Fields,
+			})
+			continue
+		}
+		if !boardTasks[command.TaskID] {
+			task, searchErr := bsh.FindByID(ctx, command.TaskID)
+			if searchErr != nil || task.BoardID != boardId {
+				bsh.Reply(session, models.SessionMessage{
+					Type:   models.SessionMessageError,
+					TaskID: command.TaskID,
+					Error:  taskNotOnBoardErr.Error(),
+				})
+				continue
+			}
+			boardTasks[command.TaskID] = true
+		}
+		bsh.handleCommand(session, &command)
+	}
+}
+
+func (bsh *BoardSessionHandler) handleCommand(session *services.BoardSession, command *services.SessionCommandData) {
+	switch command.Type {
+	case models.SessionMessageTypingStarted, models.SessionMessageTypingStopped:
+		bsh.SetTyping(session, command.TaskID, command.Field, command.Type == models.SessionMessageTypingStarted)
+	case models.SessionMessageLockAcquire:
+		lock, lockErr := bsh.AcquireTaskLock(session, command.TaskID)
+		if errors.Is(lockErr, services.ErrorTaskLocked) {
+			bsh.Reply(session, models.SessionMessage{
+				Type:   models.SessionMessageLockDenied,
+				TaskID: command.TaskID,
+				Lock:   lock,
+			})
+		}
+	case models.SessionMessageLockRelease:
+		if releaseErr := bsh.ReleaseTaskLock(session, command.TaskID); releaseErr != nil {
+			bsh.Reply(session, models.SessionMessage{
+				Type:   models.SessionMessageError,
+				TaskID: command.TaskID,
+				Error:  releaseErr.Error(),
+			})
+		}
+	}
+}
+
+// sessionCloseCode gives close code and reason for session dropped by hub
+func sessionCloseCode(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrorSessionTooSlow):
+		return websocket.CloseTryAgainLater, err.Error()
+	case errors.Is(err, services.ErrorSessionTimedOut):
+		return closeSessionTimedOut, err.Error()
+	case errors.Is(err, services.ErrorSessionHubClosed):
+		return websocket.CloseGoingAway, err.Error()
+	default:
+		return websocket.CloseNormal, ""
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+	"just-kanban/pkg/websocket"
+)
+
+// sessionTestUserHeader passes identifier of authenticated user to test server
+const sessionTestUserHeader = "X-User"
+
+func dialSession(t *testing.T, server *httptest.Server, userId string) *websocket.Conn {
+	t.Helper()
+	header := http.Header{sessionTestUserHeader: []string{userId}}
+	conn, _, dialErr := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/boards/board/session", header)
+	if dialErr != nil {
+		t.Fatal(dialErr)
+	}
+	return conn
+}
+
+func readSessionMessage(t *testing.T, conn *websocket.Conn) models.SessionMessage {
+	t.Helper()
+	_, data, readErr := conn.ReadMessage()
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	var message models.SessionMessage
+	if decodeErr := json.Unmarshal(data, &message); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	return message
+}
+
+func TestBoardSessionHandler(t *testing.T) {
+	sessionHub := services.NewBoardSessionHub(
+		services.SystemClock{},
+		services.DefaultPresenceTimeout,
+		services.DefaultTaskLockTimeout,
+	)
+	eventHub := services.NewBoardEventHub(
+		services.SystemClock{},
+		services.DefaultBoardEventBufferSize,
+		services.DefaultBoardEventIdleTimeout,
+	)
+	handler := NewBoardSessionHandler(sessionHub, eventHub, nil, validation.NewValidator(), time.Hour, nil)
+	mux := http.NewServeMux()
+	mux.Handle("/boards/{boardId}/session", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userId := sqlddl.ID(r.Header.Get(sessionTestUserHeader))
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextkeys.KeyUserId, userId)))
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	alice := dialSession(t, server, "alice")
+	defer alice.Close()
+	if state := readSessionMessage(t, alice); state.Type != models.SessionMessagePresenceState ||
+		len(state.Viewers) != 1 || state.Viewers[0].UserID != "alice" {
+		t.Fatalf("got message %+v", state)
+	}
+
+	t.Run("Invalid message is answered with error", func(t *testing.T) {
+		if writeErr := alice.WriteMessage(websocket.TextMessage, []byte(`{"type": "lock.steal"}`)); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		reply := readSessionMessage(t, alice)
+		if reply.Type != models.SessionMessageError || reply.Fields["type"] == "" || reply.Fields["task_id"] == "" {
+			t.Fatalf("got message %+v", reply)
+		}
+	})
+
+	t.Run("Removed member is disconnected", func(t *testing.T) {
+		bob := dialSession(t, server, "bob")
+		defer bob.Close()
+		readSessionMessage(t, bob)
+		if joined := readSessionMessage(t, alice); joined.Type != models.SessionMessagePresenceJoined ||
+			joined.Viewer.UserID != "bob" {
+			t.Fatalf("got message %+v", joined)
+		}
+		eventHub.PublishBoardEvent(&models.BoardEvent{
+			Type:    models.BoardEventMemberRemoved,
+			BoardID: "board",
+			Data:    &models.BoardMember{UserID: "bob"},
+		})
+		var closeErr *websocket.CloseError
+		if _, _, readErr := bob.ReadMessage(); !errors.As(readErr, &closeErr) || closeErr.Code != closeAccessRevoked {
+			t.Fatalf("got error %v, expected close of revoked access", readErr)
+		}
+		if left := readSessionMessage(t, alice); left.Type != models.SessionMessagePresenceLeft ||
+			left.Viewer.UserID != "bob" {
+			t.Fatalf("got message %+v", left)
+		}
+	})
+
+	t.Run("Sessions are closed on shutdown", func(t *testing.T) {
+		sessionHub.Close()
+		var closeErr *websocket.CloseError
+		_, _, readErr := alice.ReadMessage()
+		if !errors.As(readErr, &closeErr) || closeErr.Code != websocket.CloseGoingAway {
+			t.Fatalf("got error %v, expected going away close", readErr)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// ChecklistHandler handles http requests for working with methods of services.ChecklistService
+type ChecklistHandler struct {
+	*services.ChecklistService
+	*services.TaskService
+	*validation.Validate
+}
+
+// NewChecklistHandler creates new instance of ChecklistHandler
+func NewChecklistHandler(
+	cs *services.ChecklistService,
+	ts *services.TaskService,
+	validate *validation.Validate,
+) *ChecklistHandler {
+	return &ChecklistHandler{cs, ts, validate}
+}
+
+func (ch *ChecklistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := ch.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	task, searchErr := ch.TaskService.FindByOrder(ctx, boardId, uint(order))
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	itemIdParam := r.PathValue(config.ParamChecklistItemID)
+	if itemIdParam == "" {
+		ch.handleMultipleItems(ctx, w, r, task)
+	} else {
+		ch.handleSingleItem(ctx, w, r, task, sqlddl.ID(itemIdParam))
+	}
+}
+
+func (ch *ChecklistHandler) handleMultipleItems(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		items, searchErr := ch.ListChecklistItems(ctx, task)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(items)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateChecklistItemData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := ch.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdItem, creationErr := ch.CreateChecklistItem(ctx, task, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdItem)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPut:
+		var reorderData services.ReorderChecklistItemsData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&reorderData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := ch.Validate.Struct(reorderData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		items, reorderErr := ch.ReorderChecklistItems(ctx, task, &reorderData)
+		if reorderErr != nil {
+			http.Error(w, reorderErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(items)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (ch *ChecklistHandler) handleSingleItem(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+	itemId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		item, searchErr := ch.FindChecklistItemByID(ctx, task, itemId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(item)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateChecklistItemData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := ch.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedItem, updateErr := ch.UpdateChecklistItem(ctx, task, itemId, &updateData)
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedItem)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := ch.DeleteChecklistItem(ctx, task, itemId)
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
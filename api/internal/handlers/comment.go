@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// CommentHandler handles http requests for working with methods of services.CommentService
+type CommentHandler struct {
+	*services.CommentService
+	*services.TaskService
+	*validation.Validate
+}
+
+// NewCommentHandler creates new instance of CommentHandler
+func NewCommentHandler(
+	cs *services.CommentService,
+	ts *services.TaskService,
+	validate *validation.Validate,
+) *CommentHandler {
+	return &CommentHandler{cs, ts, validate}
+}
+
+func (ch *CommentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	task, searchErr := ch.TaskService.FindByOrder(ctx, boardId, uint(order))
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	commentIdParam := r.PathValue(config.ParamCommentID)
+	if commentIdParam == "" {
+		ch.handleMultipleComments(ctx, w, r, task)
+	} else {
+		ch.handleSingleComment(ctx, w, r, task, sqlddl.ID(commentIdParam))
+	}
+}
+
+func (ch *CommentHandler) handleMultipleComments(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		comments, searchErr := ch.ListTaskComments(ctx, task)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), commentErrorStatus(searchErr))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(comments)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateCommentData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := ch.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdComment, creationErr := ch.CreateComment(ctx, task, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), commentErrorStatus(creationErr))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdComment)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (ch *CommentHandler) handleSingleComment(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+	commentId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		comment, searchErr := ch.GetTaskComment(ctx, task, commentId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), commentErrorStatus(searchErr))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(comment)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateCommentData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := ch.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedComment, updateErr := ch.UpdateComment(ctx, task, commentId, &updateData)
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), commentErrorStatus(updateErr))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedComment)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := ch.DeleteComment(ctx, task, commentId)
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), commentErrorStatus(deleteErr))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// commentErrorStatus maps errors of services.CommentService to http status codes
+func commentErrorStatus(err error) int {
+	if errors.Is(err, services.ErrorNotBoardMember) || errors.Is(err, services.ErrorCommentNotAllowed) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// BoardInvitationHandler handles http requests for managing invitations of board
+// with methods of services.InvitationService
+type BoardInvitationHandler struct {
+	*services.InvitationService
+	*validation.Validate
+}
+
+// NewBoardInvitationHandler creates new instance of BoardInvitationHandler
+func NewBoardInvitationHandler(is *services.InvitationService, validate *validation.Validate) *BoardInvitationHandler {
+	return &BoardInvitationHandler{is, validate}
+}
+
+func (bih *BoardInvitationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	if _, searchErr := bih.FindBoardByID(ctx, boardId); searchErr != nil {
+		http.Error(w, boardNotExistErr.Error(), http.StatusNotFound)
+		return
+	}
+	invitationIdParam := r.PathValue(config.ParamInvitationID)
+	if invitationIdParam == "" {
+		bih.handleMultipleInvitations(ctx, w, r, boardId)
+	} else {
+		bih.handleSingleInvitation(ctx, w, r, boardId, sqlddl.ID(invitationIdParam))
+	}
+}
+
+func (bih *BoardInvitationHandler) handleMultipleInvitations(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		invitations, searchErr := bih.ListBoardInvitations(ctx, boardId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(invitations)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateInvitationData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bih.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdInvitation, creationErr := bih.CreateInvitation(ctx, boardId, &createData)
+		if errors.Is(creationErr, services.ErrorRoleNotGrantable) {
+			http.Error(w, creationErr.Error(), http.StatusForbidden)
+			return
+		}
+		if errors.Is(creationErr, services.ErrorMemberAlreadyExists) {
+			http.Error(w, creationErr.Error(), http.StatusConflict)
+			return
+		}
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdInvitation)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (bih *BoardInvitationHandler) handleSingleInvitation(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId,
+	invitationId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		invitation, searchErr := bih.FindBoardInvitationByID(ctx, boardId, invitationId)
+		if errors.Is(searchErr, services.ErrorInvitationNotExists) {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(invitation)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		revokeErr := bih.RevokeInvitation(ctx, boardId, invitationId)
+		if errors.Is(revokeErr, services.ErrorInvitationNotExists) {
+			http.Error(w, revokeErr.Error(), http.StatusNotFound)
+			return
+		}
+		if errors.Is(revokeErr, services.ErrorInvitationClosed) {
+			http.Error(w, revokeErr.Error(), http.StatusConflict)
+			return
+		}
+		if revokeErr != nil {
+			http.Error(w, revokeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// InvitationHandler handles http requests of invitees for listing invitations addressed to them
+type InvitationHandler struct {
+	*services.InvitationService
+}
+
+// NewInvitationHandler creates new instance of InvitationHandler
+func NewInvitationHandler(is *services.InvitationService) *InvitationHandler {
+	return &InvitationHandler{is}
+}
+
+func (ih *InvitationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	invitations, searchErr := ih.ListUserInvitations(r.Context())
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(invitations)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// InvitationResponseHandler handles http requests of invitees for accepting or declining invitations
+type InvitationResponseHandler struct {
+	*services.InvitationService
+	*validation.Validate
+	// Accept defines whether handled requests accept invitations or decline them
+	Accept bool
+}
+
+// NewInvitationAcceptHandler creates new instance of InvitationResponseHandler which accepts invitations
+func NewInvitationAcceptHandler(is *services.InvitationService, validate *validation.Validate) *InvitationResponseHandler {
+	return &InvitationResponseHandler{is, validate, true}
+}
+
+// NewInvitationDeclineHandler creates new instance of InvitationResponseHandler which declines invitations
+func NewInvitationDeclineHandler(is *services.InvitationService, validate *validation.Validate) *InvitationResponseHandler {
+	return &InvitationResponseHandler{is, validate, false}
+}
+
+func (irh *InvitationResponseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	var respondData services.RespondInvitationData
+	if decodeErr := json.NewDecoder(r.Body).Decode(&respondData); decodeErr != nil {
+		http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := irh.Validate.Struct(respondData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	if !irh.Accept {
+		declineErr := irh.DeclineInvitation(ctx, &respondData)
+		if declineErr != nil {
+			http.Error(w, declineErr.Error(), invitationResponseErrStatus(declineErr))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	member, acceptErr := irh.AcceptInvitation(ctx, &respondData)
+	if acceptErr != nil {
+		http.Error(w, acceptErr.Error(), invitationResponseErrStatus(acceptErr))
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeErr := json.NewEncoder(w).Encode(member)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// invitationResponseErrStatus maps error of accepting or declining invitation to http status
+func invitationResponseErrStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrorInvitationNotExists):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrorInvitationClosed):
+		return http.StatusGone
+	case errors.Is(err, services.ErrorMemberAlreadyExists):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
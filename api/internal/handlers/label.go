@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// LabelHandler handles http requests for working with methods of services.LabelService
+type LabelHandler struct {
+	*services.LabelService
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewLabelHandler creates new instance of LabelHandler
+func NewLabelHandler(
+	ls *services.LabelService,
+	bms *services.BoardMemberService,
+	validate *validation.Validate,
+) *LabelHandler {
+	return &LabelHandler{ls, bms, validate}
+}
+
+func (lh *LabelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	if _, searchErr := lh.FindBoardByID(ctx, boardId); searchErr != nil {
+		http.Error(w, boardNotExistErr.Error(), http.StatusNotFound)
+		return
+	}
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := lh.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	if r.Method != http.MethodGet && !lh.Can(ctx, userId, boardId, access.PermissionLabelManage) {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	labelIdParam := r.PathValue(config.ParamLabelID)
+	if labelIdParam == "" {
+		lh.handleMultipleLabels(ctx, w, r, boardId)
+	} else {
+		lh.handleSingleLabel(ctx, w, r, boardId, sqlddl.ID(labelIdParam))
+	}
+}
+
+func (lh *LabelHandler) handleMultipleLabels(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		labels, searchErr := lh.ListBoardLabels(ctx, boardId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(labels)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateLabelData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := lh.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdLabel, creationErr := lh.CreateBoardLabel(ctx, boardId, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdLabel)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (lh *LabelHandler) handleSingleLabel(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId,
+	labelId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		label, searchErr := lh.FindBoardLabelByID(ctx, boardId, labelId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(label)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateLabelData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := lh.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedLabel, updateErr := lh.UpdateBoardLabel(ctx, boardId, labelId, &updateData)
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedLabel)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := lh.DeleteBoardLabel(ctx, boardId, labelId)
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
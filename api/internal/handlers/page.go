@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/services"
+)
+
+const (
+	queryParamCursor   = "cursor"
+	queryParamArchived = "archived"
+)
+
+// parseKeysetPageData reads cursor and limit query params of request, missing ones are left zero
+func parseKeysetPageData(r *http.Request) (*services.KeysetPageData, error) {
+	query := r.URL.Query()
+	pageData := services.KeysetPageData{Cursor: query.Get(queryParamCursor)}
+	if limit := query.Get(queryParamLimit); limit != "" {
+		parsedLimit, parseErr := strconv.Atoi(limit)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		pageData.Limit = parsedLimit
+	}
+	return &pageData, nil
+}
+
+// parseArchived reads archived query param of request, false if it's missing
+func parseArchived(r *http.Request) (bool, error) {
+	archived := r.URL.Query().Get(queryParamArchived)
+	if archived == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(archived)
+}
+
+// nextPageLink builds Link header value pointing to the same request with provided page
+func nextPageLink(r *http.Request, offset, limit int) string {
+	return nextLink(r, map[string]string{
+		queryParamOffset: strconv.Itoa(offset),
+		queryParamLimit:  strconv.Itoa(limit),
+	})
+}
+
+// nextCursorLink builds Link header value pointing to the same request with page following cursor
+func nextCursorLink(r *http.Request, cursor string) string {
+	return nextLink(r, map[string]string{queryParamCursor: cursor})
+}
+
+// nextLink builds Link header value of next page pointing to the same request with replaced query params
+func nextLink(r *http.Request, params map[string]string) string {
+	nextURL := *r.URL
+	query := nextURL.Query()
+	for param, value := range params {
+		query.Set(param, value)
+	}
+	nextURL.RawQuery = query.Encode()
+	return fmt.Sprintf("<%s>; rel=\"next\"", nextURL.RequestURI())
+}
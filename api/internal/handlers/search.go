@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"just-kanban/internal/services"
+	"just-kanban/pkg/validation"
+)
+
+const queryParamSearch = "q"
+
+// SearchHandler handles http requests for full-text search with methods of services.SearchService
+type SearchHandler struct {
+	*services.SearchService
+	*validation.Validate
+}
+
+// NewSearchHandler creates new instance of SearchHandler
+func NewSearchHandler(ss *services.SearchService, validate *validation.Validate) *SearchHandler {
+	return &SearchHandler{ss, validate}
+}
+
+func (sh *SearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	pageData, parseErr := parsePageData(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	searchData := services.SearchData{Query: r.URL.Query().Get(queryParamSearch), PageData: *pageData}
+	if validationErr := sh.Validate.Struct(searchData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	results, searchErr := sh.Search(ctx, &searchData)
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(results)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -3,27 +3,38 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"just-kanban/internal/access"
 	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
 	"just-kanban/internal/services"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
 	"just-kanban/pkg/validation"
 )
 
+var wipLimitOverrideNotAllowedErr = errors.New("requester is not allowed to override work in progress limit")
+
 // TaskHandler handles http requests for working with methods of services.TaskService
 type TaskHandler struct {
 	*services.TaskService
+	*services.BoardMemberService
 	*validation.Validate
 }
 
 // NewTaskHandler creates new instance of TaskHandler
 func NewTaskHandler(
 	ts *services.TaskService,
+	bms *services.BoardMemberService,
 	validate *validation.Validate,
 ) *TaskHandler {
-	return &TaskHandler{ts, validate}
+	return &TaskHandler{ts, bms, validate}
 }
 
 func (th *TaskHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -57,7 +68,16 @@ func (th *TaskHandler) handleMultipleTasks(
 			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
 			return
 		}
+		userId, _ := contextkeys.GetUserId(ctx)
+		if createData.OverrideWIPLimit && !th.Can(ctx, userId, boardId, access.PermissionTaskOverrideWIPLimit) {
+			http.Error(w, wipLimitOverrideNotAllowedErr.Error(), http.StatusForbidden)
+			return
+		}
 		createdTask, creationErr := th.TaskService.CreateTask(ctx, &createData)
+		if errors.Is(creationErr, services.ErrorWIPLimitExceeded) {
+			http.Error(w, creationErr.Error(), http.StatusConflict)
+			return
+		}
 		if creationErr != nil {
 			http.Error(w, creationErr.Error(), http.StatusBadRequest)
 			return
@@ -69,13 +89,27 @@ func (th *TaskHandler) handleMultipleTasks(
 			return
 		}
 	case http.MethodGet:
-		tasks, searchErr := th.TaskService.FindAllByBoardId(ctx, boardId)
+		listData, parseErr := parseListTasksData(r)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := th.Validate.Struct(listData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		page, searchErr := th.TaskService.ListBoardTasks(ctx, boardId, listData)
 		if searchErr != nil {
 			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
 			return
 		}
+		w.Header().Set(tcp.HeaderTotalCount, strconv.Itoa(page.Total))
+		if page.HasNext() {
+			w.Header().Set(tcp.HeaderLink, nextPageLink(r, page.Offset+page.Limit, page.Limit))
+		}
 		w.WriteHeader(http.StatusOK)
-		encodeErr := json.NewEncoder(w).Encode(tasks)
+		encodeErr := json.NewEncoder(w).Encode(page.Tasks)
 		if encodeErr != nil {
 			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
 			return
@@ -121,7 +155,21 @@ func (th *TaskHandler) handleSingleTask(
 			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
 			return
 		}
+		userId, _ := contextkeys.GetUserId(ctx)
+		if updateData.OverrideWIPLimit && !th.Can(ctx, userId, boardId, access.PermissionTaskOverrideWIPLimit) {
+			http.Error(w, wipLimitOverrideNotAllowedErr.Error(), http.StatusForbidden)
+			return
+		}
 		updatedTask, updateErr := th.TaskService.UpdateTask(ctx, task.ID, &updateData)
+		if errors.Is(updateErr, services.ErrorTaskStartsAfterDue) || errors.Is(updateErr, services.ErrorAssigneeNotMember) {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if errors.Is(updateErr, services.ErrorTaskBlocked) || errors.Is(updateErr, services.ErrorWIPLimitExceeded) ||
+			errors.Is(updateErr, services.ErrorTaskArchived) {
+			http.Error(w, updateErr.Error(), http.StatusConflict)
+			return
+		}
 		if updateErr != nil {
 			http.Error(w, updateErr.Error(), http.StatusInternalServerError)
 			return
@@ -133,7 +181,14 @@ func (th *TaskHandler) handleSingleTask(
 			return
 		}
 	case http.MethodDelete:
-		deleteErr := th.TaskService.Delete(ctx, task.ID)
+		userId, _ := contextkeys.GetUserId(ctx)
+		// delete.any covers own tasks too, so roles granting only it aren't refused
+		if !th.Can(ctx, userId, boardId, access.PermissionTaskDeleteAny) &&
+			(task.CreatorID != userId || !th.Can(ctx, userId, boardId, access.PermissionTaskDeleteOwn)) {
+			http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+			return
+		}
+		deleteErr := th.TaskService.DeleteTask(ctx, task.ID)
 		if deleteErr != nil {
 			http.Error(w, deleteErr.Error(), http.StatusInternalServerError)
 			return
@@ -141,3 +196,64 @@ func (th *TaskHandler) handleSingleTask(
 		w.WriteHeader(http.StatusOK)
 	}
 }
+
+const (
+	queryParamStatus      = "status"
+	queryParamAssigneeID  = "assignee_id"
+	queryParamCreatorID   = "creator_id"
+	queryParamCreatedFrom = "created_from"
+	queryParamCreatedTo   = "created_to"
+	queryParamUpdatedFrom = "updated_from"
+	queryParamUpdatedTo   = "updated_to"
+	queryParamSort        = "sort"
+	queryParamDirection   = "direction"
+)
+
+// parseListTasksData reads filter, sorting and page query params of request, statuses may be repeated
+// or separated by comma and dates are expected in RFC 3339 format
+func parseListTasksData(r *http.Request) (*services.ListTasksData, error) {
+	pageData, parseErr := parsePageData(r)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	archived, parseErr := parseArchived(r)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	query := r.URL.Query()
+	listData := services.ListTasksData{
+		AssigneeID: sqlddl.ID(query.Get(queryParamAssigneeID)),
+		CreatorID:  sqlddl.ID(query.Get(queryParamCreatorID)),
+		Sort:       query.Get(queryParamSort),
+		Direction:  query.Get(queryParamDirection),
+		Archived:   archived,
+		PageData:   *pageData,
+	}
+	for _, statuses := range query[queryParamStatus] {
+		for _, status := range strings.Split(statuses, ",") {
+			parsedStatus, parseErr := strconv.Atoi(status)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			listData.Statuses = append(listData.Statuses, models.TaskStatus(parsedStatus))
+		}
+	}
+	dates := map[string]**time.Time{
+		queryParamCreatedFrom: &listData.CreatedFrom,
+		queryParamCreatedTo:   &listData.CreatedTo,
+		queryParamUpdatedFrom: &listData.UpdatedFrom,
+		queryParamUpdatedTo:   &listData.UpdatedTo,
+	}
+	for param, date := range dates {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		parsedDate, parseErr := time.Parse(time.RFC3339, value)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		*date = &parsedDate
+	}
+	return &listData, nil
+}
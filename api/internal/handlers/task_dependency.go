@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// TaskDependencyHandler handles http requests for working with methods of services.TaskDependencyService
+type TaskDependencyHandler struct {
+	*services.TaskDependencyService
+	*validation.Validate
+}
+
+// NewTaskDependencyHandler creates new instance of TaskDependencyHandler
+func NewTaskDependencyHandler(
+	tds *services.TaskDependencyService,
+	validate *validation.Validate,
+) *TaskDependencyHandler {
+	return &TaskDependencyHandler{tds, validate}
+}
+
+func (tdh *TaskDependencyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := tdh.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	task, searchErr := tdh.TaskService.FindByOrder(ctx, boardId, uint(order))
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	linkedTaskIdParam := r.PathValue(config.ParamLinkedTaskID)
+	if linkedTaskIdParam == "" {
+		tdh.handleMultipleDependencies(ctx, w, r, task)
+	} else {
+		tdh.handleSingleDependency(ctx, w, r, task, sqlddl.ID(linkedTaskIdParam))
+	}
+}
+
+func (tdh *TaskDependencyHandler) handleMultipleDependencies(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		dependencies, searchErr := tdh.ListTaskDependencies(ctx, task)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(dependencies)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateTaskDependencyData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := tdh.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		dependencies, creationErr := tdh.AddTaskDependency(ctx, task, &createData)
+		if errors.Is(creationErr, services.ErrorTaskDependencyCycle) {
+			http.Error(w, creationErr.Error(), http.StatusConflict)
+			return
+		}
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(dependencies)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (tdh *TaskDependencyHandler) handleSingleDependency(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	task *models.Task,
+	linkedTaskId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodDelete:
+		deleteErr := tdh.RemoveTaskDependency(ctx, task, linkedTaskId)
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+const (
+	queryParamLimit  = "limit"
+	queryParamOffset = "offset"
+)
+
+// TaskHistoryHandler handles http requests for reading task history and board activity
+// with methods of services.TaskHistoryService
+type TaskHistoryHandler struct {
+	*services.TaskHistoryService
+	*services.TaskService
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewTaskHistoryHandler creates new instance of TaskHistoryHandler
+func NewTaskHistoryHandler(
+	ths *services.TaskHistoryService,
+	ts *services.TaskService,
+	bms *services.BoardMemberService,
+	validate *validation.Validate,
+) *TaskHistoryHandler {
+	return &TaskHistoryHandler{ths, ts, bms, validate}
+}
+
+func (thh *TaskHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	userId, _ := contextkeys.GetUserId(ctx)
+	if _, memberErr := thh.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		http.Error(w, notAllowedRequester.Error(), http.StatusForbidden)
+		return
+	}
+	pageData, parseErr := parsePageData(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := thh.Validate.Struct(pageData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	var entries []models.TaskHistoryEntry
+	var searchErr error
+	taskOrderParam := r.PathValue(config.ParamTaskOrder)
+	if taskOrderParam == "" {
+		entries, searchErr = thh.ListBoardActivity(ctx, boardId, pageData)
+	} else {
+		order, parseErr := strconv.Atoi(taskOrderParam)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		task, taskSearchErr := thh.TaskService.FindByOrder(ctx, boardId, uint(order))
+		if taskSearchErr != nil {
+			http.Error(w, taskSearchErr.Error(), http.StatusNotFound)
+			return
+		}
+		entries, searchErr = thh.ListTaskHistory(ctx, task.ID, pageData)
+	}
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(entries)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// parsePageData reads limit and offset query params of request, missing ones are left zero
+func parsePageData(r *http.Request) (*services.PageData, error) {
+	var pageData services.PageData
+	query := r.URL.Query()
+	if limit := query.Get(queryParamLimit); limit != "" {
+		parsedLimit, parseErr := strconv.Atoi(limit)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		pageData.Limit = parsedLimit
+	}
+	if offset := query.Get(queryParamOffset); offset != "" {
+		parsedOffset, parseErr := strconv.Atoi(offset)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		pageData.Offset = parsedOffset
+	}
+	return &pageData, nil
+}
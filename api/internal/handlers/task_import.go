@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+const queryParamDryRun = "dry_run"
+
+// TaskImportHandler handles http requests for importing tasks from CSV with methods of services.TaskImportService
+type TaskImportHandler struct {
+	*services.TaskImportService
+	*validation.Validate
+	// MaxSize is max size of imported CSV in bytes
+	MaxSize int64
+}
+
+// NewTaskImportHandler creates new instance of TaskImportHandler
+func NewTaskImportHandler(
+	tis *services.TaskImportService,
+	validate *validation.Validate,
+	maxSize int64,
+) *TaskImportHandler {
+	return &TaskImportHandler{tis, validate, maxSize}
+}
+
+func (tih *TaskImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	dryRun, parseErr := parseDryRun(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, tih.MaxSize)
+	rows, parseErr := services.ParseTasksCSV(r.Body)
+	if parseErr != nil {
+		writeImportReadErr(w, parseErr)
+		return
+	}
+	for i := range rows {
+		if validationErr := tih.Validate.Struct(rows[i]); validationErr != nil {
+			maps.Copy(rows[i].Errors, validation.FormatValidationErr(validationErr).Fields)
+		}
+	}
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	report, importErr := tih.ImportTasks(r.Context(), boardId, &services.ImportTasksData{Rows: rows, DryRun: dryRun})
+	if importErr != nil {
+		http.Error(w, importErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case dryRun:
+		w.WriteHeader(http.StatusOK)
+	case len(report.Errors) > 0:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusCreated)
+	}
+	encodeErr := json.NewEncoder(w).Encode(report)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseDryRun reads dry run query param of request, false if it's missing
+func parseDryRun(r *http.Request) (bool, error) {
+	dryRun := r.URL.Query().Get(queryParamDryRun)
+	if dryRun == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(dryRun)
+}
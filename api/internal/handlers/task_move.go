@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// TaskMoveHandler handles http requests for moving tasks between columns and positions of services.TaskService
+type TaskMoveHandler struct {
+	*services.TaskService
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewTaskMoveHandler creates new instance of TaskMoveHandler
+func NewTaskMoveHandler(
+	ts *services.TaskService,
+	bms *services.BoardMemberService,
+	validate *validation.Validate,
+) *TaskMoveHandler {
+	return &TaskMoveHandler{ts, bms, validate}
+}
+
+func (tmh *TaskMoveHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	order, parseErr := strconv.Atoi(r.PathValue(config.ParamTaskOrder))
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		task, searchErr := tmh.TaskService.FindByOrder(ctx, boardId, uint(order))
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		var moveData services.MoveTaskData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&moveData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := tmh.Validate.Struct(moveData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		userId, _ := contextkeys.GetUserId(ctx)
+		if moveData.OverrideWIPLimit && !tmh.Can(ctx, userId, boardId, access.PermissionTaskOverrideWIPLimit) {
+			http.Error(w, wipLimitOverrideNotAllowedErr.Error(), http.StatusForbidden)
+			return
+		}
+		movedTask, moveErr := tmh.TaskService.MoveTask(ctx, task.ID, &moveData)
+		if errors.Is(moveErr, services.ErrorTaskBlocked) || errors.Is(moveErr, services.ErrorWIPLimitExceeded) ||
+			errors.Is(moveErr, services.ErrorTaskArchived) {
+			http.Error(w, moveErr.Error(), http.StatusConflict)
+			return
+		}
+		if moveErr != nil {
+			http.Error(w, moveErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(movedTask)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
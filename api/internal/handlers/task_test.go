@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"just-kanban/internal/models"
+)
+
+func TestParseListTasksData(t *testing.T) {
+	t.Run("Success parse filters", func(t *testing.T) {
+		req := httptest.NewRequest(
+			http.MethodGet,
+			"/boards/b/tasks?status=1,2&status=3&assignee_id=u&created_from=2024-03-01T12:00:00Z&sort=created_at&limit=10",
+			nil,
+		)
+		listData, parseErr := parseListTasksData(req)
+		if parseErr != nil {
+			t.Fatalf("unexpected error: %v", parseErr)
+		}
+		expectedStatuses := []models.TaskStatus{models.TaskStatusBacklog, models.TaskStatusProcess, models.TaskStatusDone}
+		if len(listData.Statuses) != len(expectedStatuses) {
+			t.Fatalf("got statuses %v, expected %v", listData.Statuses, expectedStatuses)
+		}
+		for i, status := range expectedStatuses {
+			if listData.Statuses[i] != status {
+				t.Fatalf("got statuses %v, expected %v", listData.Statuses, expectedStatuses)
+			}
+		}
+		if listData.AssigneeID != "u" || listData.Sort != "created_at" || listData.Limit != 10 {
+			t.Fatalf("got unexpected list data %+v", listData)
+		}
+		if listData.CreatedFrom == nil || listData.CreatedFrom.Hour() != 12 || listData.CreatedTo != nil {
+			t.Fatalf("got unexpected created dates %v, %v", listData.CreatedFrom, listData.CreatedTo)
+		}
+	})
+
+	t.Run("Failed parse date", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/boards/b/tasks?updated_to=yesterday", nil)
+		if _, parseErr := parseListTasksData(req); parseErr == nil {
+			t.Fatal("expected error of bad date")
+		}
+	})
+}
+
+func TestNextPageLink(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/boards/b/tasks?status=2&offset=10&limit=10", nil)
+	link := nextPageLink(req, 20, 10)
+	expected := `</boards/b/tasks?limit=10&offset=20&status=2>; rel="next"`
+	if link != expected {
+		t.Fatalf("got %s, expected %s", link, expected)
+	}
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// TemplateHandler handles http requests for listing templates of requester
+// and instantiating boards from them with methods of services.BoardCloneService
+type TemplateHandler struct {
+	*services.BoardCloneService
+	*validation.Validate
+}
+
+// NewTemplateHandler creates new instance of TemplateHandler
+func NewTemplateHandler(bcs *services.BoardCloneService, validate *validation.Validate) *TemplateHandler {
+	return &TemplateHandler{bcs, validate}
+}
+
+func (th *TemplateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateId := r.PathValue(config.ParamBoardID)
+	switch {
+	case templateId == "" && r.Method == http.MethodGet:
+		templates, searchErr := th.ListTemplates(ctx)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(templates)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case templateId != "" && r.Method == http.MethodPost:
+		var instantiateData services.InstantiateTemplateData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&instantiateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := th.Validate.Struct(instantiateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		board, instantiateErr := th.InstantiateTemplate(ctx, sqlddl.ID(templateId), &instantiateData)
+		if errors.Is(instantiateErr, services.ErrorNotTemplate) {
+			http.Error(w, instantiateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if instantiateErr != nil {
+			http.Error(w, instantiateErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(board)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// TransferOwnershipHandler handles http requests for transferring board ownership with methods of services.BoardMemberService
+type TransferOwnershipHandler struct {
+	*services.BoardMemberService
+	*validation.Validate
+}
+
+// NewTransferOwnershipHandler creates new instance of TransferOwnershipHandler
+func NewTransferOwnershipHandler(bms *services.BoardMemberService, validate *validation.Validate) *TransferOwnershipHandler {
+	return &TransferOwnershipHandler{bms, validate}
+}
+
+func (toh *TransferOwnershipHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	var transferData services.TransferOwnershipData
+	if decodeErr := json.NewDecoder(r.Body).Decode(&transferData); decodeErr != nil {
+		http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := toh.Validate.Struct(transferData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	newOwner, transferErr := toh.TransferOwnership(ctx, boardId, &transferData)
+	if errors.Is(transferErr, services.ErrorNotBoardOwner) {
+		http.Error(w, transferErr.Error(), http.StatusForbidden)
+		return
+	}
+	if transferErr != nil {
+		http.Error(w, transferErr.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(newOwner)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+)
+
+// TrashHandler handles http requests for listing trash of requester with methods of services.TrashService
+type TrashHandler struct {
+	*services.TrashService
+}
+
+// NewTrashHandler creates new instance of TrashHandler
+func NewTrashHandler(trs *services.TrashService) *TrashHandler {
+	return &TrashHandler{trs}
+}
+
+func (trh *TrashHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	trash, searchErr := trh.ListTrash(r.Context())
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(trash)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// TrashRestoreHandler handles http requests for restoring boards and tasks from trash
+// with methods of services.TrashService
+type TrashRestoreHandler struct {
+	*services.TrashService
+}
+
+// NewTrashRestoreHandler creates new instance of TrashRestoreHandler
+func NewTrashRestoreHandler(trs *services.TrashService) *TrashRestoreHandler {
+	return &TrashRestoreHandler{trs}
+}
+
+func (trh *TrashRestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	var restored any
+	var restoreErr error
+	if boardId := r.PathValue(config.ParamBoardID); boardId != "" {
+		restored, restoreErr = trh.TrashService.RestoreBoard(ctx, sqlddl.ID(boardId))
+	} else {
+		restored, restoreErr = trh.TrashService.RestoreTask(ctx, sqlddl.ID(r.PathValue(config.ParamTaskID)))
+	}
+	if errors.Is(restoreErr, services.ErrorNotInTrash) {
+		http.Error(w, restoreErr.Error(), http.StatusNotFound)
+		return
+	}
+	if errors.Is(restoreErr, services.ErrorRestoreNotAllowed) {
+		http.Error(w, restoreErr.Error(), http.StatusForbidden)
+		return
+	}
+	if restoreErr != nil {
+		http.Error(w, restoreErr.Error(), http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(restored)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
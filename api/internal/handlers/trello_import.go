@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/importers/trello"
+	"just-kanban/pkg/validation"
+)
+
+// TrelloImportHandler handles http requests for importing Trello boards with trello.Importer
+type TrelloImportHandler struct {
+	*trello.Importer
+	// MaxSize is max size of imported Trello export in bytes
+	MaxSize int64
+}
+
+// NewTrelloImportHandler creates new instance of TrelloImportHandler
+func NewTrelloImportHandler(importer *trello.Importer, maxSize int64) *TrelloImportHandler {
+	return &TrelloImportHandler{importer, maxSize}
+}
+
+func (tih *TrelloImportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, tih.MaxSize)
+	report, importErr := tih.Import(r.Context(), r.Body)
+	var validationErrs validation.ValidationErrors
+	if errors.As(importErr, &validationErrs) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(importErr))
+		return
+	}
+	if errors.Is(importErr, trello.ErrorInvalidExport) {
+		writeImportReadErr(w, importErr)
+		return
+	}
+	if importErr != nil {
+		http.Error(w, importErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	encodeErr := json.NewEncoder(w).Encode(report)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -3,11 +3,13 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"just-kanban/internal/config"
 	"just-kanban/internal/services"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
 	"just-kanban/pkg/validation"
 )
 
@@ -35,13 +37,30 @@ func (uh *UserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (uh *UserHandler) handleMultipleUser(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		users, err := uh.UserService.ListUsers(ctx)
+		pageData, parseErr := parseKeysetPageData(r)
+		if parseErr != nil {
+			http.Error(w, parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := uh.Validate.Struct(pageData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		page, err := uh.UserService.ListUsersPage(ctx, pageData)
+		if errors.Is(err, services.ErrorInvalidCursor) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
+		if page.NextCursor != "" {
+			w.Header().Set(tcp.HeaderLink, nextCursorLink(r, page.NextCursor))
+		}
 		w.WriteHeader(http.StatusOK)
-		encodeErr := json.NewEncoder(w).Encode(users)
+		encodeErr := json.NewEncoder(w).Encode(page.Users)
 		if encodeErr != nil {
 			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
 			return
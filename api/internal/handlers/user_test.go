@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	"just-kanban/internal/config"
+	"just-kanban/internal/services"
 	"just-kanban/mocks"
+	"just-kanban/pkg/tcp"
 	"just-kanban/pkg/validation"
 )
 
@@ -22,7 +24,7 @@ func TestUserHandler(t *testing.T) {
 	handler := NewUserHandler(mockUserService, validator)
 	t.Run("No records found handling", func(t *testing.T) {
 		w := httptest.NewRecorder()
-		mockUserService.EXPECT().ListUsers(context.Background()).Return(
+		mockUserService.EXPECT().ListUsersPage(context.Background(), gomock.Any()).Return(
 			nil,
 			errors.New(""),
 		)
@@ -38,7 +40,7 @@ func TestUserHandler(t *testing.T) {
 
 	t.Run("Success read list of users", func(t *testing.T) {
 		w := httptest.NewRecorder()
-		mockUserService.EXPECT().ListUsers(context.Background()).Return(nil, nil)
+		mockUserService.EXPECT().ListUsersPage(context.Background(), gomock.Any()).Return(&services.UsersPage{}, nil)
 		handler.ServeHTTP(
 			w,
 			httptest.NewRequest(http.MethodGet, "/users", nil),
@@ -47,6 +49,45 @@ func TestUserHandler(t *testing.T) {
 		if result.StatusCode != http.StatusOK {
 			t.Fatalf("got %d, expected code %d", result.StatusCode, http.StatusOK)
 		}
+		if link := result.Header.Get(tcp.HeaderLink); link != "" {
+			t.Fatalf("got link %s of last page, expected none", link)
+		}
+	})
+
+	t.Run("Success read page of users with next page link", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mockUserService.EXPECT().ListUsersPage(
+			context.Background(),
+			&services.KeysetPageData{Cursor: "first", Limit: 1},
+		).Return(&services.UsersPage{NextCursor: "second"}, nil)
+		handler.ServeHTTP(
+			w,
+			httptest.NewRequest(http.MethodGet, "/users?cursor=first&limit=1", nil),
+		)
+		result := w.Result()
+		if result.StatusCode != http.StatusOK {
+			t.Fatalf("got %d, expected code %d", result.StatusCode, http.StatusOK)
+		}
+		expectedLink := `</users?cursor=second&limit=1>; rel="next"`
+		if link := result.Header.Get(tcp.HeaderLink); link != expectedLink {
+			t.Fatalf("got link %s, expected %s", link, expectedLink)
+		}
+	})
+
+	t.Run("Invalid cursor handling", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mockUserService.EXPECT().ListUsersPage(context.Background(), gomock.Any()).Return(
+			nil,
+			services.ErrorInvalidCursor,
+		)
+		handler.ServeHTTP(
+			w,
+			httptest.NewRequest(http.MethodGet, "/users?cursor=forged", nil),
+		)
+		result := w.Result()
+		if result.StatusCode != http.StatusBadRequest {
+			t.Fatalf("got %d, expected code %d", result.StatusCode, http.StatusBadRequest)
+		}
 	})
 
 	t.Run("Success get user", func(t *testing.T) {
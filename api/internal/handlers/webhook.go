@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/config"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// BoardWebhookHandler handles http requests for managing webhooks of board with methods of services.WebhookService
+type BoardWebhookHandler struct {
+	*services.WebhookService
+	*services.BoardService
+	*validation.Validate
+}
+
+// NewBoardWebhookHandler creates new instance of BoardWebhookHandler
+func NewBoardWebhookHandler(
+	ws *services.WebhookService,
+	bs *services.BoardService,
+	validate *validation.Validate,
+) *BoardWebhookHandler {
+	return &BoardWebhookHandler{ws, bs, validate}
+}
+
+func (bwh *BoardWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	if _, searchErr := bwh.FindBoardByID(ctx, boardId); searchErr != nil {
+		http.Error(w, boardNotExistErr.Error(), http.StatusNotFound)
+		return
+	}
+	webhookIdParam := r.PathValue(config.ParamWebhookID)
+	if webhookIdParam == "" {
+		bwh.handleMultipleWebhooks(ctx, w, r, boardId)
+	} else {
+		bwh.handleSingleWebhook(ctx, w, r, boardId, sqlddl.ID(webhookIdParam))
+	}
+}
+
+func (bwh *BoardWebhookHandler) handleMultipleWebhooks(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		webhooks, searchErr := bwh.ListBoardWebhooks(ctx, boardId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(webhooks)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		var createData services.CreateWebhookData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&createData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bwh.Validate.Struct(createData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		createdWebhook, creationErr := bwh.CreateWebhook(ctx, boardId, &createData)
+		if creationErr != nil {
+			http.Error(w, creationErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		encodeErr := json.NewEncoder(w).Encode(createdWebhook)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (bwh *BoardWebhookHandler) handleSingleWebhook(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	boardId,
+	webhookId sqlddl.ID,
+) {
+	switch r.Method {
+	case http.MethodGet:
+		webhook, searchErr := bwh.FindBoardWebhookByID(ctx, boardId, webhookId)
+		if searchErr != nil {
+			http.Error(w, searchErr.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(webhook)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPatch:
+		var updateData services.UpdateWebhookData
+		if decodeErr := json.NewDecoder(r.Body).Decode(&updateData); decodeErr != nil {
+			http.Error(w, decodeErr.Error(), http.StatusBadRequest)
+			return
+		}
+		if validationErr := bwh.Validate.Struct(updateData); validationErr != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+			return
+		}
+		updatedWebhook, updateErr := bwh.UpdateWebhook(ctx, boardId, webhookId, &updateData)
+		if errors.Is(updateErr, services.ErrorWebhookNotExists) {
+			http.Error(w, updateErr.Error(), http.StatusNotFound)
+			return
+		}
+		if updateErr != nil {
+			http.Error(w, updateErr.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		encodeErr := json.NewEncoder(w).Encode(updatedWebhook)
+		if encodeErr != nil {
+			http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+			return
+		}
+	case http.MethodDelete:
+		deleteErr := bwh.DeleteWebhook(ctx, boardId, webhookId)
+		if errors.Is(deleteErr, services.ErrorWebhookNotExists) {
+			http.Error(w, deleteErr.Error(), http.StatusNotFound)
+			return
+		}
+		if deleteErr != nil {
+			http.Error(w, deleteErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// WebhookDeliveriesHandler handles http requests for reading the latest delivery attempts of board webhook,
+// limit query param sets count of returned attempts
+type WebhookDeliveriesHandler struct {
+	*services.WebhookService
+	*validation.Validate
+}
+
+// NewWebhookDeliveriesHandler creates new instance of WebhookDeliveriesHandler
+func NewWebhookDeliveriesHandler(ws *services.WebhookService, validate *validation.Validate) *WebhookDeliveriesHandler {
+	return &WebhookDeliveriesHandler{ws, validate}
+}
+
+func (wdh *WebhookDeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+	webhookId := sqlddl.ID(r.PathValue(config.ParamWebhookID))
+	pageData, parseErr := parsePageData(r)
+	if parseErr != nil {
+		http.Error(w, parseErr.Error(), http.StatusBadRequest)
+		return
+	}
+	if validationErr := wdh.Validate.Struct(pageData); validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(validation.FormatValidationErr(validationErr))
+		return
+	}
+	deliveries, searchErr := wdh.ListWebhookDeliveries(ctx, boardId, webhookId, pageData)
+	if errors.Is(searchErr, services.ErrorWebhookNotExists) {
+		http.Error(w, searchErr.Error(), http.StatusNotFound)
+		return
+	}
+	if searchErr != nil {
+		http.Error(w, searchErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	encodeErr := json.NewEncoder(w).Encode(deliveries)
+	if encodeErr != nil {
+		http.Error(w, encodeErr.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,97 @@
+// Package trello imports boards exported from Trello as JSON, it's used by http handler and command line tool
+package trello
+
+import "time"
+
+// action types of Trello which are imported
+const (
+	actionCreateCard  = "createCard"
+	actionCommentCard = "commentCard"
+)
+
+// checkItemComplete is state of done checklist item
+const checkItemComplete = "complete"
+
+type (
+	// Board is Trello board export, only its parts which are imported are decoded
+	Board struct {
+		Name       string      `json:"name"`
+		Desc       string      `json:"desc"`
+		Lists      []List      `json:"lists"`
+		Cards      []Card      `json:"cards"`
+		Labels     []Label     `json:"labels"`
+		Members    []Member    `json:"members"`
+		Checklists []Checklist `json:"checklists"`
+		// Actions are history of board, Trello exports only the latest of them
+		Actions []Action `json:"actions"`
+	}
+
+	List struct {
+		ID     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	}
+
+	Card struct {
+		ID string `json:"id"`
+		// IDShort is number of card on its board
+		IDShort     int        `json:"idShort"`
+		Name        string     `json:"name"`
+		Desc        string     `json:"desc"`
+		Closed      bool       `json:"closed"`
+		IDList      string     `json:"idList"`
+		IDMembers   []string   `json:"idMembers"`
+		IDLabels    []string   `json:"idLabels"`
+		Pos         float64    `json:"pos"`
+		Start       *time.Time `json:"start"`
+		Due         *time.Time `json:"due"`
+		Attachments []struct {
+			Name string `json:"name"`
+		} `json:"attachments"`
+		DateLastActivity *time.Time `json:"dateLastActivity"`
+	}
+
+	Label struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+
+	Member struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		FullName string `json:"fullName"`
+		// Email is exported for members who made it visible only
+		Email string `json:"email"`
+	}
+
+	Checklist struct {
+		ID         string      `json:"id"`
+		IDCard     string      `json:"idCard"`
+		Name       string      `json:"name"`
+		Pos        float64     `json:"pos"`
+		CheckItems []CheckItem `json:"checkItems"`
+	}
+
+	CheckItem struct {
+		ID       string  `json:"id"`
+		Name     string  `json:"name"`
+		State    string  `json:"state"`
+		Pos      float64 `json:"pos"`
+		IDMember string  `json:"idMember"`
+	}
+
+	Action struct {
+		ID            string    `json:"id"`
+		Type          string    `json:"type"`
+		Date          time.Time `json:"date"`
+		MemberCreator Member    `json:"memberCreator"`
+		Data          struct {
+			Text string `json:"text"`
+			Card struct {
+				ID string `json:"id"`
+			} `json:"card"`
+		} `json:"data"`
+	}
+)
@@ -0,0 +1,425 @@
+package trello
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/models"
+)
+
+// limits of imported texts, they match validation of models.BoardExport
+const (
+	maxBoardNameLength     = 255
+	minBoardNameLength     = 3
+	maxDescriptionLength   = 1000
+	maxColumnNameLength    = 100
+	maxLabelNameLength     = 50
+	maxTaskNameLength      = 255
+	minTaskNameLength      = 3
+	maxChecklistItemLength = 500
+	maxCommentLength       = 5000
+)
+
+const (
+	defaultLabelColor          = "#b3bac5"
+	defaultColumnName          = "To do"
+	shortBoardNamePrefix       = "Trello board "
+	archivedListReason         = "list is archived, its cards are imported as archived tasks into the first column"
+	truncatedDescriptionReason = "description is truncated to %d characters"
+)
+
+// kinds of Trello records in report
+const (
+	kindBoard      = "board"
+	kindList       = "list"
+	kindLabel      = "label"
+	kindCard       = "card"
+	kindChecklist  = "checklist"
+	kindComment    = "comment"
+	kindAttachment = "attachment"
+)
+
+type (
+	// Skipped is part of Trello board which isn't carried over or is changed to fit the app
+	Skipped struct {
+		// Kind is kind of Trello record, one of board, list, label, card, checklist, comment or attachment
+		Kind string `json:"kind"`
+		// Name identifies record for people, e.g. name of list or card
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	}
+
+	// converter keeps state of Trello board being converted into document of board export
+	converter struct {
+		board    *Board
+		importer models.UserReference
+		now      time.Time
+		doc      *models.BoardExport
+		// columns maps identifiers of open lists to orders of columns
+		columns map[string]int
+		// labels maps identifiers of labels to names of document labels
+		labels  map[string]string
+		members map[string]Member
+		skipped []Skipped
+	}
+)
+
+// labelColors are hex codes of named Trello label colors, shades like green_dark fall back to base color
+var labelColors = map[string]string{
+	"green":  "#61bd4f",
+	"yellow": "#f2d600",
+	"orange": "#ff9f1a",
+	"red":    "#eb5a46",
+	"purple": "#c377e0",
+	"blue":   "#0079bf",
+	"sky":    "#00c2e0",
+	"lime":   "#51e898",
+	"pink":   "#ff78cb",
+	"black":  "#344563",
+}
+
+// Convert maps Trello board to document of board export. Lists become columns, the first list gets backlog status,
+// the last one gets done status and others get process status. Cards become tasks which are assigned to their first
+// member, card members become board members with regular role, checklists are merged into task checklist and
+// comments are carried over. Importer is used for cards and comments whose authors are unknown.
+// Changed and left out parts of board are returned as skipped
+func Convert(board *Board, importer models.UserReference, now time.Time) (*models.BoardExport, []Skipped) {
+	c := &converter{
+		board:    board,
+		importer: importer,
+		now:      now,
+		doc: &models.BoardExport{
+			Version:    models.BoardExportVersion,
+			ExportedAt: now,
+			Columns:    []models.ExportedColumn{},
+			Labels:     []models.ExportedLabel{},
+			Roles:      []models.ExportedRole{},
+			Members:    []models.ExportedMember{},
+			Tasks:      []models.ExportedTask{},
+		},
+		columns: make(map[string]int),
+		labels:  make(map[string]string),
+		members: make(map[string]Member, len(board.Members)),
+		skipped: []Skipped{},
+	}
+	for _, member := range board.Members {
+		c.members[member.ID] = member
+	}
+	c.convertBoard()
+	c.convertLists()
+	c.convertLabels()
+	c.convertMembers()
+	c.convertCards()
+	return c.doc, c.skipped
+}
+
+func (c *converter) skip(kind, name, reason string) {
+	c.skipped = append(c.skipped, Skipped{Kind: kind, Name: name, Reason: reason})
+}
+
+func (c *converter) convertBoard() {
+	name, _ := fitText(c.board.Name, maxBoardNameLength)
+	if len([]rune(name)) < minBoardNameLength {
+		c.skip(kindBoard, c.board.Name, "name is too short, it's prefixed")
+		name = strings.TrimSpace(shortBoardNamePrefix + name)
+	}
+	description, truncated := fitText(c.board.Desc, maxDescriptionLength)
+	if truncated {
+		c.skip(kindBoard, name, fmt.Sprintf(truncatedDescriptionReason, maxDescriptionLength))
+	}
+	c.doc.Board = models.ExportedBoard{Name: name, Description: description}
+}
+
+func (c *converter) convertLists() {
+	var lists []List
+	for _, list := range c.board.Lists {
+		if list.Closed {
+			c.skip(kindList, list.Name, archivedListReason)
+			continue
+		}
+		lists = append(lists, list)
+	}
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Pos < lists[j].Pos
+	})
+	for i, list := range lists {
+		name, _ := fitText(list.Name, maxColumnNameLength)
+		if name == "" {
+			name = fmt.Sprintf("List %d", i+1)
+		}
+		status := models.TaskStatusProcess
+		switch {
+		case i == 0:
+			status = models.TaskStatusBacklog
+		case i == len(lists)-1:
+			status = models.TaskStatusDone
+		}
+		c.columns[list.ID] = i + 1
+		c.doc.Columns = append(c.doc.Columns, models.ExportedColumn{Name: name, Order: i + 1, Status: status})
+	}
+	if len(c.doc.Columns) == 0 {
+		c.doc.Columns = append(c.doc.Columns, models.ExportedColumn{
+			Name:   defaultColumnName,
+			Order:  1,
+			Status: models.TaskStatusBacklog,
+		})
+	}
+}
+
+func (c *converter) convertLabels() {
+	names := make(map[string]bool, len(c.board.Labels))
+	for _, label := range c.board.Labels {
+		color, name := labelColor(label.Color)
+		if label.Name != "" {
+			name, _ = fitText(label.Name, maxLabelNameLength)
+		}
+		c.labels[label.ID] = name
+		if names[name] {
+			c.skip(kindLabel, name, "label is merged with the first label of the same name")
+			continue
+		}
+		names[name] = true
+		c.doc.Labels = append(c.doc.Labels, models.ExportedLabel{Name: name, Color: color})
+	}
+}
+
+// labelColor returns hex code of Trello color and name which is given to label without name
+func labelColor(color string) (hex string, name string) {
+	base, _, _ := strings.Cut(color, "_")
+	hex, ok := labelColors[base]
+	if !ok {
+		return defaultLabelColor, "Label"
+	}
+	return hex, strings.ToUpper(base[:1]) + base[1:]
+}
+
+// convertMembers adds members of cards to board, Trello board members who aren't on any card aren't added
+func (c *converter) convertMembers() {
+	added := make(map[string]bool)
+	for _, card := range c.board.Cards {
+		for _, memberId := range card.IDMembers {
+			member, ok := c.members[memberId]
+			if !ok || added[memberId] {
+				continue
+			}
+			added[memberId] = true
+			c.doc.Members = append(c.doc.Members, models.ExportedMember{
+				User: userReference(member),
+				Role: access.RoleRegular,
+			})
+		}
+	}
+}
+
+func userReference(member Member) models.UserReference {
+	return models.UserReference{Username: member.Username, Email: member.Email}
+}
+
+// memberReference returns reference of Trello member, importer is returned for unknown members
+func (c *converter) memberReference(member Member) models.UserReference {
+	if member.Username == "" && member.Email == "" {
+		return c.importer
+	}
+	return userReference(member)
+}
+
+func (c *converter) convertCards() {
+	cards := append([]Card(nil), c.board.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].IDShort < cards[j].IDShort
+	})
+	checklists := make(map[string][]Checklist)
+	for _, checklist := range c.board.Checklists {
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], checklist)
+	}
+	comments := make(map[string][]Action)
+	creators := make(map[string]Member)
+	for _, action := range c.board.Actions {
+		switch action.Type {
+		case actionCommentCard:
+			comments[action.Data.Card.ID] = append(comments[action.Data.Card.ID], action)
+		case actionCreateCard:
+			creators[action.Data.Card.ID] = action.MemberCreator
+		}
+	}
+	positions := c.cardPositions()
+	names := make(map[string]bool, len(cards))
+	for i, card := range cards {
+		order := i + 1
+		task := models.ExportedTask{
+			Order:     order,
+			Name:      c.taskName(card, order, names),
+			Column:    1,
+			Creator:   c.memberReference(creators[card.ID]),
+			Assignee:  c.importer,
+			StartAt:   card.Start,
+			DueAt:     card.Due,
+			Labels:    []string{},
+			Checklist: []models.ExportedChecklistItem{},
+			BlockedBy: []int{},
+			Comments:  []models.ExportedComment{},
+		}
+		names[task.Name] = true
+		description, truncated := fitText(card.Desc, maxDescriptionLength)
+		if truncated {
+			c.skip(kindCard, task.Name, fmt.Sprintf(truncatedDescriptionReason, maxDescriptionLength))
+		}
+		task.Description = description
+		column, listOpen := c.columns[card.IDList]
+		if listOpen {
+			task.Column = column
+		}
+		if listOpen && !card.Closed {
+			task.Position = positions[card.ID]
+		} else {
+			archivedAt := c.now
+			if card.DateLastActivity != nil {
+				archivedAt = *card.DateLastActivity
+			}
+			task.ArchivedAt = &archivedAt
+		}
+		if task.StartAt != nil && task.DueAt != nil && task.StartAt.After(*task.DueAt) {
+			c.skip(kindCard, task.Name, "start date is after due date, it's left out")
+			task.StartAt = nil
+		}
+		for _, memberId := range card.IDMembers {
+			if member, ok := c.members[memberId]; ok {
+				task.Assignee = userReference(member)
+				break
+			}
+		}
+		if len(card.IDMembers) > 1 {
+			c.skip(kindCard, task.Name, "card has several members, task is assigned to the first one")
+		}
+		task.Labels = c.taskLabels(card)
+		task.Checklist = c.taskChecklist(task.Name, checklists[card.ID])
+		task.Comments = c.taskComments(task.Name, comments[card.ID])
+		if len(card.Attachments) > 0 {
+			c.skip(kindAttachment, task.Name, fmt.Sprintf("%d attachments of card aren't imported", len(card.Attachments)))
+		}
+		c.doc.Tasks = append(c.doc.Tasks, task)
+	}
+}
+
+// cardPositions places open cards of open lists in sequence of their Trello positions
+func (c *converter) cardPositions() map[string]int {
+	cards := append([]Card(nil), c.board.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Pos < cards[j].Pos
+	})
+	positions := make(map[string]int, len(cards))
+	counts := make(map[string]int)
+	for _, card := range cards {
+		if _, ok := c.columns[card.IDList]; !ok || card.Closed {
+			continue
+		}
+		counts[card.IDList]++
+		positions[card.ID] = counts[card.IDList]
+	}
+	return positions
+}
+
+// taskName fits card name to task name limits, too short and repeated names are suffixed with task order
+func (c *converter) taskName(card Card, order int, names map[string]bool) string {
+	name, _ := fitText(card.Name, maxTaskNameLength)
+	suffix := fmt.Sprintf(" #%d", order)
+	switch {
+	case len([]rune(name)) < minTaskNameLength:
+		c.skip(kindCard, card.Name, "name is too short, it's suffixed with task order")
+	case names[name]:
+		c.skip(kindCard, card.Name, "name is repeated, it's suffixed with task order")
+	default:
+		return name
+	}
+	name, _ = fitText(name, maxTaskNameLength-len(suffix))
+	return strings.TrimSpace(name + suffix)
+}
+
+func (c *converter) taskLabels(card Card) []string {
+	labels := make([]string, 0, len(card.IDLabels))
+	attached := make(map[string]bool, len(card.IDLabels))
+	for _, labelId := range card.IDLabels {
+		name, ok := c.labels[labelId]
+		if !ok || attached[name] {
+			continue
+		}
+		attached[name] = true
+		labels = append(labels, name)
+	}
+	return labels
+}
+
+// taskChecklist merges checklists of card into one, items are prefixed with names of their checklists
+// if card has several of them
+func (c *converter) taskChecklist(taskName string, checklists []Checklist) []models.ExportedChecklistItem {
+	sort.SliceStable(checklists, func(i, j int) bool {
+		return checklists[i].Pos < checklists[j].Pos
+	})
+	items := make([]models.ExportedChecklistItem, 0)
+	for _, checklist := range checklists {
+		checkItems := append([]CheckItem(nil), checklist.CheckItems...)
+		sort.SliceStable(checkItems, func(i, j int) bool {
+			return checkItems[i].Pos < checkItems[j].Pos
+		})
+		for _, checkItem := range checkItems {
+			text := checkItem.Name
+			if len(checklists) > 1 {
+				text = checklist.Name + ": " + text
+			}
+			text, truncated := fitText(text, maxChecklistItemLength)
+			if text == "" {
+				continue
+			}
+			if truncated {
+				c.skip(kindChecklist, taskName, fmt.Sprintf("item is truncated to %d characters", maxChecklistItemLength))
+			}
+			item := models.ExportedChecklistItem{
+				Text:  text,
+				Done:  checkItem.State == checkItemComplete,
+				Order: len(items) + 1,
+			}
+			if member, ok := c.members[checkItem.IDMember]; ok {
+				reference := userReference(member)
+				item.Assignee = &reference
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (c *converter) taskComments(taskName string, actions []Action) []models.ExportedComment {
+	sort.SliceStable(actions, func(i, j int) bool {
+		return actions[i].Date.Before(actions[j].Date)
+	})
+	comments := make([]models.ExportedComment, 0, len(actions))
+	for _, action := range actions {
+		body, truncated := fitText(action.Data.Text, maxCommentLength)
+		if body == "" {
+			continue
+		}
+		if truncated {
+			c.skip(kindComment, taskName, fmt.Sprintf("comment is truncated to %d characters", maxCommentLength))
+		}
+		comments = append(comments, models.ExportedComment{
+			Number:    len(comments) + 1,
+			Author:    c.memberReference(action.MemberCreator),
+			Body:      body,
+			CreatedAt: action.Date,
+		})
+	}
+	return comments
+}
+
+// fitText trims white space of text and cuts it to max count of characters
+func fitText(text string, max int) (fitted string, truncated bool) {
+	fitted = strings.TrimSpace(text)
+	runes := []rune(fitted)
+	if len(runes) <= max {
+		return fitted, false
+	}
+	return strings.TrimSpace(string(runes[:max])), true
+}
@@ -0,0 +1,186 @@
+package trello
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/validation"
+)
+
+const exportJSON = `{
+	"name": "Roadmap",
+	"desc": "Plans",
+	"lists": [
+		{"id": "l3", "name": "Done", "pos": 300},
+		{"id": "l1", "name": "Ideas", "pos": 100},
+		{"id": "l2", "name": "Doing", "pos": 200},
+		{"id": "l4", "name": "Old", "pos": 400, "closed": true}
+	],
+	"labels": [
+		{"id": "b1", "name": "", "color": "green_dark"},
+		{"id": "b2", "name": "Bug", "color": "red"},
+		{"id": "b3", "name": "Bug", "color": null}
+	],
+	"members": [
+		{"id": "m1", "username": "alice", "fullName": "Alice"},
+		{"id": "m2", "username": "bob", "fullName": "Bob"}
+	],
+	"cards": [
+		{"id": "c2", "idShort": 2, "name": "Ship", "idList": "l2", "pos": 20, "idMembers": ["m2", "m1"],
+			"idLabels": ["b2", "b3"], "start": "2030-02-01T00:00:00Z", "due": "2030-01-01T00:00:00Z"},
+		{"id": "c1", "idShort": 1, "name": "Plan", "desc": "  Steps  ", "idList": "l2", "pos": 30,
+			"idLabels": ["b1"], "attachments": [{"name": "spec.pdf"}]},
+		{"id": "c3", "idShort": 3, "name": "Plan", "idList": "l4", "pos": 10,
+			"dateLastActivity": "2029-05-01T00:00:00Z"},
+		{"id": "c4", "idShort": 4, "name": "Go", "idList": "l1", "pos": 10, "closed": true}
+	],
+	"checklists": [
+		{"id": "k2", "idCard": "c1", "name": "After", "pos": 2, "checkItems": [
+			{"id": "i3", "name": "Release", "state": "incomplete", "pos": 1}
+		]},
+		{"id": "k1", "idCard": "c1", "name": "Before", "pos": 1, "checkItems": [
+			{"id": "i2", "name": "Review", "state": "complete", "pos": 2, "idMember": "m1"},
+			{"id": "i1", "name": "Draft", "state": "complete", "pos": 1}
+		]}
+	],
+	"actions": [
+		{"id": "a2", "type": "commentCard", "date": "2029-01-02T00:00:00Z",
+			"memberCreator": {"id": "m2", "username": "bob"}, "data": {"text": "Second", "card": {"id": "c1"}}},
+		{"id": "a1", "type": "commentCard", "date": "2029-01-01T00:00:00Z",
+			"memberCreator": {"id": "m9", "username": "carol"}, "data": {"text": "First", "card": {"id": "c1"}}},
+		{"id": "a3", "type": "createCard", "date": "2029-01-01T00:00:00Z",
+			"memberCreator": {"id": "m1", "username": "alice"}, "data": {"card": {"id": "c1"}}}
+	]
+}`
+
+func TestConvert(t *testing.T) {
+	var board Board
+	if decodeErr := json.NewDecoder(strings.NewReader(exportJSON)).Decode(&board); decodeErr != nil {
+		t.Fatal(decodeErr)
+	}
+	importer := models.UserReference{Username: "importer", Email: "importer@example.com"}
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	doc, skipped := Convert(&board, importer, now)
+
+	validate := validation.NewValidator()
+	if registerErr := validation.RegisterValidationTagTrimmed(validate); registerErr != nil {
+		t.Fatal(registerErr)
+	}
+	if validationErr := validate.Struct(doc); validationErr != nil {
+		t.Fatalf("converted document is invalid: %v", validationErr)
+	}
+
+	t.Run("Open lists become columns by position", func(t *testing.T) {
+		expected := []models.ExportedColumn{
+			{Name: "Ideas", Order: 1, Status: models.TaskStatusBacklog},
+			{Name: "Doing", Order: 2, Status: models.TaskStatusProcess},
+			{Name: "Done", Order: 3, Status: models.TaskStatusDone},
+		}
+		if len(doc.Columns) != len(expected) {
+			t.Fatalf("got columns %+v", doc.Columns)
+		}
+		for i, column := range expected {
+			if doc.Columns[i] != column {
+				t.Fatalf("got column %+v, expected %+v", doc.Columns[i], column)
+			}
+		}
+	})
+
+	t.Run("Labels get names of colors and are merged by name", func(t *testing.T) {
+		expected := []models.ExportedLabel{{Name: "Green", Color: "#61bd4f"}, {Name: "Bug", Color: "#eb5a46"}}
+		if len(doc.Labels) != len(expected) || doc.Labels[0] != expected[0] || doc.Labels[1] != expected[1] {
+			t.Fatalf("got labels %+v", doc.Labels)
+		}
+	})
+
+	t.Run("Card members become board members", func(t *testing.T) {
+		if len(doc.Members) != 2 || doc.Members[0].User.Username != "bob" || doc.Members[1].User.Username != "alice" {
+			t.Fatalf("got members %+v", doc.Members)
+		}
+	})
+
+	t.Run("Cards become tasks", func(t *testing.T) {
+		if len(doc.Tasks) != 4 {
+			t.Fatalf("got %d tasks", len(doc.Tasks))
+		}
+		plan, ship, repeated, short := doc.Tasks[0], doc.Tasks[1], doc.Tasks[2], doc.Tasks[3]
+		if plan.Name != "Plan" || plan.Description != "Steps" || plan.Column != 2 || plan.Position != 2 ||
+			plan.Creator.Username != "alice" || plan.Assignee != importer || plan.ArchivedAt != nil {
+			t.Fatalf("got task %+v", plan)
+		}
+		checklist := []string{"Before: Draft", "Before: Review", "After: Release"}
+		if len(plan.Checklist) != len(checklist) {
+			t.Fatalf("got checklist %+v", plan.Checklist)
+		}
+		for i, text := range checklist {
+			if plan.Checklist[i].Text != text || plan.Checklist[i].Order != i+1 {
+				t.Fatalf("got checklist item %+v, expected %s", plan.Checklist[i], text)
+			}
+		}
+		if !plan.Checklist[0].Done || plan.Checklist[1].Assignee.Username != "alice" || plan.Checklist[2].Done {
+			t.Fatalf("got checklist %+v", plan.Checklist)
+		}
+		if len(plan.Comments) != 2 || plan.Comments[0].Body != "First" || plan.Comments[0].Author.Username != "carol" ||
+			plan.Comments[1].Number != 2 {
+			t.Fatalf("got comments %+v", plan.Comments)
+		}
+		if ship.Position != 1 || ship.Assignee.Username != "bob" || ship.Creator != importer || ship.StartAt != nil ||
+			len(ship.Labels) != 1 || ship.Labels[0] != "Bug" {
+			t.Fatalf("got task %+v", ship)
+		}
+		if repeated.Name != "Plan #3" || repeated.Column != 1 || repeated.ArchivedAt == nil ||
+			!repeated.ArchivedAt.Equal(time.Date(2029, 5, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("got task %+v", repeated)
+		}
+		if short.Name != "Go #4" || short.Column != 1 || short.Position != 0 || !short.ArchivedAt.Equal(now) {
+			t.Fatalf("got task %+v", short)
+		}
+	})
+
+	t.Run("Changes are reported", func(t *testing.T) {
+		expected := []Skipped{
+			{Kind: kindList, Name: "Old", Reason: archivedListReason},
+			{Kind: kindLabel, Name: "Bug", Reason: "label is merged with the first label of the same name"},
+			{Kind: kindAttachment, Name: "Plan", Reason: "attachments"},
+			{Kind: kindCard, Name: "Ship", Reason: "start date"},
+			{Kind: kindCard, Name: "Ship", Reason: "several members"},
+			{Kind: kindCard, Name: "Plan", Reason: "repeated"},
+			{Kind: kindCard, Name: "Go", Reason: "too short"},
+		}
+		if len(skipped) != len(expected) {
+			t.Fatalf("got skipped %+v", skipped)
+		}
+		for _, part := range expected {
+			found := false
+			for _, s := range skipped {
+				if s.Kind == part.Kind && s.Name == part.Name && strings.Contains(s.Reason, part.Reason) {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("skipped %+v isn't reported in %+v", part, skipped)
+			}
+		}
+	})
+}
+
+func TestFitText(t *testing.T) {
+	cases := []struct {
+		text, expected string
+		max            int
+		truncated      bool
+	}{
+		{" short ", "short", 10, false},
+		{"ąčęėįšų", "ąčę", 3, true},
+		{"ab cd", "ab", 3, true},
+	}
+	for _, c := range cases {
+		fitted, truncated := fitText(c.text, c.max)
+		if fitted != c.expected || truncated != c.truncated {
+			t.Fatalf("got %q, %t for %q, expected %q, %t", fitted, truncated, c.text, c.expected, c.truncated)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package trello
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/pkg/validation"
+)
+
+type (
+	// Importer creates boards of app from Trello exports with board import of services.BoardExportService
+	Importer struct {
+		*services.BoardExportService
+		*validation.Validate
+	}
+
+	// Report is board imported from Trello along with parts of Trello board which were skipped or changed
+	// and references which weren't resolved in app
+	Report struct {
+		Board      *models.Board                  `json:"board"`
+		Skipped    []Skipped                      `json:"skipped"`
+		Unresolved []services.UnresolvedReference `json:"unresolved"`
+	}
+)
+
+var ErrorInvalidExport = errors.New("invalid Trello board export")
+
+// NewImporter creates new instance of Importer
+func NewImporter(bes *services.BoardExportService, validate *validation.Validate) *Importer {
+	return &Importer{bes, validate}
+}
+
+// Import reads Trello board export and creates board of it owned by requester, cards and comments of unknown
+// authors are attributed to requester. Validation errors of converted board are returned as they are
+func (i *Importer) Import(ctx context.Context, r io.Reader) (*Report, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	var board Board
+	if decodeErr := json.NewDecoder(r).Decode(&board); decodeErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrorInvalidExport, decodeErr)
+	}
+	importer, searchErr := i.UserService.FindByID(ctx, userId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	doc, skipped := Convert(
+		&board,
+		models.UserReference{Username: importer.Username, Email: importer.Email},
+		i.BoardExportService.Now(),
+	)
+	if validationErr := i.Validate.Struct(doc); validationErr != nil {
+		return nil, validationErr
+	}
+	importReport, importErr := i.ImportBoard(ctx, doc)
+	if importErr != nil {
+		return nil, importErr
+	}
+	return &Report{Board: importReport.Board, Skipped: skipped, Unresolved: importReport.Unresolved}, nil
+}
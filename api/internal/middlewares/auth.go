@@ -3,6 +3,7 @@ package middlewares
 import (
 	"context"
 	"net/http"
+	"slices"
 	"strings"
 
 	"just-kanban/internal/config"
@@ -11,21 +12,17 @@ import (
 	"just-kanban/pkg/auth"
 	"just-kanban/pkg/auth/jwt"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/websocket"
 )
 
 // Auth proxies request and check them on auth credentials.
-// If credentials provided add id of authenticated user to request context
-func Auth(next http.Handler, env *config.Env) http.Handler {
+// If credentials provided add id of authenticated user to request context.
+// Browsers can't set headers of WebSocket handshake, so its access token may be passed in query instead,
+// but only for routes registered with one of queryTokenPatterns, since urls with tokens end up in logs and history
+func Auth(next http.Handler, env *config.Env, queryTokenPatterns ...string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get(auth.TokenHeader)
-		if authHeader == "" {
-			http.Error(w, auth.UnauthorizedErr.Error(), http.StatusUnauthorized)
-			return
-		}
-		splitHeader := strings.Split(authHeader, " ")
-		authType := splitHeader[0]
-		accessToken := splitHeader[1]
-		if authType != jwt.AuthTypeBearer || accessToken == "" {
+		accessToken, found := requestAccessToken(r, queryTokenPatterns)
+		if !found {
 			http.Error(w, auth.UnauthorizedErr.Error(), http.StatusUnauthorized)
 			return
 		}
@@ -44,3 +41,18 @@ func Auth(next http.Handler, env *config.Env) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// requestAccessToken takes access token from bearer auth header or from query of WebSocket handshake
+// to route matched by one of queryTokenPatterns
+func requestAccessToken(r *http.Request, queryTokenPatterns []string) (string, bool) {
+	authHeader := r.Header.Get(auth.TokenHeader)
+	if authHeader == "" {
+		if !websocket.IsUpgrade(r) || !slices.Contains(queryTokenPatterns, r.Pattern) {
+			return "", false
+		}
+		accessToken := r.URL.Query().Get(auth.TokenQueryParam)
+		return accessToken, accessToken != ""
+	}
+	authType, accessToken, _ := strings.Cut(authHeader, " ")
+	return accessToken, authType == jwt.AuthTypeBearer && accessToken != ""
+}
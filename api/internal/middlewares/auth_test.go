@@ -0,0 +1,54 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestAccessToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		header    map[string]string
+		expected  string
+		withToken bool
+	}{
+		{"Bearer header", "/boards", map[string]string{"Authorization": "Bearer token"}, "token", true},
+		{"Another auth type", "/boards", map[string]string{"Authorization": "Basic token"}, "token", false},
+		{"Header without token", "/boards", map[string]string{"Authorization": "Bearer"}, "", false},
+		{"Query of plain request", "/boards?access_token=token", nil, "", false},
+		{
+			"Query of WebSocket handshake",
+			"/boards/b/session?access_token=token",
+			map[string]string{"Connection": "Upgrade", "Upgrade": "websocket"},
+			"token",
+			true,
+		},
+		{
+			"Query of WebSocket handshake to another route",
+			"/boards/b/events?access_token=token",
+			map[string]string{"Connection": "Upgrade", "Upgrade": "websocket"},
+			"",
+			false,
+		},
+	}
+	const sessionPattern = "/boards/{boardId}/session"
+	mux := http.NewServeMux()
+	mux.Handle(sessionPattern, http.NotFoundHandler())
+	mux.Handle("/", http.NotFoundHandler())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for name, value := range tt.header {
+				r.Header.Set(name, value)
+			}
+			// pattern is set by ServeMux before route handler and its middlewares run
+			_, r.Pattern = mux.Handler(r)
+			token, found := requestAccessToken(r, []string{sessionPattern})
+			if found != tt.withToken || found && token != tt.expected {
+				t.Fatalf("got token %q, found %t", token, found)
+			}
+		})
+	}
+}
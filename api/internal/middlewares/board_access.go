@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/pkg/sqlddl"
+)
+
+var (
+	noBoardIdErr      = errors.New("no board identifier in path")
+	boardForbiddenErr = errors.New("not allowed action for requester user")
+)
+
+// BoardPermissionChecker checks permissions of board members, usually it's services.BoardMemberService
+type BoardPermissionChecker interface {
+	Can(ctx context.Context, userId, boardId sqlddl.ID, permission access.Permission) bool
+}
+
+// BoardAccess proxies requests to board routes only if requester is a member of board from path
+// whose role grants permission required for request method according to requirements
+func BoardAccess(next http.Handler, checker BoardPermissionChecker, requirements access.Requirements) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		boardId := sqlddl.ID(r.PathValue(config.ParamBoardID))
+		if boardId == "" {
+			http.Error(w, noBoardIdErr.Error(), http.StatusBadRequest)
+			return
+		}
+		permission, allowed := requirements.PermissionFor(r.Method)
+		if !allowed {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		userId, _ := contextkeys.GetUserId(ctx)
+		if !checker.Can(ctx, userId, boardId, permission) {
+			http.Error(w, boardForbiddenErr.Error(), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/config"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/pkg/sqlddl"
+)
+
+const accessBoardId = sqlddl.ID("board")
+
+// fakeBoardMembers grants permissions of roles to members of accessBoardId found by their user identifiers
+type fakeBoardMembers map[sqlddl.ID]access.Role
+
+func (fbm fakeBoardMembers) Can(_ context.Context, userId, boardId sqlddl.ID, permission access.Permission) bool {
+	role, ok := fbm[userId]
+	if !ok || boardId != accessBoardId {
+		return false
+	}
+	return slices.Contains(access.BuiltInRoles[role], permission)
+}
+
+func TestBoardAccess(t *testing.T) {
+	members := fakeBoardMembers{
+		"owner":   access.RoleOwner,
+		"manager": access.RoleManager,
+		"regular": access.RoleRegular,
+	}
+	allowed := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tests := []struct {
+		name         string
+		requirements access.Requirements
+		method       string
+		userId       sqlddl.ID
+		expected     int
+	}{
+		{"Owner reads board", access.BoardRequirements, http.MethodGet, "owner", http.StatusOK},
+		{"Owner updates board", access.BoardRequirements, http.MethodPatch, "owner", http.StatusOK},
+		{"Owner deletes board", access.BoardRequirements, http.MethodDelete, "owner", http.StatusOK},
+		{"Manager reads board", access.BoardRequirements, http.MethodGet, "manager", http.StatusOK},
+		{"Manager updates board", access.BoardRequirements, http.MethodPatch, "manager", http.StatusOK},
+		{"Manager can't delete board", access.BoardRequirements, http.MethodDelete, "manager", http.StatusForbidden},
+		{"Regular reads board", access.BoardRequirements, http.MethodGet, "regular", http.StatusOK},
+		{"Regular can't update board", access.BoardRequirements, http.MethodPatch, "regular", http.StatusForbidden},
+		{"Regular can't delete board", access.BoardRequirements, http.MethodDelete, "regular", http.StatusForbidden},
+		{"Non-member can't read board", access.BoardRequirements, http.MethodGet, "stranger", http.StatusForbidden},
+
+		{"Owner creates column", access.ColumnsRequirements, http.MethodPost, "owner", http.StatusOK},
+		{"Manager creates column", access.ColumnsRequirements, http.MethodPost, "manager", http.StatusOK},
+		{"Regular reads columns", access.ColumnsRequirements, http.MethodGet, "regular", http.StatusOK},
+		{"Regular can't create column", access.ColumnsRequirements, http.MethodPost, "regular", http.StatusForbidden},
+		{"Non-member can't read columns", access.ColumnsRequirements, http.MethodGet, "stranger", http.StatusForbidden},
+
+		{"Owner creates role", access.RolesRequirements, http.MethodPost, "owner", http.StatusOK},
+		{"Manager can't create role", access.RolesRequirements, http.MethodPost, "manager", http.StatusForbidden},
+		{"Regular reads roles", access.RolesRequirements, http.MethodGet, "regular", http.StatusOK},
+
+		{"Manager invites member", access.MembersRequirements, http.MethodPost, "manager", http.StatusOK},
+		{"Regular can't invite member", access.MembersRequirements, http.MethodPost, "regular", http.StatusForbidden},
+		{"Manager changes member role", access.MemberRequirements, http.MethodPatch, "manager", http.StatusOK},
+		{"Regular can't change member role", access.MemberRequirements, http.MethodPatch, "regular", http.StatusForbidden},
+		{"Regular leaves board", access.MemberRequirements, http.MethodDelete, "regular", http.StatusOK},
+
+		{"Regular creates task", access.TasksRequirements, http.MethodPost, "regular", http.StatusOK},
+		{"Regular updates task", access.TaskRequirements, http.MethodPatch, "regular", http.StatusOK},
+		{"Regular deletes task", access.TaskRequirements, http.MethodDelete, "regular", http.StatusOK},
+		{"Non-member can't update task", access.TaskRequirements, http.MethodPatch, "stranger", http.StatusForbidden},
+
+		{"Regular creates comment", access.CommentsRequirements, http.MethodPost, "regular", http.StatusOK},
+		{"Regular moves task", access.TaskPartsRequirements, http.MethodPost, "regular", http.StatusOK},
+		{"Non-member can't create comment", access.CommentsRequirements, http.MethodPost, "stranger", http.StatusForbidden},
+
+		{"Not listed method isn't allowed", access.TaskRequirements, http.MethodPut, "owner", http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(test.method, "/boards/board", nil)
+			r.SetPathValue(config.ParamBoardID, string(accessBoardId))
+			r = r.WithContext(context.WithValue(r.Context(), contextkeys.KeyUserId, test.userId))
+			BoardAccess(allowed, members, test.requirements).ServeHTTP(w, r)
+			if code := w.Result().StatusCode; code != test.expected {
+				t.Fatalf("got %d, expected code %d", code, test.expected)
+			}
+		})
+	}
+
+	t.Run("Request without board identifier is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/boards", nil)
+		r = r.WithContext(context.WithValue(r.Context(), contextkeys.KeyUserId, sqlddl.ID("owner")))
+		BoardAccess(allowed, members, access.MembersRequirements).ServeHTTP(w, r)
+		if code := w.Result().StatusCode; code != http.StatusBadRequest {
+			t.Fatalf("got %d, expected code %d", code, http.StatusBadRequest)
+		}
+	})
+}
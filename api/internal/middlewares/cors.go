@@ -10,11 +10,20 @@ import (
 	"just-kanban/pkg/tcp"
 )
 
-// CORS enables cors functionality for app routing
-func CORS(next http.Handler, allowedMethods map[string][]string) http.Handler {
+// CORS enables cors functionality for app routing.
+// Any origin is allowed if allowedOrigins is empty, otherwise only listed origins are
+func CORS(next http.Handler, allowedMethods map[string][]string, allowedOrigins []string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set(cors.HeaderAllowOrigin, "*")
+		if len(allowedOrigins) == 0 {
+			w.Header().Set(cors.HeaderAllowOrigin, "*")
+		} else {
+			w.Header().Add(cors.HeaderVary, cors.HeaderOrigin)
+			if origin := r.Header.Get(cors.HeaderOrigin); cors.IsOriginAllowed(origin, allowedOrigins) {
+				w.Header().Set(cors.HeaderAllowOrigin, origin)
+			}
+		}
 		w.Header().Set(cors.HeaderAllowCredentials, "true")
+		w.Header().Set(cors.HeaderExposeHeaders, fmt.Sprintf("%v, %v", tcp.HeaderLink, tcp.HeaderTotalCount))
 		if r.Method == http.MethodOptions {
 			for pattern, methods := range allowedMethods {
 				regex := router.PatternToRegex(pattern)
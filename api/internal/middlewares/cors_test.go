@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"just-kanban/pkg/cors"
+)
+
+func TestCORS(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expected       string
+	}{
+		{"Any origin without allow-list", nil, "https://app.example.com", "*"},
+		{"Listed origin", []string{"https://app.example.com"}, "https://app.example.com", "https://app.example.com"},
+		{"Not listed origin", []string{"https://app.example.com"}, "https://evil.example.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/boards", nil)
+			r.Header.Set(cors.HeaderOrigin, tt.origin)
+			CORS(next, nil, tt.allowedOrigins).ServeHTTP(w, r)
+			if allowedOrigin := w.Result().Header.Get(cors.HeaderAllowOrigin); allowedOrigin != tt.expected {
+				t.Fatalf("got allowed origin %q, expected %q", allowedOrigin, tt.expected)
+			}
+		})
+	}
+}
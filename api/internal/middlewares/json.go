@@ -6,11 +6,38 @@ import (
 	"just-kanban/pkg/tcp"
 )
 
-// JSONResponse proxies requests and write them headers required for json responses to client.
-// If another type of response data has to be provided, then need to rewrite related headers on place
+// JSONResponse proxies requests and writes json content type header to responses which haven't set another one.
+// If another type of response data has to be provided, then handler sets related headers before writing response
 func JSONResponse(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set(tcp.HeaderContentType, tcp.ContentTypeJSON)
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(&jsonResponseWriter{ResponseWriter: w}, r)
 	})
 }
+
+// jsonResponseWriter sets json content type right before headers are sent if handler hasn't set it
+type jsonResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (jrw *jsonResponseWriter) WriteHeader(statusCode int) {
+	if !jrw.wroteHeader {
+		jrw.wroteHeader = true
+		if jrw.Header().Get(tcp.HeaderContentType) == "" {
+			jrw.Header().Set(tcp.HeaderContentType, tcp.ContentTypeJSON)
+		}
+	}
+	jrw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (jrw *jsonResponseWriter) Write(b []byte) (int, error) {
+	if !jrw.wroteHeader {
+		jrw.WriteHeader(http.StatusOK)
+	}
+	return jrw.ResponseWriter.Write(b)
+}
+
+// Unwrap gives http.ResponseController access to features of original writer, e.g. flushing
+func (jrw *jsonResponseWriter) Unwrap() http.ResponseWriter {
+	return jrw.ResponseWriter
+}
@@ -0,0 +1,35 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"just-kanban/pkg/tcp"
+)
+
+func TestJSONResponse(t *testing.T) {
+	t.Run("JSON content type is set by default", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler := JSONResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("{}"))
+		}))
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if contentType := w.Result().Header.Get(tcp.HeaderContentType); contentType != tcp.ContentTypeJSON {
+			t.Fatalf("got %s, expected content type %s", contentType, tcp.ContentTypeJSON)
+		}
+	})
+
+	t.Run("Content type set by handler is kept", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler := JSONResponse(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(tcp.HeaderContentType, "image/png")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte{0x89, 'P', 'N', 'G'})
+		}))
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if contentType := w.Result().Header.Get(tcp.HeaderContentType); contentType != "image/png" {
+			t.Fatalf("got %s, expected content type image/png", contentType)
+		}
+	})
+}
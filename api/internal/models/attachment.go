@@ -0,0 +1,18 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// Attachment is metadata of file attached to task, file content is kept in blob store under attachment ID
+type Attachment struct {
+	Model
+	// TaskID is identifier of task which file is attached to
+	TaskID sqlddl.ID `db:"task_id" json:"task_id"`
+	// UploaderID is identifier of user who uploaded file, nil once user is deleted
+	UploaderID *sqlddl.ID `db:"uploader_id" json:"uploader_id"`
+	// Filename is original name of uploaded file
+	Filename string `db:"filename" json:"filename"`
+	// Size is file size in bytes
+	Size int64 `db:"size" json:"size"`
+	// ContentType is media type detected from file content
+	ContentType string `db:"content_type" json:"content_type"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Board is project board in business logic layer.
 type Board struct {
 	Model
@@ -7,4 +9,10 @@ type Board struct {
 	Name string `db:"name" json:"name"`
 	// Description summarizes the board's purpose.
 	Description string `db:"description" json:"description"`
+	// ArchivedAt is when the board was archived, archived boards are left out of listings.
+	ArchivedAt *time.Time `db:"archived_at" json:"archived_at"`
+	// DeletedAt is when the board was moved to trash, it's purged once retention period passes.
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
+	// IsTemplate marks reusable board which new boards are instantiated from, templates are left out of listings.
+	IsTemplate bool `db:"is_template" json:"is_template"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// BoardColumn is workflow column of project board in business logic layer
+type BoardColumn struct {
+	Model
+	// BoardID is identifier of project board which column belongs to
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// Name is column title, e.g. "Review" or "QA"
+	Name string `db:"name" json:"name"`
+	// Order is column position on its project board (BoardID), starts from 1
+	Order int `db:"order" json:"order"`
+	// Status is category of column, tasks placed into column get the same status
+	Status TaskStatus `db:"status" json:"status"`
+	// WIPLimit is maximum number of tasks placed into column, column isn't limited if it's 0
+	WIPLimit int `db:"wip_limit" json:"wip_limit"`
+}
+
+// DefaultBoardColumns are columns which every new board starts with, they match legacy task statuses
+var DefaultBoardColumns = []BoardColumn{
+	{Name: "Backlog", Order: 1, Status: TaskStatusBacklog},
+	{Name: "In progress", Order: 2, Status: TaskStatusProcess},
+	{Name: "Done", Order: 3, Status: TaskStatusDone},
+}
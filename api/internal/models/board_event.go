@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"just-kanban/pkg/sqlddl"
+)
+
+// BoardEventType is kind of board change
+type BoardEventType string
+
+const (
+	BoardEventTaskCreated   BoardEventType = "task.created"
+	BoardEventTaskUpdated   BoardEventType = "task.updated"
+	BoardEventTaskMoved     BoardEventType = "task.moved"
+	BoardEventTaskDeleted   BoardEventType = "task.deleted"
+	BoardEventMemberAdded   BoardEventType = "member.added"
+	BoardEventMemberRemoved BoardEventType = "member.removed"
+)
+
+// BoardEvent is change of board delivered to clients watching the board
+type BoardEvent struct {
+	// ID is sequence number of event, it grows with every published event
+	ID      uint64         `json:"id"`
+	Type    BoardEventType `json:"type"`
+	BoardID sqlddl.ID      `json:"board_id"`
+	// ActorID is identifier of user who made the change
+	ActorID sqlddl.ID `json:"actor_id"`
+	// Data is changed record, e.g. Task after change or BoardMember before removal
+	Data       any       `json:"data"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"time"
+
+	"just-kanban/internal/access"
+)
+
+// BoardExportVersion is version of board export format, documents of other versions can't be imported
+const BoardExportVersion = 1
+
+type (
+	// BoardExport is self-contained document with board and its records, it contains no identifiers of records,
+	// users are referenced by username and email, columns by order, labels by name and tasks by order
+	BoardExport struct {
+		// Version is version of format document was exported in
+		Version    int              `json:"version" validate:"required"`
+		ExportedAt time.Time        `json:"exported_at"`
+		Board      ExportedBoard    `json:"board"`
+		Columns    []ExportedColumn `json:"columns" validate:"required,min=1,unique=Order,dive"`
+		Labels     []ExportedLabel  `json:"labels" validate:"unique=Name,dive"`
+		Roles      []ExportedRole   `json:"roles" validate:"unique=Name,dive"`
+		Members    []ExportedMember `json:"members" validate:"dive"`
+		Tasks      []ExportedTask   `json:"tasks" validate:"unique=Order,unique=Name,dive"`
+	}
+
+	ExportedBoard struct {
+		Name        string `json:"name" validate:"required,min=3,max=255,trimmed"`
+		Description string `json:"description" validate:"max=1000,trimmed"`
+	}
+
+	ExportedColumn struct {
+		Name     string     `json:"name" validate:"required,min=1,max=100,trimmed"`
+		Order    int        `json:"order" validate:"min=1"`
+		Status   TaskStatus `json:"status" validate:"required,oneof=1 2 3"`
+		WIPLimit int        `json:"wip_limit" validate:"min=0"`
+	}
+
+	ExportedLabel struct {
+		Name  string `json:"name" validate:"required,max=50,trimmed"`
+		Color string `json:"color" validate:"required,hexcolor"`
+	}
+
+	// ExportedRole is custom role of board
+	ExportedRole struct {
+		Name        access.Role         `json:"name" validate:"required,max=100,trimmed"`
+		Permissions []access.Permission `json:"permissions" validate:"required,dive,required"`
+	}
+
+	ExportedMember struct {
+		User UserReference `json:"user"`
+		Role access.Role   `json:"role" validate:"required,max=100"`
+	}
+
+	// UserReference identifies user across app instances, user is searched by email first
+	UserReference struct {
+		Username string `json:"username" validate:"required_without=Email"`
+		Email    string `json:"email" validate:"omitempty,email"`
+	}
+
+	ExportedTask struct {
+		Order       int    `json:"order" validate:"min=1"`
+		Name        string `json:"name" validate:"required,min=3,max=255,trimmed"`
+		Description string `json:"description" validate:"max=1000,trimmed"`
+		// Column is order of column which task is placed into
+		Column int `json:"column"`
+		// Position is task place inside its column, archived tasks have 0
+		Position   int           `json:"position" validate:"min=0"`
+		Creator    UserReference `json:"creator"`
+		Assignee   UserReference `json:"assignee"`
+		StartAt    *time.Time    `json:"start_at"`
+		DueAt      *time.Time    `json:"due_at"`
+		ArchivedAt *time.Time    `json:"archived_at"`
+		// Labels are names of board labels attached to task
+		Labels    []string                `json:"labels" validate:"dive,required"`
+		Checklist []ExportedChecklistItem `json:"checklist" validate:"dive"`
+		// BlockedBy are orders of tasks of the same board which have to be done before this one
+		BlockedBy []int             `json:"blocked_by"`
+		Comments  []ExportedComment `json:"comments" validate:"unique=Number,dive"`
+	}
+
+	ExportedChecklistItem struct {
+		Text     string         `json:"text" validate:"required,max=500,trimmed"`
+		Done     bool           `json:"done"`
+		Order    int            `json:"order" validate:"min=1"`
+		Assignee *UserReference `json:"assignee"`
+	}
+
+	ExportedComment struct {
+		// Number identifies comment among comments of its task
+		Number int `json:"number" validate:"min=1"`
+		// ReplyTo is number of comment which this one replies to, nil for top level comments
+		ReplyTo   *int          `json:"reply_to"`
+		Author    UserReference `json:"author"`
+		Body      string        `json:"body" validate:"required,max=5000,trimmed"`
+		CreatedAt time.Time     `json:"created_at"`
+	}
+)
+
+// String formats reference for reports, email is preferred over username
+func (ur UserReference) String() string {
+	if ur.Email != "" {
+		return ur.Email
+	}
+	return ur.Username
+}
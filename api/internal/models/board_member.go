@@ -12,6 +12,6 @@ type BoardMember struct {
 	UserID sqlddl.ID `json:"user_id"`
 	// BoardID is identifier of project board which member related to
 	BoardID sqlddl.ID `json:"board_id"`
-	// Role defines member accesses to desk, must be sync with access.Role constants
-	Role access.Role `json:"role" validate:"oneof=owner manager regular"`
+	// Role defines member accesses to desk, it's one of access.BuiltInRoles or custom role of board
+	Role access.Role `json:"role" validate:"required,max=100"`
 }
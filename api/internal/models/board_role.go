@@ -0,0 +1,18 @@
+package models
+
+import (
+	"just-kanban/internal/access"
+	"just-kanban/pkg/sqlddl"
+)
+
+// BoardRole is a role which board members may have, besides custom roles of board
+// it describes built-in roles which have no identifier and can't be changed
+type BoardRole struct {
+	Model
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// Name is role stored into board members records
+	Name        access.Role         `db:"name" json:"name"`
+	Permissions []access.Permission `db:"permissions" json:"permissions"`
+	// BuiltIn is true for roles available on every board
+	BuiltIn bool `json:"built_in"`
+}
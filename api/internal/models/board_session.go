@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"just-kanban/pkg/sqlddl"
+)
+
+// SessionMessageType is kind of message of collaborative board session
+type SessionMessageType string
+
+const (
+	// SessionMessageTypingStarted is sent by client when user starts typing in field of task and relayed to others
+	SessionMessageTypingStarted SessionMessageType = "typing.started"
+	// SessionMessageTypingStopped is sent by client when user stops typing in field of task and relayed to others
+	SessionMessageTypingStopped SessionMessageType = "typing.stopped"
+	// SessionMessageLockAcquire is sent by client to lock task description for editing or to renew its lock
+	SessionMessageLockAcquire SessionMessageType = "lock.acquire"
+	// SessionMessageLockRelease is sent by client when user stops editing task description
+	SessionMessageLockRelease SessionMessageType = "lock.release"
+
+	// SessionMessagePresenceState is sent to client right after connection with viewers and locks of board
+	SessionMessagePresenceState SessionMessageType = "presence.state"
+	// SessionMessagePresenceJoined is sent when another session of board is opened
+	SessionMessagePresenceJoined SessionMessageType = "presence.joined"
+	// SessionMessagePresenceLeft is sent when another session of board is closed or timed out
+	SessionMessagePresenceLeft SessionMessageType = "presence.left"
+	// SessionMessageLockAcquired is sent to all sessions of board when task lock is acquired or renewed
+	SessionMessageLockAcquired SessionMessageType = "lock.acquired"
+	// SessionMessageLockReleased is sent to all sessions of board when task lock is released or expired
+	SessionMessageLockReleased SessionMessageType = "lock.released"
+	// SessionMessageLockDenied is sent to client whose task is locked by another session
+	SessionMessageLockDenied SessionMessageType = "lock.denied"
+	// SessionMessageError is sent to client whose message can't be handled
+	SessionMessageError SessionMessageType = "error"
+)
+
+// BoardViewer is user viewing board in session, user with several open sessions is several viewers
+type BoardViewer struct {
+	SessionID sqlddl.ID `json:"session_id"`
+	UserID    sqlddl.ID `json:"user_id"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// TaskLock is soft lock of task description editing, it's advisory and doesn't block task updates
+type TaskLock struct {
+	TaskID    sqlddl.ID `json:"task_id"`
+	SessionID sqlddl.ID `json:"session_id"`
+	UserID    sqlddl.ID `json:"user_id"`
+	// ExpiresAt is time when lock is released unless it's renewed by its session
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SessionMessage is message sent to clients of board session, only fields related to its type are set
+type SessionMessage struct {
+	Type SessionMessageType `json:"type"`
+	// SessionID is identifier of client's own session in presence.state
+	SessionID sqlddl.ID `json:"session_id,omitempty"`
+	// Viewer is author of presence and typing messages
+	Viewer  *BoardViewer  `json:"viewer,omitempty"`
+	Viewers []BoardViewer `json:"viewers,omitempty"`
+	Locks   []TaskLock    `json:"locks,omitempty"`
+	// Lock is changed lock of lock messages, it's lock of another session in lock.denied
+	Lock   *TaskLock `json:"lock,omitempty"`
+	TaskID sqlddl.ID `json:"task_id,omitempty"`
+	// Field is task field which user types in
+	Field  string            `json:"field,omitempty"`
+	Error  string            `json:"error,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// ChecklistItem is step of task which can be done separately
+type ChecklistItem struct {
+	Model
+	// TaskID is identifier of task which item belongs to
+	TaskID sqlddl.ID `db:"task_id" json:"task_id"`
+	Text   string    `db:"text" json:"text"`
+	Done   bool      `db:"done" json:"done"`
+	// AssigneeID is identifier of user who is responsible for item, nil if item isn't assigned
+	AssigneeID *sqlddl.ID `db:"assignee_id" json:"assignee_id"`
+	// Order is item place inside task checklist, starts from 1
+	Order int `db:"order" json:"order"`
+}
+
+// UpdateChecklistItem is data to update ChecklistItem
+type UpdateChecklistItem struct {
+	Text *string `json:"text"`
+	Done *bool   `json:"done"`
+	// AssigneeID is new assignee of item, empty identifier unassigns item
+	AssigneeID *sqlddl.ID `json:"assignee_id"`
+}
+
+// ChecklistProgress is count of done checklist items among all items of task
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// Comment is message of discussion under task in business logic layer
+type Comment struct {
+	Model
+	// TaskID is identifier of task which comment is written to
+	TaskID sqlddl.ID `db:"task_id" json:"task_id"`
+	// AuthorID is identifier of user who wrote comment
+	AuthorID sqlddl.ID `db:"author_id" json:"author_id"`
+	// ParentID is identifier of comment which this one replies to, nil for top level comments
+	ParentID *sqlddl.ID `db:"parent_id" json:"parent_id"`
+	// Body is text of comment
+	Body string `db:"body" json:"body"`
+	// Replies are comments which reply to this one, only top level comments have them
+	Replies []Comment `db:"-" json:"replies,omitempty"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"just-kanban/internal/access"
+	"just-kanban/pkg/sqlddl"
+)
+
+// InvitationStatus is state of invitation computed from its records
+type InvitationStatus string
+
+const (
+	// InvitationPending is invitation which may be accepted or declined
+	InvitationPending InvitationStatus = "pending"
+	// InvitationRevoked is invitation cancelled by board member
+	InvitationRevoked InvitationStatus = "revoked"
+	// InvitationDeclined is email invitation declined by invitee
+	InvitationDeclined InvitationStatus = "declined"
+	// InvitationExpired is invitation which wasn't used before its expiry
+	InvitationExpired InvitationStatus = "expired"
+	// InvitationUsedUp is invitation accepted as many times as its usage limit allows
+	InvitationUsedUp InvitationStatus = "used_up"
+)
+
+// Invitation is offer to join board with role, it's addressed to user by email
+// or to anyone who has shareable link token
+type Invitation struct {
+	Model
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// InviterID is identifier of user who created invitation, nil once user is deleted
+	InviterID *sqlddl.ID `db:"inviter_id" json:"inviter_id"`
+	// Email is address of invitee, it's empty for link invitations
+	Email string `db:"email" json:"email,omitempty"`
+	// Token is secret of link invitation, it's known only right after creation since only its hash is kept
+	Token     string `json:"token,omitempty"`
+	TokenHash string `db:"token_hash" json:"-"`
+	// Role is role given to invitee once invitation is accepted
+	Role      access.Role `db:"role" json:"role"`
+	ExpiresAt time.Time   `db:"expires_at" json:"expires_at"`
+	// MaxUses is how many times invitation may be accepted, there is no limit if it's 0
+	MaxUses int `db:"max_uses" json:"max_uses"`
+	// Uses is how many times invitation was accepted
+	Uses       int        `db:"uses" json:"uses"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at"`
+	RevokedBy  *sqlddl.ID `db:"revoked_by" json:"revoked_by"`
+	DeclinedAt *time.Time `db:"declined_at" json:"declined_at"`
+	// Status is computed state of invitation
+	Status InvitationStatus `json:"status"`
+	// Responses is audit of users who accepted or declined invitation, it's filled for single invitation only
+	Responses []InvitationResponse `json:"responses,omitempty"`
+}
+
+// InvitationResponse is record of user accepting or declining invitation
+type InvitationResponse struct {
+	Model
+	InvitationID sqlddl.ID `db:"invitation_id" json:"invitation_id"`
+	UserID       sqlddl.ID `db:"user_id" json:"user_id"`
+	Accepted     bool      `db:"accepted" json:"accepted"`
+}
+
+// IsLink checks invitation is shareable link rather than addressed to email
+func (i *Invitation) IsLink() bool {
+	return i.Email == ""
+}
+
+// ComputeStatus returns state of invitation at provided moment
+func (i *Invitation) ComputeStatus(now time.Time) InvitationStatus {
+	switch {
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case i.DeclinedAt != nil:
+		return InvitationDeclined
+	case i.MaxUses > 0 && i.Uses >= i.MaxUses:
+		return InvitationUsedUp
+	case !now.Before(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
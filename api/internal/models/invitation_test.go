@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInvitationComputeStatus(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	tests := []struct {
+		name       string
+		invitation Invitation
+		expected   InvitationStatus
+	}{
+		{"Pending email invitation", Invitation{Email: "a@b.c", ExpiresAt: now.Add(time.Hour), MaxUses: 1}, InvitationPending},
+		{"Pending unlimited link", Invitation{ExpiresAt: now.Add(time.Hour), Uses: 100}, InvitationPending},
+		{"Expired at exact moment", Invitation{ExpiresAt: now}, InvitationExpired},
+		{"Used up link", Invitation{ExpiresAt: now.Add(time.Hour), MaxUses: 2, Uses: 2}, InvitationUsedUp},
+		{"Accepted email invitation", Invitation{Email: "a@b.c", ExpiresAt: now.Add(time.Hour), MaxUses: 1, Uses: 1}, InvitationUsedUp},
+		{"Declined invitation", Invitation{Email: "a@b.c", ExpiresAt: now.Add(time.Hour), DeclinedAt: &past}, InvitationDeclined},
+		{"Revoked expired invitation", Invitation{ExpiresAt: past, RevokedAt: &past}, InvitationRevoked},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if status := test.invitation.ComputeStatus(now); status != test.expected {
+				t.Fatalf("got %s, expected status %s", status, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// Label is board-owned category which can be attached to tasks of the board
+type Label struct {
+	Model
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	Name    string    `db:"name" json:"name"`
+	// Color is hex representation of label colour, e.g. #ff0000
+	Color string `db:"color" json:"color"`
+}
+
+// UpdateLabel is data to update Label
+type UpdateLabel struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
@@ -14,3 +14,14 @@ type Model struct {
 	// UpdatedAt contains info as timestamp when this model been updated last time
 	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
 }
+
+// PageKey is position of record in listings sorted by creation time, next page starts after it
+type PageKey struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        sqlddl.ID `json:"id"`
+}
+
+// PageKey returns position of model in listings sorted by creation time
+func (m *Model) PageKey() PageKey {
+	return PageKey{CreatedAt: m.CreatedAt, ID: m.ID}
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"html"
+	"strings"
+
+	"just-kanban/pkg/sqlddl"
+)
+
+// SearchResultKind is kind of record matched by search
+type SearchResultKind string
+
+const (
+	SearchResultTask  SearchResultKind = "task"
+	SearchResultBoard SearchResultKind = "board"
+)
+
+// SearchResult is record matched by full-text search, matched words of Name and Snippet are wrapped into
+// SearchHighlightStart and SearchHighlightStop, the rest of text is HTML-escaped
+type SearchResult struct {
+	Kind SearchResultKind `db:"kind" json:"kind"`
+	// ID is identifier of matched task or board
+	ID      sqlddl.ID `db:"id" json:"id"`
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// TaskOrder is order of matched task on its board, nil for boards
+	TaskOrder *int   `db:"task_order" json:"task_order"`
+	Name      string `db:"name" json:"name"`
+	// Snippet is fragment of description which matches search
+	Snippet string  `db:"snippet" json:"snippet"`
+	Rank    float64 `db:"rank" json:"rank"`
+}
+
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightStop  = "</mark>"
+	// SearchMatchStart and SearchMatchStop delimit matches in text highlighted by storage, they're control
+	// characters, so text of tasks and boards isn't confused with them
+	SearchMatchStart = "\x02"
+	SearchMatchStop  = "\x03"
+)
+
+// searchHighlighter replaces delimiters of matches with HTML tags
+var searchHighlighter = strings.NewReplacer(
+	SearchMatchStart,
+	SearchHighlightStart,
+	SearchMatchStop,
+	SearchHighlightStop,
+)
+
+// HighlightSearchMatches HTML-escapes text whose matches are delimited by SearchMatchStart and SearchMatchStop,
+// then wraps matches into SearchHighlightStart and SearchHighlightStop
+func HighlightSearchMatches(text string) string {
+	return searchHighlighter.Replace(html.EscapeString(text))
+}
@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestHighlightSearchMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Plain text", "Release notes", "Release notes"},
+		{"Matches", "Release \x02notes\x03 and \x02docs\x03", "Release <mark>notes</mark> and <mark>docs</mark>"},
+		{
+			"Markup of text is escaped",
+			"<img src=x onerror=\"alert(1)\"> \x02<mark>\x03",
+			"&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>&lt;mark&gt;</mark>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if highlighted := HighlightSearchMatches(tt.text); highlighted != tt.expected {
+				t.Fatalf("got %q, expected %q", highlighted, tt.expected)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package models
 
-import "just-kanban/pkg/sqlddl"
+import (
+	"time"
 
+	"just-kanban/pkg/sqlddl"
+)
+
+// TaskStatus is category of workflow, each BoardColumn belongs to one of them
 type TaskStatus uint
 
 const (
@@ -19,12 +24,34 @@ type Task struct {
 	CreatorID sqlddl.ID `db:"creator_id" json:"creator_id"`
 	// AssigneeID is identifier of user who is task assignee
 	AssigneeID sqlddl.ID `db:"assignee_id" json:"assignee_id"`
-	// Order is task position on its project board (BoardID), incremental
+	// Order is task sequence number on its project board (BoardID), incremental and never changes
 	Order int `db:"order" json:"order"`
+	// Position is task place inside its column (ColumnID), starts from 1, archived and deleted tasks have 0
+	Position int `db:"position" json:"position"`
 	// Name is task title
 	Name string `db:"name" json:"name"`
 	// Description is task description which contains info about subject of task
 	Description string `db:"description" json:"description"`
-	// Status is task status that it's on at this moment
+	// ColumnID is identifier of board column which task is placed into
+	ColumnID sqlddl.ID `db:"column_id" json:"column_id"`
+	// Status is task status that it's on at this moment, always equal to status of its column (ColumnID)
 	Status TaskStatus `db:"status" json:"status"`
+	// StartAt is time when work on task is planned to start, not after DueAt
+	StartAt *time.Time `db:"start_at" json:"start_at"`
+	// DueAt is deadline of task
+	DueAt *time.Time `db:"due_at" json:"due_at"`
+	// RemindedAt is time when the last reminder about approaching or missed DueAt has been sent
+	RemindedAt *time.Time `db:"reminded_at" json:"-"`
+	// ArchivedAt is time when task was archived, archived task is taken out of its column and left out of listings
+	ArchivedAt *time.Time `db:"archived_at" json:"archived_at"`
+	// DeletedAt is time when task was moved to trash, it's purged once retention period passes
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at"`
+	// Labels are board labels attached to task
+	Labels []Label `db:"-" json:"labels"`
+	// ChecklistProgress is computed progress of task checklist items
+	ChecklistProgress ChecklistProgress `db:"-" json:"checklist_progress"`
+	// BlockedBy are tasks which have to be done before this one
+	BlockedBy []TaskReference `db:"-" json:"blocked_by"`
+	// Blocks are tasks which can't be done before this one
+	Blocks []TaskReference `db:"-" json:"blocks"`
 }
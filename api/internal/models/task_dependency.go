@@ -0,0 +1,19 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// TaskDependency is link meaning blocker task has to be done before blocked one, tasks may live on different boards
+type TaskDependency struct {
+	Model
+	BlockerID sqlddl.ID `db:"blocker_id" json:"blocker_id"`
+	BlockedID sqlddl.ID `db:"blocked_id" json:"blocked_id"`
+}
+
+// TaskReference is short info about task linked to another one
+type TaskReference struct {
+	ID      sqlddl.ID  `db:"id" json:"id"`
+	BoardID sqlddl.ID  `db:"board_id" json:"board_id"`
+	Order   int        `db:"order" json:"order"`
+	Name    string     `db:"name" json:"name"`
+	Status  TaskStatus `db:"status" json:"status"`
+}
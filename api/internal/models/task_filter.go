@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"just-kanban/pkg/sqlddl"
+)
+
+// TaskSortField is task field which board tasks list can be sorted by
+type TaskSortField string
+
+const (
+	TaskSortOrder     TaskSortField = "order"
+	TaskSortCreatedAt TaskSortField = "created_at"
+	TaskSortUpdatedAt TaskSortField = "updated_at"
+)
+
+// TaskFilter is conditions, sorting and page of board tasks list, empty conditions aren't applied
+type TaskFilter struct {
+	Statuses    []TaskStatus
+	AssigneeID  sqlddl.ID
+	CreatorID   sqlddl.ID
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+	// Archived lists archived tasks instead of active ones
+	Archived bool
+	// SortBy is field tasks are sorted by, TaskSortOrder if it's empty
+	SortBy     TaskSortField
+	Descending bool
+	Limit      int
+	Offset     int
+}
@@ -0,0 +1,38 @@
+package models
+
+import "just-kanban/pkg/sqlddl"
+
+// TaskHistoryAction is kind of change made to task
+type TaskHistoryAction string
+
+const (
+	TaskHistoryCreated       TaskHistoryAction = "created"
+	TaskHistoryUpdated       TaskHistoryAction = "updated"
+	TaskHistoryMoved         TaskHistoryAction = "moved"
+	TaskHistoryStatusChanged TaskHistoryAction = "status_changed"
+	TaskHistoryReassigned    TaskHistoryAction = "reassigned"
+	TaskHistoryDeleted       TaskHistoryAction = "deleted"
+	TaskHistoryArchived      TaskHistoryAction = "archived"
+	TaskHistoryUnarchived    TaskHistoryAction = "unarchived"
+	TaskHistoryRestored      TaskHistoryAction = "restored"
+	// TaskHistoryWIPLimitOverridden is placement of task into full column, old value is column limit
+	// and new value is number of column tasks including placed one
+	TaskHistoryWIPLimitOverridden TaskHistoryAction = "wip_limit_overridden"
+)
+
+// TaskHistoryEntry is record about single change of task, entries of deleted tasks are kept on their board
+type TaskHistoryEntry struct {
+	Model
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// TaskID is identifier of changed task, nil once task is deleted
+	TaskID *sqlddl.ID `db:"task_id" json:"task_id"`
+	// TaskOrder is order of changed task on its board, kept for deleted tasks
+	TaskOrder int `db:"task_order" json:"task_order"`
+	// ActorID is identifier of user who made change, nil once user is deleted
+	ActorID *sqlddl.ID        `db:"actor_id" json:"actor_id"`
+	Action  TaskHistoryAction `db:"action" json:"action"`
+	// Field is name of changed task field, empty for creation and deletion
+	Field    string  `db:"field" json:"field,omitempty"`
+	OldValue *string `db:"old_value" json:"old_value"`
+	NewValue *string `db:"new_value" json:"new_value"`
+}
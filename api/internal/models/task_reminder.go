@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// TaskReminderKind is reason of reminding about task
+type TaskReminderKind string
+
+const (
+	// TaskReminderDueSoon is sent once task's due date is approaching
+	TaskReminderDueSoon TaskReminderKind = "due_soon"
+	// TaskReminderOverdue is sent once task's due date is missed
+	TaskReminderOverdue TaskReminderKind = "overdue"
+)
+
+// TaskReminder is event about task which due date is approaching or missed
+type TaskReminder struct {
+	Kind TaskReminderKind `json:"kind"`
+	Task Task             `json:"task"`
+	// RemindedAt is time when reminder has been emitted
+	RemindedAt time.Time `json:"reminded_at"`
+}
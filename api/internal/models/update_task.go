@@ -1,11 +1,19 @@
 package models
 
-import "just-kanban/pkg/sqlddl"
+import (
+	"time"
+
+	"just-kanban/pkg/sqlddl"
+)
 
 // UpdateTask is data to update Task
 type UpdateTask struct {
 	Name        *string     `json:"name"`
 	Description *string     `json:"description"`
 	Status      *TaskStatus `json:"status"`
+	ColumnID    *sqlddl.ID  `json:"column_id"`
 	AssigneeID  *sqlddl.ID  `json:"assignee_id"`
+	Position    *int        `json:"position"`
+	StartAt     *time.Time  `json:"start_at"`
+	DueAt       *time.Time  `json:"due_at"`
 }
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"just-kanban/pkg/sqlddl"
+)
+
+// WebhookPayloadVersion is version of webhook payload format, it's increased on incompatible changes of payload
+const WebhookPayloadVersion = 1
+
+// Webhook is endpoint of external service which receives board events, e.g. CI, chat bot or reporting tool
+type Webhook struct {
+	Model
+	BoardID sqlddl.ID `db:"board_id" json:"board_id"`
+	// CreatorID is identifier of user who registered webhook, nil once user is deleted
+	CreatorID *sqlddl.ID `db:"creator_id" json:"creator_id"`
+	URL       string     `db:"url" json:"url"`
+	// Events are types of board events sent to webhook, all events are sent if it's empty
+	Events []BoardEventType `db:"events" json:"events"`
+	// Secret is shared key of payload signatures, it's never returned back
+	Secret string `db:"secret" json:"-"`
+	// FailureCount is count of consecutive deliveries which failed after all retries
+	FailureCount int `db:"failure_count" json:"failure_count"`
+	// DisabledAt is time when webhook was disabled after too many failed deliveries, nil for active webhook
+	DisabledAt *time.Time `db:"disabled_at" json:"disabled_at"`
+}
+
+// WebhookDelivery is log record of single attempt to deliver board event to webhook
+type WebhookDelivery struct {
+	Model
+	WebhookID sqlddl.ID      `db:"webhook_id" json:"webhook_id"`
+	EventID   uint64         `db:"event_id" json:"event_id"`
+	EventType BoardEventType `db:"event_type" json:"event_type"`
+	// Attempt is number of delivery attempt starting from 1
+	Attempt int `db:"attempt" json:"attempt"`
+	// StatusCode is http status of webhook response, it's 0 if no response was received
+	StatusCode int `db:"status_code" json:"status_code"`
+	// Error describes failed attempt, it's empty for successful one
+	Error     string `db:"error" json:"error"`
+	Succeeded bool   `db:"succeeded" json:"succeeded"`
+	// Duration is how long attempt took in milliseconds
+	Duration int64 `db:"duration" json:"duration"`
+}
+
+// WebhookPayload is body posted to webhooks
+type WebhookPayload struct {
+	Version   int         `json:"version"`
+	WebhookID sqlddl.ID   `json:"webhook_id"`
+	Event     *BoardEvent `json:"event"`
+}
+
+// Accepts checks webhook receives events of provided type
+func (w *Webhook) Accepts(eventType BoardEventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventType)
+}
@@ -1,32 +1,105 @@
 package repositories
 
-import "just-kanban/pkg/sqlddl"
+import (
+	"fmt"
+	"strings"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
 
 const (
-	ColumnName        = "name"
-	ColumnDescription = "description"
-	ColumnUserID      = "user_id"
-	ColumnBoardID     = "board_id"
-	ColumnRole        = "role"
-	ColumnToken       = "token"
-	ColumnEmail       = "email"
-	ColumnPassword    = "password"
-	ColumnAvatar      = "avatar"
-	ColumnUsername    = "username"
-	ColumnFirstName   = "first_name"
-	ColumnsLastName   = "last_name"
-	ColumnStatus      = "status"
-	ColumnOrder       = `"order"`
-	ColumnAssigneeID  = "assignee_id"
-	ColumnCreatorID   = "creator_id"
+	ColumnName         = "name"
+	ColumnDescription  = "description"
+	ColumnUserID       = "user_id"
+	ColumnBoardID      = "board_id"
+	ColumnRole         = "role"
+	ColumnToken        = "token"
+	ColumnEmail        = "email"
+	ColumnPassword     = "password"
+	ColumnAvatar       = "avatar"
+	ColumnUsername     = "username"
+	ColumnFirstName    = "first_name"
+	ColumnsLastName    = "last_name"
+	ColumnStatus       = "status"
+	ColumnOrder        = `"order"`
+	ColumnAssigneeID   = "assignee_id"
+	ColumnCreatorID    = "creator_id"
+	ColumnColumnID     = "column_id"
+	ColumnPosition     = "position"
+	ColumnTaskID       = "task_id"
+	ColumnAuthorID     = "author_id"
+	ColumnParentID     = "parent_id"
+	ColumnBody         = "body"
+	ColumnColor        = "color"
+	ColumnLabelID      = "label_id"
+	ColumnStartAt      = "start_at"
+	ColumnDueAt        = "due_at"
+	ColumnRemindedAt   = "reminded_at"
+	ColumnText         = "text"
+	ColumnDone         = "done"
+	ColumnTaskOrder    = "task_order"
+	ColumnActorID      = "actor_id"
+	ColumnAction       = "action"
+	ColumnField        = "field"
+	ColumnOldValue     = "old_value"
+	ColumnNewValue     = "new_value"
+	ColumnFilename     = "filename"
+	ColumnSize         = "size"
+	ColumnContentType  = "content_type"
+	ColumnUploaderID   = "uploader_id"
+	ColumnBlockerID    = "blocker_id"
+	ColumnBlockedID    = "blocked_id"
+	ColumnWIPLimit     = "wip_limit"
+	ColumnSearchVector = "search_vector"
+	ColumnPermissions  = "permissions"
+	ColumnInviterID    = "inviter_id"
+	ColumnTokenHash    = "token_hash"
+	ColumnExpiresAt    = "expires_at"
+	ColumnMaxUses      = "max_uses"
+	ColumnUses         = "uses"
+	ColumnRevokedAt    = "revoked_at"
+	ColumnRevokedBy    = "revoked_by"
+	ColumnDeclinedAt   = "declined_at"
+	ColumnInvitationID = "invitation_id"
+	ColumnAccepted     = "accepted"
+	ColumnArchivedAt   = "archived_at"
+	ColumnIsTemplate   = "is_template"
+	ColumnDeletedAt    = "deleted_at"
+	ColumnURL          = "url"
+	ColumnEvents       = "events"
+	ColumnSecret       = "secret"
+	ColumnFailureCount = "failure_count"
+	ColumnDisabledAt   = "disabled_at"
+	ColumnWebhookID    = "webhook_id"
+	ColumnEventID      = "event_id"
+	ColumnEventType    = "event_type"
+	ColumnAttempt      = "attempt"
+	ColumnStatusCode   = "status_code"
+	ColumnError        = "error"
+	ColumnSucceeded    = "succeeded"
+	ColumnDuration     = "duration"
 )
 
 const (
-	TableUsers         = "users"
-	TableBoards        = "boards"
-	TableBoardMembers  = "board_members"
-	TableRefreshTokens = "refresh_tokens"
-	TableTasks         = "tasks"
+	TableUsers               = "users"
+	TableBoards              = "boards"
+	TableBoardMembers        = "board_members"
+	TableRefreshTokens       = "refresh_tokens"
+	TableTasks               = "tasks"
+	TableBoardColumns        = "board_columns"
+	TableComments            = "comments"
+	TableLabels              = "labels"
+	TableTaskLabels          = "task_labels"
+	TableChecklistItems      = "checklist_items"
+	TableTaskHistory         = "task_history"
+	TableAttachments         = "attachments"
+	TableTaskDependencies    = "task_dependencies"
+	TableBoardRoles          = "board_roles"
+	TableInvitations         = "invitations"
+	TableInvitationResponses = "invitation_responses"
+	TableWebhooks            = "webhooks"
+	TableWebhookDeliveries   = "webhook_deliveries"
 )
 
 // Tables defines structure of generating migration script files
@@ -164,5 +237,688 @@ var Tables = []sqlddl.SchemaTable{
 				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
 			},
 		},
+	}, {
+		Name: TableBoardColumns,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnName,
+				Type:        sqlddl.TypeVarchar(100),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnStatus,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnOrder,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+	},
+	{
+		Name:  TableTasks,
+		Alter: true,
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnColumnID,
+				ReferenceTable:  TableBoardColumns,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteRestrict,
+			},
+		},
+		Statements: []string{
+			// boards created before columns existed get default ones
+			fmt.Sprintf(
+				"INSERT INTO %s (%s, %s, %s, %s, %s) "+
+					"SELECT gen_random_uuid()::TEXT, %[7]s.%[8]s, defaults.%[4]s, defaults.%[5]s, defaults.%[6]s "+
+					"FROM %[7]s CROSS JOIN (VALUES %[9]s) AS defaults(%[4]s, %[5]s, %[6]s) "+
+					"WHERE NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.%[3]s = %[7]s.%[8]s)",
+				TableBoardColumns,
+				sqlddl.ColumnID,
+				ColumnBoardID,
+				ColumnName,
+				ColumnStatus,
+				ColumnOrder,
+				TableBoards,
+				sqlddl.ColumnID,
+				defaultBoardColumnsValues(),
+			),
+			// existing tasks are placed into column which matches their status
+			fmt.Sprintf(
+				"UPDATE %s SET %s = %s.%s FROM %[3]s "+
+					"WHERE %[1]s.%[2]s IS NULL AND %[3]s.%[5]s = %[1]s.%[5]s AND %[3]s.%[6]s = %[1]s.%[6]s",
+				TableTasks,
+				ColumnColumnID,
+				TableBoardColumns,
+				sqlddl.ColumnID,
+				ColumnBoardID,
+				ColumnStatus,
+			),
+		},
+	},
+	{
+		Name:  TableTasks,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnPosition,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+		},
+		Statements: []string{
+			// existing tasks keep their creation order inside columns
+			fmt.Sprintf(
+				"UPDATE %s SET %s = ranked.%[2]s FROM "+
+					"(SELECT %s, ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s) AS %[2]s FROM %[1]s) AS ranked "+
+					"WHERE %[1]s.%[3]s = ranked.%[3]s",
+				TableTasks,
+				ColumnPosition,
+				sqlddl.ColumnID,
+				ColumnColumnID,
+				ColumnOrder,
+			),
+		},
+	},
+	{
+		Name: TableComments,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnBody,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnTaskID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnAuthorID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnParentID,
+				ReferenceTable:  TableComments,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+	},
+	{
+		Name: TableLabels,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnName,
+				Type:        sqlddl.TypeVarchar(50),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnColor,
+				Type:        sqlddl.TypeVarchar(7),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE UNIQUE INDEX %[1]s_%[2]s_%[3]s_key ON %[1]s (%[2]s, %[3]s)",
+				TableLabels,
+				ColumnBoardID,
+				ColumnName,
+			),
+		},
+	},
+	{
+		// labels are detached from tasks by cascade when label or task is deleted
+		Name: TableTaskLabels,
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnTaskID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnLabelID,
+				ReferenceTable:  TableLabels,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE UNIQUE INDEX %[1]s_%[2]s_%[3]s_key ON %[1]s (%[2]s, %[3]s)",
+				TableTaskLabels,
+				ColumnTaskID,
+				ColumnLabelID,
+			),
+		},
+	},
+	{
+		Name:  TableTasks,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name: ColumnStartAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+			{
+				Name: ColumnDueAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+			{
+				Name: ColumnRemindedAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+		},
+	},
+	{
+		Name: TableChecklistItems,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnText,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnDone,
+				Type:        sqlddl.TypeBoolean,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("FALSE")},
+			},
+			{
+				Name:        ColumnOrder,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnTaskID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnAssigneeID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+		},
+	},
+	{
+		Name: TableTaskHistory,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnTaskOrder,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnAction,
+				Type:        sqlddl.TypeVarchar(30),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnField,
+				Type:        sqlddl.TypeVarchar(50),
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("''")},
+			},
+			{
+				Name: ColumnOldValue,
+				Type: sqlddl.TypeText,
+			},
+			{
+				Name: ColumnNewValue,
+				Type: sqlddl.TypeText,
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				// history outlives task, so board activity feed keeps deletions
+				ColumnName:      ColumnTaskID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+			{
+				ColumnName:      ColumnActorID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE INDEX %[1]s_%[2]s_%[3]s_idx ON %[1]s (%[2]s, %[3]s)",
+				TableTaskHistory,
+				ColumnBoardID,
+				sqlddl.ColumnCreatedAt,
+			),
+		},
+	},
+	{
+		Name: TableAttachments,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnFilename,
+				Type:        sqlddl.TypeVarchar(255),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnSize,
+				Type:        sqlddl.TypeBigInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnContentType,
+				Type:        sqlddl.TypeVarchar(255),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnTaskID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnUploaderID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+		},
+	},
+	{
+		Name: TableTaskDependencies,
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBlockerID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnBlockedID,
+				ReferenceTable:  TableTasks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE UNIQUE INDEX %[1]s_%[2]s_%[3]s_key ON %[1]s (%[2]s, %[3]s)",
+				TableTaskDependencies,
+				ColumnBlockerID,
+				ColumnBlockedID,
+			),
+			fmt.Sprintf(
+				"CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)",
+				TableTaskDependencies,
+				ColumnBlockedID,
+			),
+		},
+	},
+	{
+		Name:  TableBoardColumns,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnWIPLimit,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+		},
+	},
+	{
+		Name:  TableTasks,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnSearchVector,
+				Type:        sqlddl.TypeTSVector,
+				Constraints: []string{sqlddl.ConstraintGeneratedStored(searchVectorExpression)},
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s USING GIN (%[2]s)", TableTasks, ColumnSearchVector),
+		},
+	},
+	{
+		Name:  TableBoards,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnSearchVector,
+				Type:        sqlddl.TypeTSVector,
+				Constraints: []string{sqlddl.ConstraintGeneratedStored(searchVectorExpression)},
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s USING GIN (%[2]s)", TableBoards, ColumnSearchVector),
+		},
+	},
+	{
+		Name: TableBoardRoles,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnName,
+				Type:        sqlddl.TypeVarchar(100),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnPermissions,
+				Type:        sqlddl.TypeTextArray,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE UNIQUE INDEX %[1]s_%[2]s_%[3]s_key ON %[1]s (%[2]s, %[3]s)",
+				TableBoardRoles,
+				ColumnBoardID,
+				ColumnName,
+			),
+		},
+	},
+	{
+		Name: TableInvitations,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnEmail,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("''")},
+			},
+			{
+				Name: ColumnTokenHash,
+				Type: sqlddl.TypeText,
+			},
+			{
+				Name:        ColumnRole,
+				Type:        sqlddl.TypeVarchar(100),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnExpiresAt,
+				Type:        sqlddl.TypeTimestampTZ,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnMaxUses,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+			{
+				Name:        ColumnUses,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+			{
+				Name: ColumnRevokedAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+			{
+				Name: ColumnDeclinedAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnInviterID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+			{
+				ColumnName:      ColumnRevokedBy,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf("CREATE UNIQUE INDEX %[1]s_%[2]s_key ON %[1]s (%[2]s)", TableInvitations, ColumnTokenHash),
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)", TableInvitations, ColumnBoardID),
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)", TableInvitations, ColumnEmail),
+		},
 	},
+	{
+		Name: TableInvitationResponses,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnAccepted,
+				Type:        sqlddl.TypeBoolean,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnInvitationID,
+				ReferenceTable:  TableInvitations,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnUserID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)",
+				TableInvitationResponses,
+				ColumnInvitationID,
+			),
+		},
+	},
+	{
+		Name:    TableBoards,
+		Alter:   true,
+		Columns: trashColumns,
+		Statements: []string{
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)", TableBoards, ColumnDeletedAt),
+		},
+	},
+	{
+		Name:    TableTasks,
+		Alter:   true,
+		Columns: trashColumns,
+		Statements: []string{
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)", TableTasks, ColumnDeletedAt),
+		},
+	},
+	{
+		Name:  TableBoards,
+		Alter: true,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnIsTemplate,
+				Type:        sqlddl.TypeBoolean,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("FALSE")},
+			},
+		},
+	},
+	{
+		Name: TableWebhooks,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnURL,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnEvents,
+				Type:        sqlddl.TypeTextArray,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("'{}'")},
+			},
+			{
+				Name:        ColumnSecret,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnFailureCount,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+			{
+				Name: ColumnDisabledAt,
+				Type: sqlddl.TypeTimestampTZ,
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnBoardID,
+				ReferenceTable:  TableBoards,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+			{
+				ColumnName:      ColumnCreatorID,
+				ReferenceTable:  TableUsers,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteSetNull,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf("CREATE INDEX %[1]s_%[2]s_idx ON %[1]s (%[2]s)", TableWebhooks, ColumnBoardID),
+		},
+	},
+	{
+		Name: TableWebhookDeliveries,
+		Columns: []sqlddl.SchemaColumn{
+			{
+				Name:        ColumnEventID,
+				Type:        sqlddl.TypeBigInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnEventType,
+				Type:        sqlddl.TypeVarchar(50),
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnAttempt,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnStatusCode,
+				Type:        sqlddl.TypeInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+			{
+				Name:        ColumnError,
+				Type:        sqlddl.TypeText,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("''")},
+			},
+			{
+				Name:        ColumnSucceeded,
+				Type:        sqlddl.TypeBoolean,
+				Constraints: []string{sqlddl.ConstraintNotNull},
+			},
+			{
+				Name:        ColumnDuration,
+				Type:        sqlddl.TypeBigInt,
+				Constraints: []string{sqlddl.ConstraintNotNull, sqlddl.ConstraintDefault("0")},
+			},
+		},
+		ForeignKeys: []sqlddl.SchemaForeignKey{
+			{
+				ColumnName:      ColumnWebhookID,
+				ReferenceTable:  TableWebhooks,
+				ReferenceColumn: sqlddl.ColumnID,
+				OnDelete:        sqlddl.ConstraintOnDeleteCascade,
+			},
+		},
+		Statements: []string{
+			fmt.Sprintf(
+				"CREATE INDEX %[1]s_%[2]s_%[3]s_idx ON %[1]s (%[2]s, %[3]s)",
+				TableWebhookDeliveries,
+				ColumnWebhookID,
+				sqlddl.ColumnCreatedAt,
+			),
+		},
+	},
+}
+
+// trashColumns are columns of records which may be archived and moved to trash, both are NULL for active record
+var trashColumns = []sqlddl.SchemaColumn{
+	{
+		Name: ColumnArchivedAt,
+		Type: sqlddl.TypeTimestampTZ,
+	},
+	{
+		Name: ColumnDeletedAt,
+		Type: sqlddl.TypeTimestampTZ,
+	},
+}
+
+// SearchConfig is text search configuration used for both indexing and querying,
+// it doesn't stem words so any language of boards is matched the same way
+const SearchConfig = "simple"
+
+// searchVectorExpression builds text search document of record, name matches are ranked above description ones
+var searchVectorExpression = fmt.Sprintf(
+	"setweight(to_tsvector('%[1]s'::regconfig, COALESCE(%[2]s, '')), 'A') || "+
+		"setweight(to_tsvector('%[1]s'::regconfig, COALESCE(%[3]s, '')), 'B')",
+	SearchConfig,
+	ColumnName,
+	ColumnDescription,
+)
+
+// defaultBoardColumnsValues formats models.DefaultBoardColumns as rows of sql VALUES expression
+func defaultBoardColumnsValues() string {
+	rows := make([]string, 0, len(models.DefaultBoardColumns))
+	for _, column := range models.DefaultBoardColumns {
+		rows = append(rows, fmt.Sprintf("('%s', %d, %d)", column.Name, column.Status, column.Order))
+	}
+	return strings.Join(rows, ", ")
 }
@@ -0,0 +1,76 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const dirPermissions = 0o750
+
+var invalidKeyErr = errors.New("blob key must be plain file name")
+
+// BlobStore keeps blobs as files of local directory
+type BlobStore struct {
+	Dir string
+}
+
+// NewBlobStore creates directory for blobs if it doesn't exist
+func NewBlobStore(dir string) (*BlobStore, error) {
+	if mkdirErr := os.MkdirAll(dir, dirPermissions); mkdirErr != nil {
+		return nil, mkdirErr
+	}
+	return &BlobStore{dir}, nil
+}
+
+// Put writes content to temporary file first, so partially written blob is never visible under key
+func (bs *BlobStore) Put(_ context.Context, key string, content io.Reader) error {
+	path, pathErr := bs.path(key)
+	if pathErr != nil {
+		return pathErr
+	}
+	tmpFile, createErr := os.CreateTemp(bs.Dir, key+".*.tmp")
+	if createErr != nil {
+		return createErr
+	}
+	defer os.Remove(tmpFile.Name())
+	if _, copyErr := io.Copy(tmpFile, content); copyErr != nil {
+		tmpFile.Close()
+		return copyErr
+	}
+	if closeErr := tmpFile.Close(); closeErr != nil {
+		return closeErr
+	}
+	return os.Rename(tmpFile.Name(), path)
+}
+
+func (bs *BlobStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, pathErr := bs.path(key)
+	if pathErr != nil {
+		return nil, pathErr
+	}
+	return os.Open(path)
+}
+
+func (bs *BlobStore) Delete(_ context.Context, key string) error {
+	path, pathErr := bs.path(key)
+	if pathErr != nil {
+		return pathErr
+	}
+	removeErr := os.Remove(path)
+	if errors.Is(removeErr, fs.ErrNotExist) {
+		return nil
+	}
+	return removeErr
+}
+
+// path returns location of blob file, keys containing path elements are rejected
+func (bs *BlobStore) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key == "." || key == ".." {
+		return "", invalidKeyErr
+	}
+	return filepath.Join(bs.Dir, key), nil
+}
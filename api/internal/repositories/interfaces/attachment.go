@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// AttachmentRepository is an abstract storage of tasks attachments metadata
+type AttachmentRepository interface {
+	// Create adds new attachment record to data storage
+	Create(ctx context.Context, attachment *models.Attachment) error
+	// FindByID searches for attachment with provided id
+	FindByID(ctx context.Context, attachmentId sqlddl.ID) (*models.Attachment, error)
+	// FindAllByTaskID searches for all attachments of task, the oldest first
+	FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.Attachment, error)
+	// FindIDsDeletedBefore searches for identifiers of attachments of tasks moved to trash before deadline
+	// and of all tasks of boards moved to trash before deadline, their records are purged along with them
+	FindIDsDeletedBefore(ctx context.Context, deadline time.Time) ([]sqlddl.ID, error)
+	// Delete removes attachment record from data storage
+	Delete(ctx context.Context, attachmentId sqlddl.ID) error
+}
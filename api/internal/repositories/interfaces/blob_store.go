@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore is an abstract storage of binary files content addressed by keys
+type BlobStore interface {
+	// Put saves all content of reader under key, existing content of key is replaced
+	Put(ctx context.Context, key string, content io.Reader) error
+	// Get opens content saved under key, caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes content saved under key, it's not an error if key doesn't exist
+	Delete(ctx context.Context, key string) error
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"just-kanban/internal/models"
 	"just-kanban/pkg/sqlddl"
@@ -13,10 +14,26 @@ type BoardRepository interface {
 	Create(ctx context.Context, board *models.Board) error
 	// Update changes data of board exists into storage
 	Update(ctx context.Context, id sqlddl.ID, d *models.UpdateBoard) error
-	// FindByID searches board with provided id
+	// FindByID searches board with provided id, boards moved to trash aren't found
 	FindByID(ctx context.Context, id sqlddl.ID) (*models.Board, error)
-	// FindAll searches all existing boards
+	// FindDeletedByID searches board with provided id among boards moved to trash
+	FindDeletedByID(ctx context.Context, id sqlddl.ID) (*models.Board, error)
+	// FindAll searches all existing boards except ones moved to trash
 	FindAll(ctx context.Context) ([]models.Board, error)
-	// Delete removes board data from storage
+	// FindPage searches for limited count of boards positioned after provided key, ordered by creation time
+	// and then by id ascending, first page is returned if after is nil. Only archived boards are searched
+	// if archived is set and only not archived ones otherwise, boards moved to trash and templates aren't searched
+	FindPage(ctx context.Context, archived bool, after *models.PageKey, limit int) ([]models.Board, error)
+	// FindTemplatesByUserID searches for templates which user is member of ordered by name
+	FindTemplatesByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error)
+	// FindDeletedByUserID searches for boards moved to trash which user is member of, the latest deleted first
+	FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error)
+	// SetArchivedAt saves time when board was archived, nil returns board from archive
+	SetArchivedAt(ctx context.Context, id sqlddl.ID, archivedAt *time.Time) error
+	// SetDeletedAt saves time when board was moved to trash, nil restores board from trash
+	SetDeletedAt(ctx context.Context, id sqlddl.ID, deletedAt *time.Time) error
+	// PurgeDeletedBefore permanently removes boards moved to trash before deadline and returns their count
+	PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error)
+	// Delete permanently removes board data from storage
 	Delete(ctx context.Context, id sqlddl.ID) error
 }
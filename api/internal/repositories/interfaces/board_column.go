@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// BoardColumnRepository is an abstract data storage of project boards workflow columns
+type BoardColumnRepository interface {
+	// Create adds new column to data storage
+	Create(ctx context.Context, column *models.BoardColumn) error
+	// Rename changes name of column with provided id
+	Rename(ctx context.Context, id sqlddl.ID, name string) error
+	// SetWIPLimit changes maximum number of tasks of column with provided id, 0 removes limit
+	SetWIPLimit(ctx context.Context, id sqlddl.ID, limit int) error
+	// Reorder sets order of board columns according to position of their identifiers in provided slice
+	Reorder(ctx context.Context, boardId sqlddl.ID, columnIds []sqlddl.ID) error
+	// FindByID searches for column with provided id
+	FindByID(ctx context.Context, id sqlddl.ID) (*models.BoardColumn, error)
+	// FindAllByBoardID searches for all columns of project board sorted by their order
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardColumn, error)
+	// Lock locks all columns of board until end of transaction, serializes concurrent changes of tasks positions
+	Lock(ctx context.Context, boardId sqlddl.ID) error
+	// Delete removes column from data storage
+	Delete(ctx context.Context, id sqlddl.ID) error
+}
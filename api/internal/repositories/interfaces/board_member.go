@@ -20,6 +20,10 @@ type BoardMemberRepository interface {
 	FindBoardUser(ctx context.Context, boardID, userID sqlddl.ID) (*models.BoardMember, error)
 	// FindBoardMembers searches for all member of a boards by provided board identifier
 	FindBoardMembers(ctx context.Context, boardId sqlddl.ID) ([]models.BoardMember, error)
+	// CountByRole counts members of board having provided role
+	CountByRole(ctx context.Context, boardId sqlddl.ID, role access.Role) (int, error)
+	// Lock locks all member records of board till the end of transaction, must be called within transaction
+	Lock(ctx context.Context, boardId sqlddl.ID) error
 	// Delete removes board member from data storage
-	Delete(ctx context.Context, member *models.BoardMember) error
+	Delete(ctx context.Context, memberId sqlddl.ID) error
 }
@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// BoardRoleRepository is an abstract storage of custom roles defined by boards
+type BoardRoleRepository interface {
+	// Create adds new role record to data storage
+	Create(ctx context.Context, role *models.BoardRole) error
+	// UpdatePermissions replaces permissions of role, where id equal provided
+	UpdatePermissions(ctx context.Context, roleId sqlddl.ID, permissions []access.Permission) error
+	// Delete removes role record from data storage
+	Delete(ctx context.Context, roleId sqlddl.ID) error
+	// FindByID searches for role by provided id
+	FindByID(ctx context.Context, roleId sqlddl.ID) (*models.BoardRole, error)
+	// FindByName searches for role by name on project board
+	FindByName(ctx context.Context, boardId sqlddl.ID, name access.Role) (*models.BoardRole, error)
+	// FindAllByBoardID searches for all custom roles of project board ordered by name
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardRole, error)
+}
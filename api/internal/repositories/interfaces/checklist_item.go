@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// ChecklistItemRepository is an abstract storage of tasks checklist items
+type ChecklistItemRepository interface {
+	// Create adds new checklist item to data storage
+	Create(ctx context.Context, item *models.ChecklistItem) error
+	// Update changes checklist item record into data storage, where id equal provided
+	Update(ctx context.Context, itemId sqlddl.ID, d *models.UpdateChecklistItem) error
+	// Reorder sets order of task checklist items according to position of their identifiers in provided slice
+	Reorder(ctx context.Context, taskId sqlddl.ID, itemIds []sqlddl.ID) error
+	// FindByID searches for checklist item with provided id
+	FindByID(ctx context.Context, itemId sqlddl.ID) (*models.ChecklistItem, error)
+	// FindAllByTaskID searches for all checklist items of task sorted by their order
+	FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.ChecklistItem, error)
+	// CountProgressByTaskIDs counts done and all checklist items of provided tasks grouped by task identifier
+	CountProgressByTaskIDs(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID]models.ChecklistProgress, error)
+	// Delete removes checklist item from data storage
+	Delete(ctx context.Context, itemId sqlddl.ID) error
+}
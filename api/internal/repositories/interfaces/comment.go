@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// CommentRepository is an abstract data storage of tasks comments
+type CommentRepository interface {
+	// Create adds new comment to data storage
+	Create(ctx context.Context, comment *models.Comment) error
+	// UpdateBody changes text of comment with provided id
+	UpdateBody(ctx context.Context, id sqlddl.ID, body string) error
+	// FindByID searches for comment with provided id
+	FindByID(ctx context.Context, id sqlddl.ID) (*models.Comment, error)
+	// FindAllByTaskID searches for all comments of task sorted by creation time
+	FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.Comment, error)
+	// Delete removes comment and its replies from data storage
+	Delete(ctx context.Context, id sqlddl.ID) error
+}
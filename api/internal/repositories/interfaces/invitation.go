@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// InvitationRepository is an abstract storage of board invitations and responses to them
+type InvitationRepository interface {
+	// Create adds new invitation record to data storage
+	Create(ctx context.Context, invitation *models.Invitation) error
+	// FindByID searches for invitation by provided id
+	FindByID(ctx context.Context, invitationId sqlddl.ID) (*models.Invitation, error)
+	// FindByTokenHash searches for link invitation by hash of its token
+	FindByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error)
+	// FindAllByBoardID searches for all invitations of project board, newest first
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Invitation, error)
+	// FindPendingByEmail searches for invitations addressed to email which may be still accepted at provided moment
+	FindPendingByEmail(ctx context.Context, email string, now time.Time) ([]models.Invitation, error)
+	// Lock locks invitation record till the end of transaction, must be called within transaction
+	Lock(ctx context.Context, invitationId sqlddl.ID) error
+	// IncrementUses increases count of invitation acceptances by one
+	IncrementUses(ctx context.Context, invitationId sqlddl.ID) error
+	// Revoke marks invitation as revoked by user
+	Revoke(ctx context.Context, invitationId, userId sqlddl.ID, revokedAt time.Time) error
+	// Decline marks email invitation as declined by invitee
+	Decline(ctx context.Context, invitationId sqlddl.ID, declinedAt time.Time) error
+	// CreateResponse adds record of user accepting or declining invitation
+	CreateResponse(ctx context.Context, response *models.InvitationResponse) error
+	// FindResponses searches for responses to invitation ordered by time
+	FindResponses(ctx context.Context, invitationId sqlddl.ID) ([]models.InvitationResponse, error)
+}
@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// LabelRepository is an abstract storage of board labels and their links to tasks
+type LabelRepository interface {
+	// Create adds new label record to data storage
+	Create(ctx context.Context, label *models.Label) error
+	// Update changes label record into data storage, where id equal provided
+	Update(ctx context.Context, labelId sqlddl.ID, d *models.UpdateLabel) error
+	// Delete removes label record from data storage, label is detached from all tasks
+	Delete(ctx context.Context, labelId sqlddl.ID) error
+	// FindByID searches for label by provided id
+	FindByID(ctx context.Context, labelId sqlddl.ID) (*models.Label, error)
+	// FindByName searches for label by name on project board
+	FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Label, error)
+	// FindAllByBoardID searches for all labels of project board ordered by name
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Label, error)
+	// FindAllByTaskIDs searches for labels attached to provided tasks grouped by task identifier
+	FindAllByTaskIDs(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.Label, error)
+	// ReplaceTaskLabels detaches all labels from task and attaches provided ones
+	ReplaceTaskLabels(ctx context.Context, taskId sqlddl.ID, labelIds []sqlddl.ID) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// SearchRepository is an abstract full-text index of tasks and boards
+type SearchRepository interface {
+	// Search searches for tasks and boards of boards where user is a member, most relevant ones go first
+	Search(ctx context.Context, userId sqlddl.ID, query string, limit, offset int) ([]models.SearchResult, error)
+}
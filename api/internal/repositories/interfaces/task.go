@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"just-kanban/internal/models"
 	"just-kanban/pkg/sqlddl"
@@ -13,14 +14,37 @@ type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
 	// Update changes task record into data storage, where id equal provided
 	Update(ctx context.Context, taskId sqlddl.ID, d *models.UpdateTask) error
-	// Delete removes task record from data storage
-	Delete(ctx context.Context, taskId sqlddl.ID) error
-	// FindByID searches for task by provided id
+	// FindByID searches for task by provided id, tasks moved to trash aren't found
 	FindByID(ctx context.Context, taskId sqlddl.ID) (*models.Task, error)
-	// FindByOrder searches for task by order on project board
+	// FindDeletedByID searches for task by provided id among tasks moved to trash
+	FindDeletedByID(ctx context.Context, taskId sqlddl.ID) (*models.Task, error)
+	// FindByOrder searches for task by order on project board, tasks moved to trash aren't found
 	FindByOrder(ctx context.Context, boardId sqlddl.ID, order uint) (*models.Task, error)
-	// FindByName searches for task by name on project board
+	// FindByName searches for task by name on project board, tasks moved to trash aren't found
 	FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Task, error)
-	// FindAllByBoardId searches for all project board's tasks
+	// FindAllByBoardId searches for all project board's tasks including archived and moved to trash ones
 	FindAllByBoardId(ctx context.Context, boardId sqlddl.ID) ([]models.Task, error)
+	// FindPageByBoardID searches for page of project board's tasks matching filter
+	// and counts all matching tasks regardless of page, tasks moved to trash aren't searched
+	FindPageByBoardID(ctx context.Context, boardId sqlddl.ID, filter *models.TaskFilter) ([]models.Task, int, error)
+	// FindDeletedByUserID searches for tasks moved to trash from active boards which user is member of,
+	// the latest deleted first
+	FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Task, error)
+	// CountByColumnID counts tasks placed into board column, archived and moved to trash ones aren't counted
+	CountByColumnID(ctx context.Context, columnId sqlddl.ID) (int, error)
+	// MoveHiddenTasks moves archived and moved to trash tasks of column into another one
+	MoveHiddenTasks(ctx context.Context, fromColumnId sqlddl.ID, to *models.BoardColumn) error
+	// ShiftPositions moves tasks of column which are placed at fromPosition or below by delta positions
+	ShiftPositions(ctx context.Context, columnId sqlddl.ID, fromPosition, delta int) error
+	// FindAllDueBefore searches for not done active tasks of active boards, not templates, which are due before deadline
+	// and weren't reminded about as overdue yet
+	FindAllDueBefore(ctx context.Context, deadline time.Time) ([]models.Task, error)
+	// SetRemindedAt saves time when the last reminder about task has been sent
+	SetRemindedAt(ctx context.Context, taskId sqlddl.ID, remindedAt time.Time) error
+	// SetArchivedAt saves time when task was archived, nil returns task from archive
+	SetArchivedAt(ctx context.Context, taskId sqlddl.ID, archivedAt *time.Time) error
+	// SetDeletedAt saves time when task was moved to trash, nil restores task from trash
+	SetDeletedAt(ctx context.Context, taskId sqlddl.ID, deletedAt *time.Time) error
+	// PurgeDeletedBefore permanently removes tasks moved to trash before deadline and returns their count
+	PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error)
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// TaskDependencyRepository is an abstract storage of links between blocking and blocked tasks
+type TaskDependencyRepository interface {
+	// Create adds new dependency record to data storage
+	Create(ctx context.Context, dependency *models.TaskDependency) error
+	// Delete removes dependency between provided tasks from data storage
+	Delete(ctx context.Context, blockerId, blockedId sqlddl.ID) error
+	// Lock locks all dependencies until end of transaction, serializes concurrent checks of dependencies graph
+	Lock(ctx context.Context) error
+	// FindBlockers searches for tasks blocking provided ones grouped by blocked task identifier
+	FindBlockers(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error)
+	// FindBlocked searches for tasks blocked by provided ones grouped by blocker task identifier
+	FindBlocked(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error)
+}
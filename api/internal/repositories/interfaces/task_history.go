@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// TaskHistoryRepository is an abstract storage of tasks changes history
+type TaskHistoryRepository interface {
+	// Create adds new history entry to data storage
+	Create(ctx context.Context, entry *models.TaskHistoryEntry) error
+	// FindAllByTaskID searches for history entries of task, the newest first
+	FindAllByTaskID(ctx context.Context, taskId sqlddl.ID, limit, offset int) ([]models.TaskHistoryEntry, error)
+	// FindAllByBoardID searches for history entries of all tasks of board including deleted ones, the newest first
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID, limit, offset int) ([]models.TaskHistoryEntry, error)
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "context"
+
+// Transactor runs changes of several repositories as single atomic operation of data storage
+type Transactor interface {
+	// WithinTransaction runs fn in transaction, repositories called with context passed to fn take part in it
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
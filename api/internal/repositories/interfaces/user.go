@@ -21,6 +21,9 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	// FindAll searches for all users
 	FindAll(ctx context.Context) ([]models.User, error)
+	// FindPage searches for limited count of users positioned after provided key, ordered by creation time
+	// and then by id ascending, first page is returned if after is nil. All users are searched, there's no filter
+	FindPage(ctx context.Context, after *models.PageKey, limit int) ([]models.User, error)
 	// Delete removes user record from data storage
 	Delete(ctx context.Context, id sqlddl.ID) error
 }
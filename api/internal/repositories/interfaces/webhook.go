@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// WebhookRepository is an abstract storage of board webhooks and logs of their deliveries
+type WebhookRepository interface {
+	// Create adds new webhook record to data storage
+	Create(ctx context.Context, webhook *models.Webhook) error
+	// Update changes url, events and secret of webhook, failures are reset and webhook is enabled if enable is true
+	Update(ctx context.Context, webhook *models.Webhook, enable bool) error
+	// Delete removes webhook with its deliveries from data storage
+	Delete(ctx context.Context, webhookId sqlddl.ID) error
+	// FindByID searches for webhook by provided id
+	FindByID(ctx context.Context, webhookId sqlddl.ID) (*models.Webhook, error)
+	// FindAllByBoardID searches for all webhooks of project board, oldest first
+	FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Webhook, error)
+	// FindActiveByBoardID searches for webhooks of project board which aren't disabled
+	FindActiveByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Webhook, error)
+	// RecordFailure increases count of consecutive failed deliveries and disables webhook at provided moment
+	// once count reaches limit, it reports whether webhook has been disabled
+	RecordFailure(ctx context.Context, webhookId sqlddl.ID, limit int, disabledAt time.Time) (bool, error)
+	// ResetFailures clears count of consecutive failed deliveries
+	ResetFailures(ctx context.Context, webhookId sqlddl.ID) error
+	// CreateDelivery adds log record of delivery attempt
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// FindDeliveries searches for the latest delivery attempts of webhook, newest first
+	FindDeliveries(ctx context.Context, webhookId sqlddl.ID, limit int) ([]models.WebhookDelivery, error)
+}
@@ -0,0 +1,155 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type AttachmentRepository struct {
+	DB *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db}
+}
+
+func (repo *AttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableAttachments,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnUploaderID,
+		repositories.ColumnFilename,
+		repositories.ColumnSize,
+		repositories.ColumnContentType,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		attachment.ID,
+		attachment.TaskID,
+		attachment.UploaderID,
+		attachment.Filename,
+		attachment.Size,
+		attachment.ContentType,
+	)
+	return execErr
+}
+
+func (repo *AttachmentRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Attachment, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnUploaderID,
+		repositories.ColumnFilename,
+		repositories.ColumnSize,
+		repositories.ColumnContentType,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableAttachments,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	var attachment models.Attachment
+	scanErr := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UploaderID,
+		&attachment.Filename,
+		&attachment.Size,
+		&attachment.ContentType,
+		&attachment.CreatedAt,
+		&attachment.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &attachment, nil
+}
+
+func (repo *AttachmentRepository) FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.Attachment, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[7]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnUploaderID,
+		repositories.ColumnFilename,
+		repositories.ColumnSize,
+		repositories.ColumnContentType,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableAttachments,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, taskId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var attachments []models.Attachment
+	for rows.Next() {
+		var attachment models.Attachment
+		scanErr := rows.Scan(
+			&attachment.ID,
+			&attachment.TaskID,
+			&attachment.UploaderID,
+			&attachment.Filename,
+			&attachment.Size,
+			&attachment.ContentType,
+			&attachment.CreatedAt,
+			&attachment.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// FindIDsDeletedBefore searches for identifiers of attachments of tasks and boards moved to trash before deadline
+func (repo *AttachmentRepository) FindIDsDeletedBefore(ctx context.Context, deadline time.Time) ([]sqlddl.ID, error) {
+	const query = "SELECT a.%[1]s FROM %[2]s a JOIN %[3]s t ON t.%[1]s = a.%[4]s " +
+		"JOIN %[5]s b ON b.%[1]s = t.%[6]s WHERE t.%[7]s < $1 OR b.%[7]s < $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.TableAttachments,
+		repositories.TableTasks,
+		repositories.ColumnTaskID,
+		repositories.TableBoards,
+		repositories.ColumnBoardID,
+		repositories.ColumnDeletedAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, deadline)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var ids []sqlddl.ID
+	for rows.Next() {
+		var id sqlddl.ID
+		if scanErr := rows.Scan(&id); scanErr != nil {
+			return nil, scanErr
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (repo *AttachmentRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableAttachments, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
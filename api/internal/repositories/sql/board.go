@@ -5,30 +5,85 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
 )
 
 type BoardRepository struct {
 	DB *sql.DB
 }
 
+// boardColumns are columns of boards table in order which scanBoard reads them
+var boardColumns = strings.Join([]string{
+	sqlddl.ColumnID,
+	repositories.ColumnName,
+	repositories.ColumnDescription,
+	repositories.ColumnArchivedAt,
+	repositories.ColumnDeletedAt,
+	repositories.ColumnIsTemplate,
+	sqlddl.ColumnCreatedAt,
+	sqlddl.ColumnUpdatedAt,
+}, ", ")
+
+// scanBoard reads board selected with boardColumns
+func scanBoard(row rowScanner) (*models.Board, error) {
+	var board models.Board
+	scanErr := row.Scan(
+		&board.ID,
+		&board.Name,
+		&board.Description,
+		&board.ArchivedAt,
+		&board.DeletedAt,
+		&board.IsTemplate,
+		&board.CreatedAt,
+		&board.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &board, nil
+}
+
+// scanBoards reads all boards selected with boardColumns and closes rows
+func scanBoards(rows *sql.Rows) ([]models.Board, error) {
+	defer rows.Close()
+	var boards []models.Board
+	for rows.Next() {
+		board, scanErr := scanBoard(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		boards = append(boards, *board)
+	}
+	return boards, rows.Err()
+}
+
 func NewBoardRepository(db *sql.DB) *BoardRepository {
 	return &BoardRepository{db}
 }
 
 func (repo *BoardRepository) Create(ctx context.Context, board *models.Board) error {
-	const query = "INSERT INTO %s (%s, %s, %s) VALUES ($1, $2, $3)"
+	const query = "INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4)"
 	formattedQuery := fmt.Sprintf(
 		query,
 		repositories.TableBoards,
 		sqlddl.ColumnID,
 		repositories.ColumnName,
 		repositories.ColumnDescription,
+		repositories.ColumnIsTemplate,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		board.ID,
+		board.Name,
+		board.Description,
+		board.IsTemplate,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, board.ID, board.Name, board.Description)
 	return execErr
 }
 
@@ -47,107 +102,162 @@ func (repo *BoardRepository) Update(ctx context.Context, id sqlddl.ID, d *models
 		strings.Join(clauses, ", "),
 		sqlddl.ColumnID,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, d.Name, d.Description, id)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, d.Name, d.Description, id)
 	return execErr
 }
 
 func (repo *BoardRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Board, error) {
-	const query = "SELECT %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s IS NULL"
 	formattedQuery := fmt.Sprintf(
 		query,
-		sqlddl.ColumnID,
-		repositories.ColumnName,
-		repositories.ColumnDescription,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
+		boardColumns,
 		repositories.TableBoards,
+		sqlddl.ColumnID,
+		repositories.ColumnDeletedAt,
 	)
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, id)
-	var board models.Board
-	scanErr := row.Scan(
-		&board.ID,
-		&board.Name,
-		&board.Description,
-		&board.CreatedAt,
-		&board.UpdatedAt,
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanBoard(row)
+}
+
+func (repo *BoardRepository) FindDeletedByID(ctx context.Context, id sqlddl.ID) (*models.Board, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s IS NOT NULL"
+	formattedQuery := fmt.Sprintf(
+		query,
+		boardColumns,
+		repositories.TableBoards,
+		sqlddl.ColumnID,
+		repositories.ColumnDeletedAt,
 	)
-	if scanErr != nil {
-		return nil, scanErr
-	}
-	return &board, nil
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanBoard(row)
 }
 
 func (repo *BoardRepository) FindAll(ctx context.Context) ([]models.Board, error) {
-	const query = "SELECT %s, %s, %s, %s, %s FROM %s"
+	const query = "SELECT %s FROM %s WHERE %s IS NULL"
+	formattedQuery := fmt.Sprintf(query, boardColumns, repositories.TableBoards, repositories.ColumnDeletedAt)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanBoards(rows)
+}
+
+func (repo *BoardRepository) FindPage(
+	ctx context.Context,
+	archived bool,
+	after *models.PageKey,
+	limit int,
+) ([]models.Board, error) {
+	const query = "SELECT %s FROM %s WHERE %s IS NULL AND %s IS %s AND NOT %s AND %s ORDER BY %s, %s LIMIT $1"
+	archivedCondition := "NULL"
+	if archived {
+		archivedCondition = "NOT NULL"
+	}
 	formattedQuery := fmt.Sprintf(
 		query,
-		sqlddl.ColumnID,
-		repositories.ColumnName,
-		repositories.ColumnDescription,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
+		boardColumns,
 		repositories.TableBoards,
+		repositories.ColumnDeletedAt,
+		repositories.ColumnArchivedAt,
+		archivedCondition,
+		repositories.ColumnIsTemplate,
+		keysetCondition(after, 2),
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnID,
 	)
-	rows, rowsErr := repo.DB.QueryContext(ctx, formattedQuery)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, keysetArgs(after, limit)...)
 	if rowsErr != nil {
 		return nil, rowsErr
 	}
-	defer rows.Close()
-	var boards []models.Board
-	for rows.Next() {
-		var board models.Board
-		scanErr := rows.Scan(
-			&board.ID,
-			&board.Name,
-			&board.Description,
-			&board.CreatedAt,
-			&board.UpdatedAt,
-		)
-		if scanErr != nil {
-			return nil, scanErr
-		}
-		boards = append(boards, board)
+	boards, scanErr := scanBoards(rows)
+	if boards == nil {
+		boards = make([]models.Board, 0)
 	}
-	return boards, nil
+	return boards, scanErr
 }
 
 func (repo *BoardRepository) FindAllByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
-	const query = "SELECT %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	const query = "SELECT %s FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, boardColumns, repositories.TableBoards, sqlddl.ColumnID)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, userId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanBoards(rows)
+}
+
+func (repo *BoardRepository) FindTemplatesByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
+	const query = "SELECT %s FROM %s WHERE %s AND %s IS NULL AND %s IN (SELECT %s FROM %s WHERE %s = $1) ORDER BY %s"
 	formattedQuery := fmt.Sprintf(
 		query,
+		boardColumns,
+		repositories.TableBoards,
+		repositories.ColumnIsTemplate,
+		repositories.ColumnDeletedAt,
 		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.TableBoardMembers,
+		repositories.ColumnUserID,
 		repositories.ColumnName,
-		repositories.ColumnDescription,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, userId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	templates, scanErr := scanBoards(rows)
+	if templates == nil {
+		templates = make([]models.Board, 0)
+	}
+	return templates, scanErr
+}
+
+func (repo *BoardRepository) FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
+	const query = "SELECT %s FROM %s WHERE %s IS NOT NULL AND %s IN (SELECT %s FROM %s WHERE %s = $1) ORDER BY %[3]s DESC"
+	formattedQuery := fmt.Sprintf(
+		query,
+		boardColumns,
 		repositories.TableBoards,
+		repositories.ColumnDeletedAt,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.TableBoardMembers,
+		repositories.ColumnUserID,
 	)
-	rows, rowsErr := repo.DB.QueryContext(ctx, formattedQuery, userId)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, userId)
 	if rowsErr != nil {
 		return nil, rowsErr
 	}
-	defer rows.Close()
-	var boards []models.Board
-	for rows.Next() {
-		var board models.Board
-		scanErr := rows.Scan(
-			&board.ID,
-			&board.Name,
-			&board.Description,
-			&board.CreatedAt,
-			&board.UpdatedAt,
-		)
-		if scanErr != nil {
-			return nil, scanErr
-		}
-		boards = append(boards, board)
+	return scanBoards(rows)
+}
+
+func (repo *BoardRepository) SetArchivedAt(ctx context.Context, id sqlddl.ID, archivedAt *time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(query, repositories.TableBoards, repositories.ColumnArchivedAt, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, archivedAt, id)
+	return execErr
+}
+
+func (repo *BoardRepository) SetDeletedAt(ctx context.Context, id sqlddl.ID, deletedAt *time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(query, repositories.TableBoards, repositories.ColumnDeletedAt, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, deletedAt, id)
+	return execErr
+}
+
+func (repo *BoardRepository) PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error) {
+	const query = "DELETE FROM %s WHERE %s < $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableBoards, repositories.ColumnDeletedAt)
+	result, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, deadline)
+	if execErr != nil {
+		return 0, execErr
 	}
-	return boards, nil
+	return result.RowsAffected()
 }
 
+// Delete removes board permanently, records of board are removed by cascade
 func (repo *BoardRepository) Delete(ctx context.Context, id sqlddl.ID) error {
 	const query = "DELETE FROM %s WHERE %s = $1"
 	formattedQuery := fmt.Sprintf(query, repositories.TableBoards, sqlddl.ColumnID)
-	_, err := repo.DB.ExecContext(ctx, formattedQuery, id)
+	_, err := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
 	return err
 }
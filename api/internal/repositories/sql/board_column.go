@@ -0,0 +1,186 @@
+package sql
+
+import (
+	"github.com/lib/pq"
+
+	"context"
+	"database/sql"
+	"fmt"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type BoardColumnRepository struct {
+	DB *sql.DB
+}
+
+func NewBoardColumnRepository(db *sql.DB) *BoardColumnRepository {
+	return &BoardColumnRepository{db}
+}
+
+func (repo *BoardColumnRepository) Create(ctx context.Context, column *models.BoardColumn) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardColumns,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnOrder,
+		repositories.ColumnStatus,
+		repositories.ColumnWIPLimit,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		column.ID,
+		column.BoardID,
+		column.Name,
+		column.Order,
+		column.Status,
+		column.WIPLimit,
+	)
+	return execErr
+}
+
+func (repo *BoardColumnRepository) Rename(ctx context.Context, id sqlddl.ID, name string) error {
+	const query = "UPDATE %s SET %s = $1, %s = CURRENT_TIMESTAMP WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardColumns,
+		repositories.ColumnName,
+		sqlddl.ColumnUpdatedAt,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, name, id)
+	return execErr
+}
+
+func (repo *BoardColumnRepository) SetWIPLimit(ctx context.Context, id sqlddl.ID, limit int) error {
+	const query = "UPDATE %s SET %s = $1, %s = CURRENT_TIMESTAMP WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardColumns,
+		repositories.ColumnWIPLimit,
+		sqlddl.ColumnUpdatedAt,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, limit, id)
+	return execErr
+}
+
+// Reorder updates all columns of board in single statement, so order is never left partially applied
+func (repo *BoardColumnRepository) Reorder(ctx context.Context, boardId sqlddl.ID, columnIds []sqlddl.ID) error {
+	const query = "UPDATE %s SET %s = array_position($2::TEXT[], %s), %s = CURRENT_TIMESTAMP WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardColumns,
+		repositories.ColumnOrder,
+		sqlddl.ColumnID,
+		sqlddl.ColumnUpdatedAt,
+		repositories.ColumnBoardID,
+	)
+	ids := make([]string, 0, len(columnIds))
+	for _, id := range columnIds {
+		ids = append(ids, string(id))
+	}
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, boardId, pq.Array(ids))
+	return execErr
+}
+
+func (repo *BoardColumnRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.BoardColumn, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnOrder,
+		repositories.ColumnStatus,
+		repositories.ColumnWIPLimit,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableBoardColumns,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	var column models.BoardColumn
+	scanErr := row.Scan(
+		&column.ID,
+		&column.BoardID,
+		&column.Name,
+		&column.Order,
+		&column.Status,
+		&column.WIPLimit,
+		&column.CreatedAt,
+		&column.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &column, nil
+}
+
+func (repo *BoardColumnRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardColumn, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[4]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnOrder,
+		repositories.ColumnStatus,
+		repositories.ColumnWIPLimit,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableBoardColumns,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var columns []models.BoardColumn
+	for rows.Next() {
+		var column models.BoardColumn
+		scanErr := rows.Scan(
+			&column.ID,
+			&column.BoardID,
+			&column.Name,
+			&column.Order,
+			&column.Status,
+			&column.WIPLimit,
+			&column.CreatedAt,
+			&column.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func (repo *BoardColumnRepository) Lock(ctx context.Context, boardId sqlddl.ID) error {
+	const query = "SELECT %s FROM %s WHERE %s = $1 ORDER BY %[1]s FOR UPDATE"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.TableBoardColumns,
+		repositories.ColumnBoardID,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return rowsErr
+	}
+	return rows.Close()
+}
+
+func (repo *BoardColumnRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableBoardColumns, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
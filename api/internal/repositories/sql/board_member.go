@@ -9,6 +9,7 @@ import (
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
 )
 
 type BoardMemberRepository struct {
@@ -29,7 +30,7 @@ func (repo *BoardMemberRepository) Create(ctx context.Context, member *models.Bo
 		repositories.ColumnBoardID,
 		repositories.ColumnRole,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, member.ID, member.UserID, member.BoardID, member.Role)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, member.ID, member.UserID, member.BoardID, member.Role)
 	return execErr
 }
 
@@ -41,7 +42,7 @@ func (repo *BoardMemberRepository) ChangeMemberRole(ctx context.Context, id sqld
 		repositories.ColumnRole,
 		sqlddl.ColumnID,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, role, id)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, role, id)
 	return execErr
 }
 
@@ -58,7 +59,7 @@ func (repo *BoardMemberRepository) FindByID(ctx context.Context, id sqlddl.ID) (
 		repositories.TableBoardMembers,
 	)
 	var member models.BoardMember
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, id)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
 	scanErr := row.Scan(
 		&member.ID,
 		&member.UserID,
@@ -83,7 +84,7 @@ func (repo *BoardMemberRepository) FindBoardUser(ctx context.Context, boardID, u
 		repositories.TableBoardMembers,
 	)
 	var member models.BoardMember
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, userID, boardID)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, userID, boardID)
 	scanErr := row.Scan(
 		&member.ID,
 		&member.UserID,
@@ -108,7 +109,7 @@ func (repo *BoardMemberRepository) FindBoardMembers(ctx context.Context, boardId
 		repositories.TableBoardMembers,
 	)
 	var members []models.BoardMember
-	rows, err := repo.DB.QueryContext(ctx, formattedQuery, boardId)
+	rows, err := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +132,37 @@ func (repo *BoardMemberRepository) FindBoardMembers(ctx context.Context, boardId
 	return members, nil
 }
 
-func (repo *BoardMemberRepository) Delete(ctx context.Context, member *models.BoardMember) error {
+func (repo *BoardMemberRepository) CountByRole(ctx context.Context, boardId sqlddl.ID, role access.Role) (int, error) {
+	const query = "SELECT COUNT(*) FROM %s WHERE %s = $1 AND %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardMembers,
+		repositories.ColumnBoardID,
+		repositories.ColumnRole,
+	)
+	var count int
+	scanErr := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, boardId, role).Scan(&count)
+	return count, scanErr
+}
+
+func (repo *BoardMemberRepository) Lock(ctx context.Context, boardId sqlddl.ID) error {
+	const query = "SELECT %s FROM %s WHERE %s = $1 ORDER BY %[1]s FOR UPDATE"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.TableBoardMembers,
+		repositories.ColumnBoardID,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return rowsErr
+	}
+	return rows.Close()
+}
+
+func (repo *BoardMemberRepository) Delete(ctx context.Context, id sqlddl.ID) error {
 	const query = "DELETE FROM %s WHERE %s = $1"
 	formattedString := fmt.Sprintf(query, repositories.TableBoardMembers, sqlddl.ColumnID)
-	_, execErr := repo.DB.ExecContext(ctx, formattedString, member.UserID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedString, id)
 	return execErr
 }
@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type BoardRoleRepository struct {
+	DB *sql.DB
+}
+
+func NewBoardRoleRepository(db *sql.DB) *BoardRoleRepository {
+	return &BoardRoleRepository{db}
+}
+
+func (repo *BoardRoleRepository) Create(ctx context.Context, role *models.BoardRole) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardRoles,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnPermissions,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		role.ID,
+		role.BoardID,
+		role.Name,
+		permissionsArray(role.Permissions),
+	)
+	return execErr
+}
+
+func (repo *BoardRoleRepository) UpdatePermissions(
+	ctx context.Context,
+	id sqlddl.ID,
+	permissions []access.Permission,
+) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableBoardRoles,
+		repositories.ColumnPermissions,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, permissionsArray(permissions), id)
+	return execErr
+}
+
+func (repo *BoardRoleRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableBoardRoles, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
+
+func (repo *BoardRoleRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.BoardRole, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnPermissions,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableBoardRoles,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanBoardRole(row)
+}
+
+func (repo *BoardRoleRepository) FindByName(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	name access.Role,
+) (*models.BoardRole, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 AND %[3]s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnPermissions,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableBoardRoles,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, boardId, name)
+	return scanBoardRole(row)
+}
+
+func (repo *BoardRoleRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardRole, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[3]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnPermissions,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableBoardRoles,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var roles []models.BoardRole
+	for rows.Next() {
+		role, scanErr := scanBoardRole(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		roles = append(roles, *role)
+	}
+	return roles, rows.Err()
+}
+
+// scanBoardRole reads role from row selected with id, board id, name, permissions and timestamps columns
+func scanBoardRole(row rowScanner) (*models.BoardRole, error) {
+	var role models.BoardRole
+	var permissions pq.StringArray
+	scanErr := row.Scan(
+		&role.ID,
+		&role.BoardID,
+		&role.Name,
+		&permissions,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	role.Permissions = make([]access.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, access.Permission(permission))
+	}
+	return &role, nil
+}
+
+// permissionsArray converts permissions to value of sql TEXT[] column
+func permissionsArray(permissions []access.Permission) pq.StringArray {
+	array := make(pq.StringArray, 0, len(permissions))
+	for _, permission := range permissions {
+		array = append(array, string(permission))
+	}
+	return array
+}
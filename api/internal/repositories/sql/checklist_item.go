@@ -0,0 +1,209 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type ChecklistItemRepository struct {
+	DB *sql.DB
+}
+
+func NewChecklistItemRepository(db *sql.DB) *ChecklistItemRepository {
+	return &ChecklistItemRepository{db}
+}
+
+func (repo *ChecklistItemRepository) Create(ctx context.Context, item *models.ChecklistItem) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableChecklistItems,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnText,
+		repositories.ColumnDone,
+		repositories.ColumnAssigneeID,
+		repositories.ColumnOrder,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		item.ID,
+		item.TaskID,
+		item.Text,
+		item.Done,
+		item.AssigneeID,
+		item.Order,
+	)
+	return execErr
+}
+
+func (repo *ChecklistItemRepository) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateChecklistItem) error {
+	var assigneeId interface{} = d.AssigneeID
+	if d.AssigneeID != nil && *d.AssigneeID == "" {
+		assigneeId = sql.NullString{}
+	}
+	execErr := sqlquery.DynamicUpdate(ctx, sqlquery.Conn(ctx, repo.DB), &sqlquery.DynamicUpdateParams{
+		TableName:   repositories.TableChecklistItems,
+		WhereColumn: sqlddl.ColumnID,
+		WhereValue:  id,
+		Changes: map[string]interface{}{
+			repositories.ColumnText:       d.Text,
+			repositories.ColumnDone:       d.Done,
+			repositories.ColumnAssigneeID: assigneeId,
+		},
+		IsNilValue: func(value interface{}) bool {
+			switch v := value.(type) {
+			case *string:
+				return v == nil
+			case *bool:
+				return v == nil
+			case *sqlddl.ID:
+				return v == nil
+			case sql.NullString:
+				return false
+			default:
+				return true
+			}
+		},
+	})
+	return execErr
+}
+
+// Reorder updates all items of task checklist in single statement, so order is never left partially applied
+func (repo *ChecklistItemRepository) Reorder(ctx context.Context, taskId sqlddl.ID, itemIds []sqlddl.ID) error {
+	const query = "UPDATE %s SET %s = array_position($2::TEXT[], %s), %s = CURRENT_TIMESTAMP WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableChecklistItems,
+		repositories.ColumnOrder,
+		sqlddl.ColumnID,
+		sqlddl.ColumnUpdatedAt,
+		repositories.ColumnTaskID,
+	)
+	ids := make([]string, 0, len(itemIds))
+	for _, id := range itemIds {
+		ids = append(ids, string(id))
+	}
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, taskId, pq.Array(ids))
+	return execErr
+}
+
+func (repo *ChecklistItemRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.ChecklistItem, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnText,
+		repositories.ColumnDone,
+		repositories.ColumnAssigneeID,
+		repositories.ColumnOrder,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableChecklistItems,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	var item models.ChecklistItem
+	scanErr := row.Scan(
+		&item.ID,
+		&item.TaskID,
+		&item.Text,
+		&item.Done,
+		&item.AssigneeID,
+		&item.Order,
+		&item.CreatedAt,
+		&item.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &item, nil
+}
+
+func (repo *ChecklistItemRepository) FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.ChecklistItem, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[6]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnText,
+		repositories.ColumnDone,
+		repositories.ColumnAssigneeID,
+		repositories.ColumnOrder,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableChecklistItems,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, taskId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var items []models.ChecklistItem
+	for rows.Next() {
+		var item models.ChecklistItem
+		scanErr := rows.Scan(
+			&item.ID,
+			&item.TaskID,
+			&item.Text,
+			&item.Done,
+			&item.AssigneeID,
+			&item.Order,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (repo *ChecklistItemRepository) CountProgressByTaskIDs(
+	ctx context.Context,
+	taskIds []sqlddl.ID,
+) (map[sqlddl.ID]models.ChecklistProgress, error) {
+	const query = "SELECT %s, COUNT(*) FILTER (WHERE %s), COUNT(*) FROM %s WHERE %[1]s = ANY($1::TEXT[]) GROUP BY %[1]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.ColumnTaskID,
+		repositories.ColumnDone,
+		repositories.TableChecklistItems,
+	)
+	ids := make([]string, 0, len(taskIds))
+	for _, id := range taskIds {
+		ids = append(ids, string(id))
+	}
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, pq.Array(ids))
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	progress := make(map[sqlddl.ID]models.ChecklistProgress)
+	for rows.Next() {
+		var taskId sqlddl.ID
+		var taskProgress models.ChecklistProgress
+		if scanErr := rows.Scan(&taskId, &taskProgress.Done, &taskProgress.Total); scanErr != nil {
+			return nil, scanErr
+		}
+		progress[taskId] = taskProgress
+	}
+	return progress, nil
+}
+
+func (repo *ChecklistItemRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableChecklistItems, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
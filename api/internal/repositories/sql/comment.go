@@ -0,0 +1,131 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type CommentRepository struct {
+	DB *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) *CommentRepository {
+	return &CommentRepository{db}
+}
+
+func (repo *CommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableComments,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnAuthorID,
+		repositories.ColumnParentID,
+		repositories.ColumnBody,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		comment.ID,
+		comment.TaskID,
+		comment.AuthorID,
+		comment.ParentID,
+		comment.Body,
+	)
+	return execErr
+}
+
+func (repo *CommentRepository) UpdateBody(ctx context.Context, id sqlddl.ID, body string) error {
+	const query = "UPDATE %s SET %s = $1, %s = CURRENT_TIMESTAMP WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableComments,
+		repositories.ColumnBody,
+		sqlddl.ColumnUpdatedAt,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, body, id)
+	return execErr
+}
+
+func (repo *CommentRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Comment, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnAuthorID,
+		repositories.ColumnParentID,
+		repositories.ColumnBody,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableComments,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	var comment models.Comment
+	scanErr := row.Scan(
+		&comment.ID,
+		&comment.TaskID,
+		&comment.AuthorID,
+		&comment.ParentID,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &comment, nil
+}
+
+func (repo *CommentRepository) FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.Comment, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[6]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnAuthorID,
+		repositories.ColumnParentID,
+		repositories.ColumnBody,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableComments,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, taskId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var comments []models.Comment
+	for rows.Next() {
+		var comment models.Comment
+		scanErr := rows.Scan(
+			&comment.ID,
+			&comment.TaskID,
+			&comment.AuthorID,
+			&comment.ParentID,
+			&comment.Body,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		comments = append(comments, comment)
+	}
+	return comments, nil
+}
+
+func (repo *CommentRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableComments, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
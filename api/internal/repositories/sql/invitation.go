@@ -0,0 +1,274 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type InvitationRepository struct {
+	DB *sql.DB
+}
+
+// invitationColumns are columns of invitations table in order which scanInvitation reads them
+var invitationColumns = strings.Join([]string{
+	sqlddl.ColumnID,
+	repositories.ColumnBoardID,
+	repositories.ColumnInviterID,
+	repositories.ColumnEmail,
+	repositories.ColumnTokenHash,
+	repositories.ColumnRole,
+	repositories.ColumnExpiresAt,
+	repositories.ColumnMaxUses,
+	repositories.ColumnUses,
+	repositories.ColumnRevokedAt,
+	repositories.ColumnRevokedBy,
+	repositories.ColumnDeclinedAt,
+	sqlddl.ColumnCreatedAt,
+	sqlddl.ColumnUpdatedAt,
+}, ", ")
+
+// scanInvitation reads invitation selected with invitationColumns
+func scanInvitation(row rowScanner) (*models.Invitation, error) {
+	var invitation models.Invitation
+	var tokenHash sql.NullString
+	scanErr := row.Scan(
+		&invitation.ID,
+		&invitation.BoardID,
+		&invitation.InviterID,
+		&invitation.Email,
+		&tokenHash,
+		&invitation.Role,
+		&invitation.ExpiresAt,
+		&invitation.MaxUses,
+		&invitation.Uses,
+		&invitation.RevokedAt,
+		&invitation.RevokedBy,
+		&invitation.DeclinedAt,
+		&invitation.CreatedAt,
+		&invitation.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	invitation.TokenHash = tokenHash.String
+	return &invitation, nil
+}
+
+// scanInvitations reads all invitations selected with invitationColumns and closes rows
+func scanInvitations(rows *sql.Rows) ([]models.Invitation, error) {
+	defer rows.Close()
+	var invitations []models.Invitation
+	for rows.Next() {
+		invitation, scanErr := scanInvitation(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		invitations = append(invitations, *invitation)
+	}
+	return invitations, rows.Err()
+}
+
+func NewInvitationRepository(db *sql.DB) *InvitationRepository {
+	return &InvitationRepository{db}
+}
+
+func (repo *InvitationRepository) Create(ctx context.Context, invitation *models.Invitation) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableInvitations,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnInviterID,
+		repositories.ColumnEmail,
+		repositories.ColumnTokenHash,
+		repositories.ColumnRole,
+		repositories.ColumnExpiresAt,
+		repositories.ColumnMaxUses,
+	)
+	tokenHash := sql.NullString{String: invitation.TokenHash, Valid: invitation.TokenHash != ""}
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		invitation.ID,
+		invitation.BoardID,
+		invitation.InviterID,
+		invitation.Email,
+		tokenHash,
+		invitation.Role,
+		invitation.ExpiresAt,
+		invitation.MaxUses,
+	)
+	return execErr
+}
+
+func (repo *InvitationRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Invitation, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, invitationColumns, repositories.TableInvitations, sqlddl.ColumnID)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanInvitation(row)
+}
+
+func (repo *InvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*models.Invitation, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		invitationColumns,
+		repositories.TableInvitations,
+		repositories.ColumnTokenHash,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, tokenHash)
+	return scanInvitation(row)
+}
+
+func (repo *InvitationRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Invitation, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 ORDER BY %s DESC"
+	formattedQuery := fmt.Sprintf(
+		query,
+		invitationColumns,
+		repositories.TableInvitations,
+		repositories.ColumnBoardID,
+		sqlddl.ColumnCreatedAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanInvitations(rows)
+}
+
+func (repo *InvitationRepository) FindPendingByEmail(
+	ctx context.Context,
+	email string,
+	now time.Time,
+) ([]models.Invitation, error) {
+	const query = "SELECT %[1]s FROM %[2]s WHERE %[3]s = $1 AND %[4]s IS NULL AND %[5]s IS NULL AND %[6]s > $2 " +
+		"AND (%[7]s = 0 OR %[8]s < %[7]s) ORDER BY %[9]s DESC"
+	formattedQuery := fmt.Sprintf(
+		query,
+		invitationColumns,
+		repositories.TableInvitations,
+		repositories.ColumnEmail,
+		repositories.ColumnRevokedAt,
+		repositories.ColumnDeclinedAt,
+		repositories.ColumnExpiresAt,
+		repositories.ColumnMaxUses,
+		repositories.ColumnUses,
+		sqlddl.ColumnCreatedAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, email, now)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanInvitations(rows)
+}
+
+func (repo *InvitationRepository) Lock(ctx context.Context, id sqlddl.ID) error {
+	const query = "SELECT %s FROM %s WHERE %[1]s = $1 FOR UPDATE"
+	formattedQuery := fmt.Sprintf(query, sqlddl.ColumnID, repositories.TableInvitations)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, id)
+	if rowsErr != nil {
+		return rowsErr
+	}
+	return rows.Close()
+}
+
+func (repo *InvitationRepository) IncrementUses(ctx context.Context, id sqlddl.ID) error {
+	const query = "UPDATE %s SET %s = %[2]s + 1 WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableInvitations, repositories.ColumnUses, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
+
+func (repo *InvitationRepository) Revoke(ctx context.Context, id, userId sqlddl.ID, revokedAt time.Time) error {
+	const query = "UPDATE %s SET %s = $1, %s = $2 WHERE %s = $3"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableInvitations,
+		repositories.ColumnRevokedAt,
+		repositories.ColumnRevokedBy,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, revokedAt, userId, id)
+	return execErr
+}
+
+func (repo *InvitationRepository) Decline(ctx context.Context, id sqlddl.ID, declinedAt time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableInvitations,
+		repositories.ColumnDeclinedAt,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, declinedAt, id)
+	return execErr
+}
+
+func (repo *InvitationRepository) CreateResponse(ctx context.Context, response *models.InvitationResponse) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableInvitationResponses,
+		sqlddl.ColumnID,
+		repositories.ColumnInvitationID,
+		repositories.ColumnUserID,
+		repositories.ColumnAccepted,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		response.ID,
+		response.InvitationID,
+		response.UserID,
+		response.Accepted,
+	)
+	return execErr
+}
+
+func (repo *InvitationRepository) FindResponses(
+	ctx context.Context,
+	invitationId sqlddl.ID,
+) ([]models.InvitationResponse, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[5]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnInvitationID,
+		repositories.ColumnUserID,
+		repositories.ColumnAccepted,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableInvitationResponses,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, invitationId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var responses []models.InvitationResponse
+	for rows.Next() {
+		var response models.InvitationResponse
+		scanErr := rows.Scan(
+			&response.ID,
+			&response.InvitationID,
+			&response.UserID,
+			&response.Accepted,
+			&response.CreatedAt,
+			&response.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		responses = append(responses, response)
+	}
+	return responses, rows.Err()
+}
@@ -0,0 +1,31 @@
+package sql
+
+import (
+	"fmt"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// keysetCondition builds condition selecting records placed after page key in listing sorted by
+// creation time and id, key values are expected at firstParam and the next one, condition is TRUE if key is nil
+func keysetCondition(after *models.PageKey, firstParam int) string {
+	if after == nil {
+		return "TRUE"
+	}
+	return fmt.Sprintf(
+		"(%s, %s) > ($%d, $%d)",
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnID,
+		firstParam,
+		firstParam+1,
+	)
+}
+
+// keysetArgs returns query args for page limit followed by page key values of keysetCondition
+func keysetArgs(after *models.PageKey, limit int) []interface{} {
+	if after == nil {
+		return []interface{}{limit}
+	}
+	return []interface{}{limit, after.CreatedAt, after.ID}
+}
@@ -0,0 +1,239 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type LabelRepository struct {
+	DB *sql.DB
+}
+
+func NewLabelRepository(db *sql.DB) *LabelRepository {
+	return &LabelRepository{db}
+}
+
+func (repo *LabelRepository) Create(ctx context.Context, label *models.Label) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s) VALUES ($1, $2, $3, $4)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableLabels,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnColor,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		label.ID,
+		label.BoardID,
+		label.Name,
+		label.Color,
+	)
+	return execErr
+}
+
+func (repo *LabelRepository) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateLabel) error {
+	execErr := sqlquery.DynamicUpdate(ctx, sqlquery.Conn(ctx, repo.DB), &sqlquery.DynamicUpdateParams{
+		TableName:   repositories.TableLabels,
+		WhereColumn: sqlddl.ColumnID,
+		WhereValue:  id,
+		Changes: map[string]interface{}{
+			repositories.ColumnName:  d.Name,
+			repositories.ColumnColor: d.Color,
+		},
+		IsNilValue: func(value interface{}) bool {
+			switch v := value.(type) {
+			case *string:
+				return v == nil
+			default:
+				return true
+			}
+		},
+	})
+	return execErr
+}
+
+func (repo *LabelRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Label, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnColor,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableLabels,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	var label models.Label
+	scanErr := row.Scan(
+		&label.ID,
+		&label.BoardID,
+		&label.Name,
+		&label.Color,
+		&label.CreatedAt,
+		&label.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &label, nil
+}
+
+func (repo *LabelRepository) FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Label, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 AND %[3]s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnColor,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableLabels,
+	)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, boardId, name)
+	var label models.Label
+	scanErr := row.Scan(
+		&label.ID,
+		&label.BoardID,
+		&label.Name,
+		&label.Color,
+		&label.CreatedAt,
+		&label.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &label, nil
+}
+
+func (repo *LabelRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Label, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s FROM %s WHERE %[2]s = $1 ORDER BY %[3]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnColor,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableLabels,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var labels []models.Label
+	for rows.Next() {
+		var label models.Label
+		scanErr := rows.Scan(
+			&label.ID,
+			&label.BoardID,
+			&label.Name,
+			&label.Color,
+			&label.CreatedAt,
+			&label.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+func (repo *LabelRepository) FindAllByTaskIDs(
+	ctx context.Context,
+	taskIds []sqlddl.ID,
+) (map[sqlddl.ID][]models.Label, error) {
+	const query = "SELECT %[1]s.%[2]s, %[3]s.%[4]s, %[3]s.%[5]s, %[3]s.%[6]s, %[3]s.%[7]s, %[3]s.%[8]s, %[3]s.%[9]s " +
+		"FROM %[3]s JOIN %[1]s ON %[1]s.%[10]s = %[3]s.%[4]s " +
+		"WHERE %[1]s.%[2]s = ANY($1::TEXT[]) ORDER BY %[3]s.%[6]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTaskLabels,
+		repositories.ColumnTaskID,
+		repositories.TableLabels,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnName,
+		repositories.ColumnColor,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.ColumnLabelID,
+	)
+	ids := make([]string, 0, len(taskIds))
+	for _, id := range taskIds {
+		ids = append(ids, string(id))
+	}
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, pq.Array(ids))
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	labels := make(map[sqlddl.ID][]models.Label)
+	for rows.Next() {
+		var taskId sqlddl.ID
+		var label models.Label
+		scanErr := rows.Scan(
+			&taskId,
+			&label.ID,
+			&label.BoardID,
+			&label.Name,
+			&label.Color,
+			&label.CreatedAt,
+			&label.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		labels[taskId] = append(labels[taskId], label)
+	}
+	return labels, nil
+}
+
+// ReplaceTaskLabels must be called within transaction to keep task labels consistent
+func (repo *LabelRepository) ReplaceTaskLabels(ctx context.Context, taskId sqlddl.ID, labelIds []sqlddl.ID) error {
+	const deleteQuery = "DELETE FROM %s WHERE %s = $1"
+	formattedDeleteQuery := fmt.Sprintf(deleteQuery, repositories.TableTaskLabels, repositories.ColumnTaskID)
+	conn := sqlquery.Conn(ctx, repo.DB)
+	if _, execErr := conn.ExecContext(ctx, formattedDeleteQuery, taskId); execErr != nil {
+		return execErr
+	}
+	const insertQuery = "INSERT INTO %s (%s, %s, %s) VALUES ($1, $2, $3)"
+	formattedInsertQuery := fmt.Sprintf(
+		insertQuery,
+		repositories.TableTaskLabels,
+		sqlddl.ColumnID,
+		repositories.ColumnTaskID,
+		repositories.ColumnLabelID,
+	)
+	for _, labelId := range labelIds {
+		_, execErr := conn.ExecContext(ctx, formattedInsertQuery, identifier.GenerateUUID(), taskId, labelId)
+		if execErr != nil {
+			return execErr
+		}
+	}
+	return nil
+}
+
+func (repo *LabelRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableLabels, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
@@ -8,6 +8,7 @@ import (
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories"
 	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
 )
 
 type RefreshTokenRepository struct {
@@ -27,7 +28,7 @@ func (repo *RefreshTokenRepository) Create(ctx context.Context, token *models.Re
 		repositories.ColumnUserID,
 		repositories.ColumnToken,
 	)
-	_, execErr := repo.DB.ExecContext(
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
 		ctx,
 		formattedQuery,
 		token.ID,
@@ -49,7 +50,7 @@ func (repo *RefreshTokenRepository) FindByUserID(ctx context.Context, id sqlddl.
 		repositories.TableRefreshTokens,
 	)
 	var findToken models.RefreshToken
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, id)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
 	scanErr := row.Scan(
 		&findToken.ID,
 		&findToken.UserID,
@@ -71,7 +72,7 @@ func (repo *RefreshTokenRepository) FindUserIDByToken(ctx context.Context, token
 		repositories.TableRefreshTokens,
 	)
 	var userId sqlddl.ID
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, token)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, token)
 	scanErr := row.Scan(&userId)
 	if scanErr != nil {
 		return "", scanErr
@@ -91,7 +92,7 @@ func (repo *RefreshTokenRepository) FindByToken(ctx context.Context, token strin
 		repositories.TableRefreshTokens,
 	)
 	var findToken models.RefreshToken
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, token)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, token)
 	scanErr := row.Scan(
 		&findToken.ID,
 		&findToken.UserID,
@@ -108,13 +109,13 @@ func (repo *RefreshTokenRepository) FindByToken(ctx context.Context, token strin
 func (repo *RefreshTokenRepository) DeleteByToken(ctx context.Context, token string) error {
 	const query = "DELETE FROM %s WHERE %s = $1"
 	formattedQuery := fmt.Sprintf(query, repositories.TableRefreshTokens, repositories.ColumnToken)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, token)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, token)
 	return execErr
 }
 
 func (repo *RefreshTokenRepository) DeleteByUserID(ctx context.Context, userID sqlddl.ID) error {
 	const query = "DELETE FROM %s WHERE %s = $1"
 	formattedQuery := fmt.Sprintf(query, repositories.TableRefreshTokens, repositories.ColumnUserID)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, userID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, userID)
 	return execErr
 }
@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+// headlineOptions are ts_headline options of highlighted description fragments. Matches are delimited by
+// control characters, text is escaped before they're replaced by HTML tags, see models.HighlightSearchMatches
+var headlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=20, MinWords=5`,
+	models.SearchMatchStart,
+	models.SearchMatchStop,
+)
+
+// nameHeadlineOptions are ts_headline options of highlighted names which are shown entirely
+var nameHeadlineOptions = fmt.Sprintf(
+	`StartSel="%s", StopSel="%s", HighlightAll=true`,
+	models.SearchMatchStart,
+	models.SearchMatchStop,
+)
+
+type SearchRepository struct {
+	DB *sql.DB
+}
+
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db}
+}
+
+func (repo *SearchRepository) Search(
+	ctx context.Context,
+	userId sqlddl.ID,
+	query string,
+	limit,
+	offset int,
+) ([]models.SearchResult, error) {
+	const searchQuery = "WITH search AS (SELECT websearch_to_tsquery('%[1]s', $2) AS query) " +
+		"SELECT kind, %[4]s, %[5]s, task_order, %[7]s, snippet, rank FROM (" +
+		"SELECT '%[2]s' AS kind, t.%[4]s, t.%[5]s, t.%[6]s AS task_order, " +
+		"ts_headline('%[1]s', t.%[7]s, search.query, $5) AS %[7]s, " +
+		"ts_headline('%[1]s', COALESCE(t.%[8]s, ''), search.query, $6) AS snippet, " +
+		"ts_rank(t.%[9]s, search.query) AS rank " +
+		"FROM %[10]s t CROSS JOIN search " +
+		"JOIN %[12]s m ON m.%[5]s = t.%[5]s AND m.%[13]s = $1 " +
+		"JOIN %[11]s tb ON tb.%[4]s = t.%[5]s AND tb.%[14]s IS NULL AND tb.%[15]s IS NULL AND NOT tb.%[16]s " +
+		"WHERE t.%[9]s @@ search.query AND t.%[14]s IS NULL AND t.%[15]s IS NULL " +
+		"UNION ALL " +
+		"SELECT '%[3]s', b.%[4]s, b.%[4]s, NULL, " +
+		"ts_headline('%[1]s', b.%[7]s, search.query, $5), " +
+		"ts_headline('%[1]s', COALESCE(b.%[8]s, ''), search.query, $6), " +
+		"ts_rank(b.%[9]s, search.query) " +
+		"FROM %[11]s b CROSS JOIN search " +
+		"JOIN %[12]s m ON m.%[5]s = b.%[4]s AND m.%[13]s = $1 " +
+		"WHERE b.%[9]s @@ search.query AND b.%[14]s IS NULL AND b.%[15]s IS NULL AND NOT b.%[16]s" +
+		") results ORDER BY rank DESC, kind, %[4]s LIMIT $3 OFFSET $4"
+	formattedQuery := fmt.Sprintf(
+		searchQuery,
+		repositories.SearchConfig,
+		models.SearchResultTask,
+		models.SearchResultBoard,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnOrder,
+		repositories.ColumnName,
+		repositories.ColumnDescription,
+		repositories.ColumnSearchVector,
+		repositories.TableTasks,
+		repositories.TableBoards,
+		repositories.TableBoardMembers,
+		repositories.ColumnUserID,
+		repositories.ColumnArchivedAt,
+		repositories.ColumnDeletedAt,
+		repositories.ColumnIsTemplate,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(
+		ctx,
+		formattedQuery,
+		userId,
+		query,
+		limit,
+		offset,
+		nameHeadlineOptions,
+		headlineOptions,
+	)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	results := make([]models.SearchResult, 0)
+	for rows.Next() {
+		var result models.SearchResult
+		scanErr := rows.Scan(
+			&result.Kind,
+			&result.ID,
+			&result.BoardID,
+			&result.TaskOrder,
+			&result.Name,
+			&result.Snippet,
+			&result.Rank,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		result.Name = models.HighlightSearchMatches(result.Name)
+		result.Snippet = models.HighlightSearchMatches(result.Snippet)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
@@ -1,9 +1,13 @@
 package sql
 
 import (
+	"github.com/lib/pq"
+
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories"
@@ -15,12 +19,81 @@ type TaskRepository struct {
 	DB *sql.DB
 }
 
+// taskColumns are columns of tasks table in order which scanTask reads them
+var taskColumns = strings.Join([]string{
+	sqlddl.ColumnID,
+	repositories.ColumnBoardID,
+	repositories.ColumnName,
+	repositories.ColumnDescription,
+	repositories.ColumnStatus,
+	repositories.ColumnColumnID,
+	repositories.ColumnOrder,
+	repositories.ColumnPosition,
+	repositories.ColumnCreatorID,
+	repositories.ColumnAssigneeID,
+	repositories.ColumnStartAt,
+	repositories.ColumnDueAt,
+	repositories.ColumnRemindedAt,
+	repositories.ColumnArchivedAt,
+	repositories.ColumnDeletedAt,
+	sqlddl.ColumnCreatedAt,
+	sqlddl.ColumnUpdatedAt,
+}, ", ")
+
+// rowScanner is common part of sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask reads task selected with taskColumns
+func scanTask(row rowScanner) (*models.Task, error) {
+	var task models.Task
+	scanErr := row.Scan(
+		&task.ID,
+		&task.BoardID,
+		&task.Name,
+		&task.Description,
+		&task.Status,
+		&task.ColumnID,
+		&task.Order,
+		&task.Position,
+		&task.CreatorID,
+		&task.AssigneeID,
+		&task.StartAt,
+		&task.DueAt,
+		&task.RemindedAt,
+		&task.ArchivedAt,
+		&task.DeletedAt,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	return &task, nil
+}
+
+// scanTasks reads all tasks selected with taskColumns and closes rows
+func scanTasks(rows *sql.Rows) ([]models.Task, error) {
+	defer rows.Close()
+	var tasks []models.Task
+	for rows.Next() {
+		task, scanErr := scanTask(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		tasks = append(tasks, *task)
+	}
+	return tasks, rows.Err()
+}
+
 func NewTaskRepository(db *sql.DB) *TaskRepository {
 	return &TaskRepository{db}
 }
 
 func (repo *TaskRepository) Create(ctx context.Context, task *models.Task) error {
-	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
 	formattedQuery := fmt.Sprintf(
 		query,
 		repositories.TableTasks,
@@ -28,28 +101,36 @@ func (repo *TaskRepository) Create(ctx context.Context, task *models.Task) error
 		repositories.ColumnName,
 		repositories.ColumnDescription,
 		repositories.ColumnStatus,
+		repositories.ColumnColumnID,
 		repositories.ColumnOrder,
+		repositories.ColumnPosition,
 		repositories.ColumnBoardID,
 		repositories.ColumnCreatorID,
 		repositories.ColumnAssigneeID,
+		repositories.ColumnStartAt,
+		repositories.ColumnDueAt,
 	)
-	_, execErr := repo.DB.ExecContext(
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
 		ctx,
 		formattedQuery,
 		task.ID,
 		task.Name,
 		task.Description,
 		task.Status,
+		task.ColumnID,
 		task.Order,
+		task.Position,
 		task.BoardID,
 		task.CreatorID,
 		task.AssigneeID,
+		task.StartAt,
+		task.DueAt,
 	)
 	return execErr
 }
 
 func (repo *TaskRepository) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateTask) error {
-	execErr := sqlquery.DynamicUpdate(ctx, repo.DB, &sqlquery.DynamicUpdateParams{
+	execErr := sqlquery.DynamicUpdate(ctx, sqlquery.Conn(ctx, repo.DB), &sqlquery.DynamicUpdateParams{
 		TableName:   repositories.TableTasks,
 		WhereColumn: sqlddl.ColumnID,
 		WhereValue:  id,
@@ -57,7 +138,11 @@ func (repo *TaskRepository) Update(ctx context.Context, id sqlddl.ID, d *models.
 			repositories.ColumnName:        d.Name,
 			repositories.ColumnDescription: d.Description,
 			repositories.ColumnStatus:      d.Status,
+			repositories.ColumnColumnID:    d.ColumnID,
 			repositories.ColumnAssigneeID:  d.AssigneeID,
+			repositories.ColumnPosition:    d.Position,
+			repositories.ColumnStartAt:     d.StartAt,
+			repositories.ColumnDueAt:       d.DueAt,
 		},
 		IsNilValue: func(value interface{}) bool {
 			switch v := value.(type) {
@@ -65,6 +150,10 @@ func (repo *TaskRepository) Update(ctx context.Context, id sqlddl.ID, d *models.
 				return v == nil
 			case *sqlddl.ID:
 				return v == nil
+			case *int:
+				return v == nil
+			case *time.Time:
+				return v == nil
 			case *string:
 				return v == nil
 			default:
@@ -76,155 +165,279 @@ func (repo *TaskRepository) Update(ctx context.Context, id sqlddl.ID, d *models.
 }
 
 func (repo *TaskRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Task, error) {
-	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s IS NULL"
 	formattedQuery := fmt.Sprintf(
 		query,
-		sqlddl.ColumnID,
-		repositories.ColumnBoardID,
-		repositories.ColumnName,
-		repositories.ColumnDescription,
-		repositories.ColumnStatus,
-		repositories.ColumnOrder,
-		repositories.ColumnCreatorID,
-		repositories.ColumnAssigneeID,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
+		taskColumns,
 		repositories.TableTasks,
+		sqlddl.ColumnID,
+		repositories.ColumnDeletedAt,
 	)
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, id)
-	var findTask models.Task
-	scanErr := row.Scan(
-		&findTask.ID,
-		&findTask.BoardID,
-		&findTask.Name,
-		&findTask.Description,
-		&findTask.Status,
-		&findTask.Order,
-		&findTask.CreatorID,
-		&findTask.AssigneeID,
-		&findTask.CreatedAt,
-		&findTask.UpdatedAt,
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanTask(row)
+}
+
+func (repo *TaskRepository) FindDeletedByID(ctx context.Context, id sqlddl.ID) (*models.Task, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s IS NOT NULL"
+	formattedQuery := fmt.Sprintf(
+		query,
+		taskColumns,
+		repositories.TableTasks,
+		sqlddl.ColumnID,
+		repositories.ColumnDeletedAt,
 	)
-	return &findTask, scanErr
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanTask(row)
 }
 
 func (repo *TaskRepository) FindByOrder(ctx context.Context, boardId sqlddl.ID, order uint) (*models.Task, error) {
-	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1 AND %[2]s = $2"
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s = $2 AND %s IS NULL"
 	formattedQuery := fmt.Sprintf(
 		query,
+		taskColumns,
+		repositories.TableTasks,
 		repositories.ColumnBoardID,
 		repositories.ColumnOrder,
-		repositories.ColumnName,
-		sqlddl.ColumnID,
-		repositories.ColumnDescription,
-		repositories.ColumnStatus,
-		repositories.ColumnCreatorID,
-		repositories.ColumnAssigneeID,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
-		repositories.TableTasks,
-	)
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, boardId, order)
-	var findTask models.Task
-	scanErr := row.Scan(
-		&findTask.BoardID,
-		&findTask.Order,
-		&findTask.Name,
-		&findTask.ID,
-		&findTask.Description,
-		&findTask.Status,
-		&findTask.CreatorID,
-		&findTask.AssigneeID,
-		&findTask.CreatedAt,
-		&findTask.UpdatedAt,
+		repositories.ColumnDeletedAt,
 	)
-	return &findTask, scanErr
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, boardId, order)
+	return scanTask(row)
 }
 
 func (repo *TaskRepository) FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Task, error) {
-	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1 AND %[2]s = $2"
-	formatterQuery := fmt.Sprintf(
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s = $2 AND %s IS NULL"
+	formattedQuery := fmt.Sprintf(
 		query,
+		taskColumns,
+		repositories.TableTasks,
 		repositories.ColumnBoardID,
-		repositories.ColumnOrder,
 		repositories.ColumnName,
-		sqlddl.ColumnID,
-		repositories.ColumnDescription,
-		repositories.ColumnStatus,
-		repositories.ColumnCreatorID,
-		repositories.ColumnAssigneeID,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
-		repositories.TableTasks,
-	)
-	row := repo.DB.QueryRowContext(ctx, formatterQuery, boardId, name)
-	var findTask models.Task
-	scanErr := row.Scan(
-		&findTask.BoardID,
-		&findTask.Name,
-		&findTask.ID,
-		&findTask.Description,
-		&findTask.Status,
-		&findTask.Order,
-		&findTask.CreatorID,
-		&findTask.AssigneeID,
-		&findTask.CreatedAt,
-		&findTask.UpdatedAt,
+		repositories.ColumnDeletedAt,
 	)
-	return &findTask, scanErr
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, boardId, name)
+	return scanTask(row)
 }
 
 func (repo *TaskRepository) FindAllByBoardId(ctx context.Context, boardId sqlddl.ID) ([]models.Task, error) {
-	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %[1]s = $1"
+	const query = "SELECT %s FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, taskColumns, repositories.TableTasks, repositories.ColumnBoardID)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanTasks(rows)
+}
+
+// taskSortColumns maps sort fields to columns, so sorting never puts client input into query
+var taskSortColumns = map[models.TaskSortField]string{
+	models.TaskSortOrder:     repositories.ColumnOrder,
+	models.TaskSortCreatedAt: sqlddl.ColumnCreatedAt,
+	models.TaskSortUpdatedAt: sqlddl.ColumnUpdatedAt,
+}
+
+func (repo *TaskRepository) FindPageByBoardID(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	filter *models.TaskFilter,
+) ([]models.Task, int, error) {
+	archivedCondition := "NULL"
+	if filter.Archived {
+		archivedCondition = "NOT NULL"
+	}
+	conditions := []string{
+		fmt.Sprintf("%s = $1", repositories.ColumnBoardID),
+		fmt.Sprintf("%s IS NULL", repositories.ColumnDeletedAt),
+		fmt.Sprintf("%s IS %s", repositories.ColumnArchivedAt, archivedCondition),
+	}
+	args := []interface{}{boardId}
+	addCondition := func(format, column string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, column, len(args)))
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]int64, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, int64(status))
+		}
+		addCondition("%s = ANY($%d)", repositories.ColumnStatus, pq.Array(statuses))
+	}
+	if filter.AssigneeID != "" {
+		addCondition("%s = $%d", repositories.ColumnAssigneeID, filter.AssigneeID)
+	}
+	if filter.CreatorID != "" {
+		addCondition("%s = $%d", repositories.ColumnCreatorID, filter.CreatorID)
+	}
+	if filter.CreatedFrom != nil {
+		addCondition("%s >= $%d", sqlddl.ColumnCreatedAt, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCondition("%s <= $%d", sqlddl.ColumnCreatedAt, *filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		addCondition("%s >= $%d", sqlddl.ColumnUpdatedAt, *filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		addCondition("%s <= $%d", sqlddl.ColumnUpdatedAt, *filter.UpdatedTo)
+	}
+	where := strings.Join(conditions, " AND ")
+	conn := sqlquery.Conn(ctx, repo.DB)
+
+	const countQuery = "SELECT COUNT(*) FROM %s WHERE %s"
+	var total int
+	countRow := conn.QueryRowContext(ctx, fmt.Sprintf(countQuery, repositories.TableTasks, where), args...)
+	if scanErr := countRow.Scan(&total); scanErr != nil {
+		return nil, 0, scanErr
+	}
+
+	sortColumn, ok := taskSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = repositories.ColumnOrder
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	// order is unique on board, it keeps pages stable when sorting values are equal
+	const query = "SELECT %[1]s FROM %[2]s WHERE %[3]s ORDER BY %[4]s %[5]s, %[6]s %[5]s LIMIT $%[7]d OFFSET $%[8]d"
 	formattedQuery := fmt.Sprintf(
 		query,
-		repositories.ColumnBoardID,
-		sqlddl.ColumnID,
-		repositories.ColumnName,
-		repositories.ColumnDescription,
-		repositories.ColumnStatus,
+		taskColumns,
+		repositories.TableTasks,
+		where,
+		sortColumn,
+		direction,
 		repositories.ColumnOrder,
-		repositories.ColumnCreatorID,
-		repositories.ColumnAssigneeID,
-		sqlddl.ColumnCreatedAt,
-		sqlddl.ColumnUpdatedAt,
+		len(args)+1,
+		len(args)+2,
+	)
+	rows, rowsErr := conn.QueryContext(ctx, formattedQuery, append(args, filter.Limit, filter.Offset)...)
+	if rowsErr != nil {
+		return nil, 0, rowsErr
+	}
+	tasks, scanErr := scanTasks(rows)
+	return tasks, total, scanErr
+}
+
+// FindAllDueBefore searches for not done active tasks of active boards which are due before deadline
+// and weren't reminded about as overdue yet
+func (repo *TaskRepository) FindAllDueBefore(ctx context.Context, deadline time.Time) ([]models.Task, error) {
+	const query = "SELECT %s FROM %s WHERE %s <= $1 AND %s <> $2 AND (%s IS NULL OR %[5]s < %[3]s) " +
+		"AND %[6]s IS NULL AND %[7]s IS NULL AND %[8]s NOT IN (SELECT %[9]s FROM %[10]s WHERE %[7]s IS NOT NULL OR %[11]s)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		taskColumns,
 		repositories.TableTasks,
+		repositories.ColumnDueAt,
+		repositories.ColumnStatus,
+		repositories.ColumnRemindedAt,
+		repositories.ColumnArchivedAt,
+		repositories.ColumnDeletedAt,
+		repositories.ColumnBoardID,
+		sqlddl.ColumnID,
+		repositories.TableBoards,
+		repositories.ColumnIsTemplate,
 	)
-	rows, rowsErr := repo.DB.QueryContext(ctx, formattedQuery, boardId)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, deadline, models.TaskStatusDone)
 	if rowsErr != nil {
 		return nil, rowsErr
 	}
-	defer rows.Close()
-	var tasks []models.Task
-	for rows.Next() {
-		var task models.Task
-		scanErr := rows.Scan(
-			&task.BoardID,
-			&task.ID,
-			&task.Name,
-			&task.Description,
-			&task.Status,
-			&task.Order,
-			&task.CreatorID,
-			&task.AssigneeID,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-		)
-		if scanErr != nil {
-			return nil, scanErr
-		}
-		tasks = append(tasks, task)
-	}
-	return tasks, nil
+	return scanTasks(rows)
 }
 
-func (repo *TaskRepository) Delete(ctx context.Context, taskId sqlddl.ID) error {
-	const query = "DELETE FROM %s WHERE %s = $1"
+func (repo *TaskRepository) CountByColumnID(ctx context.Context, columnId sqlddl.ID) (int, error) {
+	const query = "SELECT COUNT(*) FROM %s WHERE %s = $1 AND %s IS NULL AND %s IS NULL"
 	formattedQuery := fmt.Sprintf(
 		query,
 		repositories.TableTasks,
-		sqlddl.ColumnID,
+		repositories.ColumnColumnID,
+		repositories.ColumnArchivedAt,
+		repositories.ColumnDeletedAt,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, taskId)
+	var count int
+	scanErr := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, columnId).Scan(&count)
+	return count, scanErr
+}
+
+// ShiftPositions moves tasks of column which are placed at fromPosition or below by delta positions
+func (repo *TaskRepository) ShiftPositions(ctx context.Context, columnId sqlddl.ID, fromPosition, delta int) error {
+	const query = "UPDATE %s SET %s = %[2]s + $1 WHERE %s = $2 AND %[2]s >= $3"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTasks,
+		repositories.ColumnPosition,
+		repositories.ColumnColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, delta, columnId, fromPosition)
+	return execErr
+}
+
+// SetRemindedAt saves time when the last reminder about task has been sent
+func (repo *TaskRepository) SetRemindedAt(ctx context.Context, taskId sqlddl.ID, remindedAt time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(query, repositories.TableTasks, repositories.ColumnRemindedAt, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, remindedAt, taskId)
+	return execErr
+}
+
+// MoveHiddenTasks moves archived and moved to trash tasks of column into another one
+func (repo *TaskRepository) MoveHiddenTasks(ctx context.Context, fromColumnId sqlddl.ID, to *models.BoardColumn) error {
+	const query = "UPDATE %s SET %s = $1, %s = $2 WHERE %[2]s = $3 AND (%s IS NOT NULL OR %s IS NOT NULL)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTasks,
+		repositories.ColumnColumnID,
+		repositories.ColumnStatus,
+		repositories.ColumnArchivedAt,
+		repositories.ColumnDeletedAt,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, to.ID, to.Status, fromColumnId)
+	return execErr
+}
+
+func (repo *TaskRepository) SetArchivedAt(ctx context.Context, taskId sqlddl.ID, archivedAt *time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(query, repositories.TableTasks, repositories.ColumnArchivedAt, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, archivedAt, taskId)
 	return execErr
 }
+
+func (repo *TaskRepository) SetDeletedAt(ctx context.Context, taskId sqlddl.ID, deletedAt *time.Time) error {
+	const query = "UPDATE %s SET %s = $1 WHERE %s = $2"
+	formattedQuery := fmt.Sprintf(query, repositories.TableTasks, repositories.ColumnDeletedAt, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, deletedAt, taskId)
+	return execErr
+}
+
+// FindDeletedByUserID searches for tasks moved to trash from active boards which user is member of
+func (repo *TaskRepository) FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Task, error) {
+	const query = "SELECT %[1]s FROM %[2]s WHERE %[3]s IS NOT NULL AND %[4]s IN (" +
+		"SELECT b.%[5]s FROM %[6]s b JOIN %[7]s m ON m.%[4]s = b.%[5]s AND m.%[8]s = $1 WHERE b.%[3]s IS NULL" +
+		") ORDER BY %[3]s DESC"
+	formattedQuery := fmt.Sprintf(
+		query,
+		taskColumns,
+		repositories.TableTasks,
+		repositories.ColumnDeletedAt,
+		repositories.ColumnBoardID,
+		sqlddl.ColumnID,
+		repositories.TableBoards,
+		repositories.TableBoardMembers,
+		repositories.ColumnUserID,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, userId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanTasks(rows)
+}
+
+// PurgeDeletedBefore permanently removes tasks moved to trash before deadline
+func (repo *TaskRepository) PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error) {
+	const query = "DELETE FROM %s WHERE %s < $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableTasks, repositories.ColumnDeletedAt)
+	result, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, deadline)
+	if execErr != nil {
+		return 0, execErr
+	}
+	return result.RowsAffected()
+}
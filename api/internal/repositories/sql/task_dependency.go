@@ -0,0 +1,128 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type TaskDependencyRepository struct {
+	DB *sql.DB
+}
+
+func NewTaskDependencyRepository(db *sql.DB) *TaskDependencyRepository {
+	return &TaskDependencyRepository{db}
+}
+
+func (repo *TaskDependencyRepository) Create(ctx context.Context, dependency *models.TaskDependency) error {
+	const query = "INSERT INTO %s (%s, %s, %s) VALUES ($1, $2, $3)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTaskDependencies,
+		sqlddl.ColumnID,
+		repositories.ColumnBlockerID,
+		repositories.ColumnBlockedID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		dependency.ID,
+		dependency.BlockerID,
+		dependency.BlockedID,
+	)
+	return execErr
+}
+
+func (repo *TaskDependencyRepository) Delete(ctx context.Context, blockerId, blockedId sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1 AND %s = $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTaskDependencies,
+		repositories.ColumnBlockerID,
+		repositories.ColumnBlockedID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, blockerId, blockedId)
+	return execErr
+}
+
+// Lock takes transaction level advisory lock named after dependencies table
+func (repo *TaskDependencyRepository) Lock(ctx context.Context) error {
+	const query = "SELECT pg_advisory_xact_lock(hashtext('%s'))"
+	formattedQuery := fmt.Sprintf(query, repositories.TableTaskDependencies)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery)
+	return execErr
+}
+
+func (repo *TaskDependencyRepository) FindBlockers(
+	ctx context.Context,
+	taskIds []sqlddl.ID,
+) (map[sqlddl.ID][]models.TaskReference, error) {
+	return repo.findLinked(ctx, taskIds, repositories.ColumnBlockedID, repositories.ColumnBlockerID)
+}
+
+func (repo *TaskDependencyRepository) FindBlocked(
+	ctx context.Context,
+	taskIds []sqlddl.ID,
+) (map[sqlddl.ID][]models.TaskReference, error) {
+	return repo.findLinked(ctx, taskIds, repositories.ColumnBlockerID, repositories.ColumnBlockedID)
+}
+
+// findLinked searches for tasks referenced by linkedColumn of dependencies where fromColumn is one of taskIds,
+// found tasks are grouped by fromColumn value, tasks moved to trash are skipped
+func (repo *TaskDependencyRepository) findLinked(
+	ctx context.Context,
+	taskIds []sqlddl.ID,
+	fromColumn,
+	linkedColumn string,
+) (map[sqlddl.ID][]models.TaskReference, error) {
+	const query = "SELECT %[1]s.%[2]s, %[3]s.%[4]s, %[3]s.%[5]s, %[3]s.%[6]s, %[3]s.%[7]s, %[3]s.%[8]s " +
+		"FROM %[1]s JOIN %[3]s ON %[3]s.%[4]s = %[1]s.%[9]s " +
+		"WHERE %[1]s.%[2]s = ANY($1::TEXT[]) AND %[3]s.%[10]s IS NULL ORDER BY %[3]s.%[5]s, %[3]s.%[6]s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTaskDependencies,
+		fromColumn,
+		repositories.TableTasks,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnOrder,
+		repositories.ColumnName,
+		repositories.ColumnStatus,
+		linkedColumn,
+		repositories.ColumnDeletedAt,
+	)
+	ids := make([]string, 0, len(taskIds))
+	for _, id := range taskIds {
+		ids = append(ids, string(id))
+	}
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, pq.Array(ids))
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	linked := make(map[sqlddl.ID][]models.TaskReference)
+	for rows.Next() {
+		var fromId sqlddl.ID
+		var reference models.TaskReference
+		scanErr := rows.Scan(
+			&fromId,
+			&reference.ID,
+			&reference.BoardID,
+			&reference.Order,
+			&reference.Name,
+			&reference.Status,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		linked[fromId] = append(linked[fromId], reference)
+	}
+	return linked, nil
+}
@@ -0,0 +1,128 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type TaskHistoryRepository struct {
+	DB *sql.DB
+}
+
+func NewTaskHistoryRepository(db *sql.DB) *TaskHistoryRepository {
+	return &TaskHistoryRepository{db}
+}
+
+// Create uses clock_timestamp, so entries written within one transaction keep their order
+func (repo *TaskHistoryRepository) Create(ctx context.Context, entry *models.TaskHistoryEntry) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s) " +
+		"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, clock_timestamp(), clock_timestamp())"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableTaskHistory,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnTaskID,
+		repositories.ColumnTaskOrder,
+		repositories.ColumnActorID,
+		repositories.ColumnAction,
+		repositories.ColumnField,
+		repositories.ColumnOldValue,
+		repositories.ColumnNewValue,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		entry.ID,
+		entry.BoardID,
+		entry.TaskID,
+		entry.TaskOrder,
+		entry.ActorID,
+		entry.Action,
+		entry.Field,
+		entry.OldValue,
+		entry.NewValue,
+	)
+	return execErr
+}
+
+func (repo *TaskHistoryRepository) FindAllByTaskID(
+	ctx context.Context,
+	taskId sqlddl.ID,
+	limit,
+	offset int,
+) ([]models.TaskHistoryEntry, error) {
+	return repo.findAll(ctx, repositories.ColumnTaskID, taskId, limit, offset)
+}
+
+func (repo *TaskHistoryRepository) FindAllByBoardID(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	limit,
+	offset int,
+) ([]models.TaskHistoryEntry, error) {
+	return repo.findAll(ctx, repositories.ColumnBoardID, boardId, limit, offset)
+}
+
+// findAll searches for page of history entries where whereColumn equal provided value, the newest first
+func (repo *TaskHistoryRepository) findAll(
+	ctx context.Context,
+	whereColumn string,
+	whereValue sqlddl.ID,
+	limit,
+	offset int,
+) ([]models.TaskHistoryEntry, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s " +
+		"WHERE %s = $1 ORDER BY %[10]s DESC LIMIT $2 OFFSET $3"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnTaskID,
+		repositories.ColumnTaskOrder,
+		repositories.ColumnActorID,
+		repositories.ColumnAction,
+		repositories.ColumnField,
+		repositories.ColumnOldValue,
+		repositories.ColumnNewValue,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableTaskHistory,
+		whereColumn,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, whereValue, limit, offset)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	entries := make([]models.TaskHistoryEntry, 0, limit)
+	for rows.Next() {
+		var entry models.TaskHistoryEntry
+		scanErr := rows.Scan(
+			&entry.ID,
+			&entry.BoardID,
+			&entry.TaskID,
+			&entry.TaskOrder,
+			&entry.ActorID,
+			&entry.Action,
+			&entry.Field,
+			&entry.OldValue,
+			&entry.NewValue,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,20 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"just-kanban/pkg/sqlquery"
+)
+
+type Transactor struct {
+	DB *sql.DB
+}
+
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db}
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return sqlquery.RunInTx(ctx, t.DB, fn)
+}
@@ -32,7 +32,7 @@ func (repo *UserRepository) Create(ctx context.Context, user *models.User) error
 		repositories.ColumnsLastName,
 		repositories.ColumnAvatar,
 	)
-	_, execErr := repo.DB.ExecContext(
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
 		ctx,
 		formattedQuery,
 		user.ID,
@@ -48,7 +48,7 @@ func (repo *UserRepository) Create(ctx context.Context, user *models.User) error
 
 // Update partial change data of user record and save it to db
 func (repo *UserRepository) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateUser) error {
-	execErr := sqlquery.DynamicUpdate(ctx, repo.DB, &sqlquery.DynamicUpdateParams{
+	execErr := sqlquery.DynamicUpdate(ctx, sqlquery.Conn(ctx, repo.DB), &sqlquery.DynamicUpdateParams{
 		TableName:   repositories.TableUsers,
 		WhereColumn: sqlddl.ColumnID,
 		WhereValue:  id,
@@ -77,7 +77,7 @@ func (repo *UserRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models
 		repositories.TableUsers,
 	)
 	var findUser models.User
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, id)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
 	scanErr := row.Scan(
 		&findUser.ID,
 		&findUser.Email,
@@ -111,7 +111,7 @@ func (repo *UserRepository) FindByUsername(ctx context.Context, username string)
 		repositories.TableUsers,
 	)
 	var findUser models.User
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, username)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, username)
 	scanErr := row.Scan(
 		&findUser.Username,
 		&findUser.ID,
@@ -146,7 +146,7 @@ func (repo *UserRepository) FindByEmail(ctx context.Context, email string) (*mod
 		repositories.TableUsers,
 	)
 	var findUser models.User
-	row := repo.DB.QueryRowContext(ctx, formattedQuery, email)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, email)
 	scanErr := row.Scan(
 		&findUser.ID,
 		&findUser.Email,
@@ -180,7 +180,7 @@ func (repo *UserRepository) FindAll(ctx context.Context) ([]models.User, error)
 		repositories.TableUsers,
 	)
 	var findUsers []models.User
-	rows, rowsErr := repo.DB.QueryContext(ctx, formattedQuery)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery)
 	if rowsErr != nil {
 		return nil, rowsErr
 	}
@@ -206,6 +206,49 @@ func (repo *UserRepository) FindAll(ctx context.Context) ([]models.User, error)
 	return findUsers, nil
 }
 
+func (repo *UserRepository) FindPage(ctx context.Context, after *models.PageKey, limit int) ([]models.User, error) {
+	const query = "SELECT %s, %s, %s, %s, %s, %s, %s, %s, %s FROM %s WHERE %s ORDER BY %[8]s, %[1]s LIMIT $1"
+	formattedQuery := fmt.Sprintf(
+		query,
+		sqlddl.ColumnID,
+		repositories.ColumnEmail,
+		repositories.ColumnPassword,
+		repositories.ColumnAvatar,
+		repositories.ColumnUsername,
+		repositories.ColumnFirstName,
+		repositories.ColumnsLastName,
+		sqlddl.ColumnCreatedAt,
+		sqlddl.ColumnUpdatedAt,
+		repositories.TableUsers,
+		keysetCondition(after, 2),
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, keysetArgs(after, limit)...)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	findUsers := make([]models.User, 0, limit)
+	for rows.Next() {
+		var findUser models.User
+		scanErr := rows.Scan(
+			&findUser.ID,
+			&findUser.Email,
+			&findUser.Password,
+			&findUser.Avatar,
+			&findUser.Username,
+			&findUser.FirstName,
+			&findUser.LastName,
+			&findUser.CreatedAt,
+			&findUser.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		findUsers = append(findUsers, findUser)
+	}
+	return findUsers, rows.Err()
+}
+
 func (repo *UserRepository) Delete(ctx context.Context, id sqlddl.ID) error {
 	const query = "DELETE FROM %s WHERE %s = $1"
 	formattedQuery := fmt.Sprintf(
@@ -213,7 +256,7 @@ func (repo *UserRepository) Delete(ctx context.Context, id sqlddl.ID) error {
 		repositories.TableUsers,
 		sqlddl.ColumnID,
 	)
-	_, execErr := repo.DB.ExecContext(ctx, formattedQuery, id)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
 	if execErr != nil {
 		return execErr
 	}
@@ -0,0 +1,304 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/sqlquery"
+)
+
+type WebhookRepository struct {
+	DB *sql.DB
+}
+
+// webhookColumns are columns of webhooks table in order which scanWebhook reads them
+var webhookColumns = strings.Join([]string{
+	sqlddl.ColumnID,
+	repositories.ColumnBoardID,
+	repositories.ColumnCreatorID,
+	repositories.ColumnURL,
+	repositories.ColumnEvents,
+	repositories.ColumnSecret,
+	repositories.ColumnFailureCount,
+	repositories.ColumnDisabledAt,
+	sqlddl.ColumnCreatedAt,
+	sqlddl.ColumnUpdatedAt,
+}, ", ")
+
+// webhookDeliveryColumns are columns of webhook deliveries table in order which FindDeliveries reads them
+var webhookDeliveryColumns = strings.Join([]string{
+	sqlddl.ColumnID,
+	repositories.ColumnWebhookID,
+	repositories.ColumnEventID,
+	repositories.ColumnEventType,
+	repositories.ColumnAttempt,
+	repositories.ColumnStatusCode,
+	repositories.ColumnError,
+	repositories.ColumnSucceeded,
+	repositories.ColumnDuration,
+	sqlddl.ColumnCreatedAt,
+	sqlddl.ColumnUpdatedAt,
+}, ", ")
+
+// scanWebhook reads webhook selected with webhookColumns
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var events pq.StringArray
+	scanErr := row.Scan(
+		&webhook.ID,
+		&webhook.BoardID,
+		&webhook.CreatorID,
+		&webhook.URL,
+		&events,
+		&webhook.Secret,
+		&webhook.FailureCount,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+	webhook.Events = make([]models.BoardEventType, 0, len(events))
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, models.BoardEventType(event))
+	}
+	return &webhook, nil
+}
+
+// scanWebhooks reads all webhooks selected with webhookColumns and closes rows
+func scanWebhooks(rows *sql.Rows) ([]models.Webhook, error) {
+	defer rows.Close()
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, scanErr := scanWebhook(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// eventsArray converts event types to value of sql TEXT[] column
+func eventsArray(events []models.BoardEventType) pq.StringArray {
+	array := make(pq.StringArray, 0, len(events))
+	for _, event := range events {
+		array = append(array, string(event))
+	}
+	return array
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+func (repo *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableWebhooks,
+		sqlddl.ColumnID,
+		repositories.ColumnBoardID,
+		repositories.ColumnCreatorID,
+		repositories.ColumnURL,
+		repositories.ColumnEvents,
+		repositories.ColumnSecret,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		webhook.ID,
+		webhook.BoardID,
+		webhook.CreatorID,
+		webhook.URL,
+		eventsArray(webhook.Events),
+		webhook.Secret,
+	)
+	return execErr
+}
+
+func (repo *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook, enable bool) error {
+	const query = "UPDATE %[1]s SET %[2]s = $1, %[3]s = $2, %[4]s = $3, %[5]s = CURRENT_TIMESTAMP, " +
+		"%[6]s = CASE WHEN $4 THEN 0 ELSE %[6]s END, %[7]s = CASE WHEN $4 THEN NULL ELSE %[7]s END " +
+		"WHERE %[8]s = $5"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableWebhooks,
+		repositories.ColumnURL,
+		repositories.ColumnEvents,
+		repositories.ColumnSecret,
+		sqlddl.ColumnUpdatedAt,
+		repositories.ColumnFailureCount,
+		repositories.ColumnDisabledAt,
+		sqlddl.ColumnID,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		webhook.URL,
+		eventsArray(webhook.Events),
+		webhook.Secret,
+		enable,
+		webhook.ID,
+	)
+	return execErr
+}
+
+func (repo *WebhookRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	const query = "DELETE FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableWebhooks, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
+
+func (repo *WebhookRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Webhook, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, webhookColumns, repositories.TableWebhooks, sqlddl.ColumnID)
+	row := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, id)
+	return scanWebhook(row)
+}
+
+func (repo *WebhookRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Webhook, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 ORDER BY %s"
+	formattedQuery := fmt.Sprintf(
+		query,
+		webhookColumns,
+		repositories.TableWebhooks,
+		repositories.ColumnBoardID,
+		sqlddl.ColumnCreatedAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanWebhooks(rows)
+}
+
+func (repo *WebhookRepository) FindActiveByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Webhook, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 AND %s IS NULL"
+	formattedQuery := fmt.Sprintf(
+		query,
+		webhookColumns,
+		repositories.TableWebhooks,
+		repositories.ColumnBoardID,
+		repositories.ColumnDisabledAt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, boardId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	return scanWebhooks(rows)
+}
+
+func (repo *WebhookRepository) RecordFailure(
+	ctx context.Context,
+	id sqlddl.ID,
+	limit int,
+	disabledAt time.Time,
+) (bool, error) {
+	const query = "UPDATE %[1]s SET %[2]s = %[2]s + 1, " +
+		"%[3]s = CASE WHEN %[3]s IS NULL AND %[2]s + 1 >= $1 THEN $2 ELSE %[3]s END " +
+		"WHERE %[4]s = $3 RETURNING %[3]s IS NOT NULL"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableWebhooks,
+		repositories.ColumnFailureCount,
+		repositories.ColumnDisabledAt,
+		sqlddl.ColumnID,
+	)
+	var disabled bool
+	scanErr := sqlquery.Conn(ctx, repo.DB).QueryRowContext(ctx, formattedQuery, limit, disabledAt, id).Scan(&disabled)
+	return disabled, scanErr
+}
+
+func (repo *WebhookRepository) ResetFailures(ctx context.Context, id sqlddl.ID) error {
+	const query = "UPDATE %s SET %s = 0 WHERE %s = $1"
+	formattedQuery := fmt.Sprintf(query, repositories.TableWebhooks, repositories.ColumnFailureCount, sqlddl.ColumnID)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(ctx, formattedQuery, id)
+	return execErr
+}
+
+func (repo *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	const query = "INSERT INTO %s (%s, %s, %s, %s, %s, %s, %s, %s, %s) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+	formattedQuery := fmt.Sprintf(
+		query,
+		repositories.TableWebhookDeliveries,
+		sqlddl.ColumnID,
+		repositories.ColumnWebhookID,
+		repositories.ColumnEventID,
+		repositories.ColumnEventType,
+		repositories.ColumnAttempt,
+		repositories.ColumnStatusCode,
+		repositories.ColumnError,
+		repositories.ColumnSucceeded,
+		repositories.ColumnDuration,
+	)
+	_, execErr := sqlquery.Conn(ctx, repo.DB).ExecContext(
+		ctx,
+		formattedQuery,
+		delivery.ID,
+		delivery.WebhookID,
+		int64(delivery.EventID),
+		delivery.EventType,
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Succeeded,
+		delivery.Duration,
+	)
+	return execErr
+}
+
+func (repo *WebhookRepository) FindDeliveries(
+	ctx context.Context,
+	webhookId sqlddl.ID,
+	limit int,
+) ([]models.WebhookDelivery, error) {
+	const query = "SELECT %s FROM %s WHERE %s = $1 ORDER BY %s DESC, %s DESC LIMIT $2"
+	formattedQuery := fmt.Sprintf(
+		query,
+		webhookDeliveryColumns,
+		repositories.TableWebhookDeliveries,
+		repositories.ColumnWebhookID,
+		sqlddl.ColumnCreatedAt,
+		repositories.ColumnAttempt,
+	)
+	rows, rowsErr := sqlquery.Conn(ctx, repo.DB).QueryContext(ctx, formattedQuery, webhookId, limit)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+	defer rows.Close()
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var eventId int64
+		scanErr := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&eventId,
+			&delivery.EventType,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Succeeded,
+			&delivery.Duration,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		delivery.EventID = uint64(eventId)
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
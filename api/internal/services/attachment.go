@@ -0,0 +1,206 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+const (
+	// sniffLen is count of first file bytes used to detect its content type
+	sniffLen        = 512
+	maxFilenameLen  = 255
+	defaultFilename = "file"
+)
+
+var (
+	ErrorAttachmentTooLarge       = errors.New("attachment exceeds max size")
+	ErrorAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrorAttachmentNotAllowed     = errors.New("only uploader or board manager can delete attachment")
+	attachmentNotExistsErr        = errors.New("attachment does not exist")
+	emptyAttachmentErr            = errors.New("attachment is empty")
+)
+
+// allowedAttachmentTypes are media types detected from content which can be uploaded
+var allowedAttachmentTypes = map[string]bool{
+	"image/png":          true,
+	"image/jpeg":         true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"image/bmp":          true,
+	"application/pdf":    true,
+	"application/zip":    true,
+	"application/x-gzip": true,
+	"text/plain":         true,
+}
+
+type AttachmentService struct {
+	interfaces.AttachmentRepository
+	interfaces.BlobStore
+	*BoardMemberService
+	// MaxSize is max size of single attachment in bytes
+	MaxSize int64
+}
+
+// countingReader counts bytes read through it
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, readErr := cr.Reader.Read(p)
+	cr.count += int64(n)
+	return n, readErr
+}
+
+func NewAttachmentService(
+	repo interfaces.AttachmentRepository,
+	blobStore interfaces.BlobStore,
+	bms *BoardMemberService,
+	maxSize int64,
+) *AttachmentService {
+	return &AttachmentService{repo, blobStore, bms, maxSize}
+}
+
+func (as *AttachmentService) ListTaskAttachments(ctx context.Context, task *models.Task) ([]models.Attachment, error) {
+	attachments, searchErr := as.AttachmentRepository.FindAllByTaskID(ctx, task.ID)
+	return attachments, searchErr
+}
+
+// FindAttachmentByID searches for attachment and checks it's attached to provided task
+func (as *AttachmentService) FindAttachmentByID(
+	ctx context.Context,
+	task *models.Task,
+	attachmentId sqlddl.ID,
+) (*models.Attachment, error) {
+	attachment, searchErr := as.AttachmentRepository.FindByID(ctx, attachmentId)
+	if searchErr != nil || attachment.TaskID != task.ID {
+		return nil, attachmentNotExistsErr
+	}
+	return attachment, nil
+}
+
+// UploadAttachment saves content to blob store and attaches it to task, content type is detected from content
+// instead of trusting client, content larger than MaxSize is rejected
+func (as *AttachmentService) UploadAttachment(
+	ctx context.Context,
+	task *models.Task,
+	filename string,
+	content io.Reader,
+) (*models.Attachment, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	head := make([]byte, sniffLen)
+	headLen, readErr := io.ReadFull(content, head)
+	if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+		return nil, readErr
+	}
+	if headLen == 0 {
+		return nil, emptyAttachmentErr
+	}
+	head = head[:headLen]
+	contentType := http.DetectContentType(head)
+	mediaType, _, parseErr := mime.ParseMediaType(contentType)
+	if parseErr != nil || !allowedAttachmentTypes[mediaType] {
+		return nil, ErrorAttachmentTypeNotAllowed
+	}
+	id := sqlddl.ID(identifier.GenerateUUID())
+	// one byte over limit is read to find out content exceeds it
+	reader := &countingReader{Reader: io.LimitReader(io.MultiReader(bytes.NewReader(head), content), as.MaxSize+1)}
+	if putErr := as.BlobStore.Put(ctx, string(id), reader); putErr != nil {
+		return nil, putErr
+	}
+	if reader.count > as.MaxSize {
+		as.deleteBlob(ctx, id)
+		return nil, ErrorAttachmentTooLarge
+	}
+	creationErr := as.AttachmentRepository.Create(ctx, &models.Attachment{
+		Model:       models.Model{ID: id},
+		TaskID:      task.ID,
+		UploaderID:  &userId,
+		Filename:    sanitizeFilename(filename),
+		Size:        reader.count,
+		ContentType: contentType,
+	})
+	if creationErr != nil {
+		as.deleteBlob(ctx, id)
+		return nil, creationErr
+	}
+	createdAttachment, searchErr := as.AttachmentRepository.FindByID(ctx, id)
+	return createdAttachment, searchErr
+}
+
+// OpenAttachment returns attachment metadata with its content, caller must close content
+func (as *AttachmentService) OpenAttachment(
+	ctx context.Context,
+	task *models.Task,
+	attachmentId sqlddl.ID,
+) (*models.Attachment, io.ReadCloser, error) {
+	attachment, searchErr := as.FindAttachmentByID(ctx, task, attachmentId)
+	if searchErr != nil {
+		return nil, nil, searchErr
+	}
+	content, openErr := as.BlobStore.Get(ctx, string(attachment.ID))
+	if openErr != nil {
+		return nil, nil, openErr
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes attachment of task, allowed for its uploader and members allowed to delete any attachment
+func (as *AttachmentService) DeleteAttachment(ctx context.Context, task *models.Task, attachmentId sqlddl.ID) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	attachment, searchErr := as.FindAttachmentByID(ctx, task, attachmentId)
+	if searchErr != nil {
+		return searchErr
+	}
+	isUploader := attachment.UploaderID != nil && *attachment.UploaderID == userId
+	if !isUploader && !as.Can(ctx, userId, task.BoardID, access.PermissionAttachmentDeleteAny) {
+		return ErrorAttachmentNotAllowed
+	}
+	if deleteErr := as.AttachmentRepository.Delete(ctx, attachmentId); deleteErr != nil {
+		return deleteErr
+	}
+	as.deleteBlob(ctx, attachmentId)
+	return nil
+}
+
+// deleteBlob removes content of attachment, failure only leaves unreachable blob, so it's logged
+func (as *AttachmentService) deleteBlob(ctx context.Context, attachmentId sqlddl.ID) {
+	if deleteErr := as.BlobStore.Delete(ctx, string(attachmentId)); deleteErr != nil {
+		log.Println("Deleting attachment blob failed:", deleteErr)
+	}
+}
+
+// sanitizeFilename drops directories of client file path and limits its length
+func sanitizeFilename(filename string) string {
+	name := strings.TrimSpace(filepath.Base(strings.ReplaceAll(filename, "\\", "/")))
+	if name == "." || name == "/" || name == "" {
+		return defaultFilename
+	}
+	for len(name) > maxFilenameLen {
+		_, lastRuneLen := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-lastRuneLen]
+	}
+	return name
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories/interfaces"
 	"just-kanban/pkg/identifier"
@@ -13,7 +15,26 @@ import (
 type (
 	BoardService struct {
 		interfaces.BoardRepository
+		interfaces.BoardColumnRepository
+		interfaces.BoardMemberRepository
+		interfaces.Transactor
 		*TaskService
+		Paginator *KeysetPaginator
+		Clock
+	}
+
+	// ListBoardsData is requested page of boards listing
+	ListBoardsData struct {
+		// Archived lists archived boards instead of active ones
+		Archived bool `json:"archived"`
+		KeysetPageData
+	}
+
+	// BoardsPage is page of boards listing
+	BoardsPage struct {
+		Boards []models.Board
+		// NextCursor is cursor of the next page, empty for the last page
+		NextCursor string
 	}
 
 	CreateBoardData struct {
@@ -25,25 +46,79 @@ type (
 		Name        string `json:"name" validate:"omitempty,min=3,max=255,trimmed"`
 		Description string `json:"description" validate:"max=1000,trimmed"`
 	}
+
+	CreateBoardColumnData struct {
+		Name   string            `json:"name" validate:"required,min=1,max=100,trimmed"`
+		Status models.TaskStatus `json:"status" validate:"required,oneof=1 2 3"`
+		// WIPLimit is maximum number of column tasks, column isn't limited if it's 0
+		WIPLimit int `json:"wip_limit" validate:"min=0"`
+	}
+
+	UpdateBoardColumnData struct {
+		Name string `json:"name" validate:"omitempty,min=1,max=100,trimmed"`
+		// WIPLimit is new maximum number of column tasks, it's kept if nil and removed if 0
+		WIPLimit *int `json:"wip_limit" validate:"omitempty,min=0"`
+	}
+
+	// ReorderBoardColumnsData contains all board column identifiers in their new order
+	ReorderBoardColumnsData struct {
+		ColumnIDs []sqlddl.ID `json:"column_ids" validate:"required,min=1"`
+	}
 )
 
 var (
-	boardNotExistErr = errors.New("board doesn't exist")
+	boardNotExistErr    = errors.New("board doesn't exist")
+	columnNotEmptyErr   = errors.New("board column contains tasks, move them before deleting")
+	lastColumnErr       = errors.New("board must have at least one column")
+	columnsMismatchErr  = errors.New("column identifiers must match all board columns")
+	boardArchivedErr    = errors.New("board is archived already")
+	boardNotArchivedErr = errors.New("board isn't archived")
 )
 
-func NewBoardService(boardRepo interfaces.BoardRepository, taskService *TaskService) *BoardService {
-	return &BoardService{boardRepo, taskService}
+func NewBoardService(
+	boardRepo interfaces.BoardRepository,
+	columnRepo interfaces.BoardColumnRepository,
+	memberRepo interfaces.BoardMemberRepository,
+	transactor interfaces.Transactor,
+	taskService *TaskService,
+	paginator *KeysetPaginator,
+	clock Clock,
+) *BoardService {
+	return &BoardService{boardRepo, columnRepo, memberRepo, transactor, taskService, paginator, clock}
 }
 
+// CreateBoard creates board with default columns and makes requester its owner, nothing is kept if any step fails
 func (bs *BoardService) CreateBoard(ctx context.Context, d *CreateBoardData) (*models.Board, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
 	id := sqlddl.ID(identifier.GenerateUUID())
-	creationErr := bs.BoardRepository.Create(ctx, &models.Board{
-		Model:       models.Model{ID: id},
-		Name:        d.Name,
-		Description: d.Description,
+	txErr := bs.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		creationErr := bs.BoardRepository.Create(ctx, &models.Board{
+			Model:       models.Model{ID: id},
+			Name:        d.Name,
+			Description: d.Description,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		for _, column := range models.DefaultBoardColumns {
+			column.ID = sqlddl.ID(identifier.GenerateUUID())
+			column.BoardID = id
+			if columnErr := bs.BoardColumnRepository.Create(ctx, &column); columnErr != nil {
+				return columnErr
+			}
+		}
+		return bs.BoardMemberRepository.Create(ctx, &models.BoardMember{
+			Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			UserID:  userId,
+			BoardID: id,
+			Role:    access.RoleOwner,
+		})
 	})
-	if creationErr != nil {
-		return nil, creationErr
+	if txErr != nil {
+		return nil, txErr
 	}
 	newBoard, searchErr := bs.BoardRepository.FindByID(ctx, id)
 	return newBoard, searchErr
@@ -73,18 +148,71 @@ func (bs *BoardService) UpdateBoard(ctx context.Context, id sqlddl.ID, d *Update
 	return updatedBoard, searchErr
 }
 
+// DeleteBoard moves board to trash, board records are kept until board is purged
 func (bs *BoardService) DeleteBoard(ctx context.Context, boardId sqlddl.ID) error {
 	_, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
 	if searchErr != nil {
 		return searchErr
 	}
-	deleteErr := bs.BoardRepository.Delete(ctx, boardId)
+	deletedAt := bs.Now()
+	deleteErr := bs.BoardRepository.SetDeletedAt(ctx, boardId, &deletedAt)
 	if deleteErr != nil {
 		return deleteErr
 	}
 	return nil
 }
 
+// RestoreBoard returns board from trash
+func (bs *BoardService) RestoreBoard(ctx context.Context, boardId sqlddl.ID) (*models.Board, error) {
+	if _, searchErr := bs.BoardRepository.FindDeletedByID(ctx, boardId); searchErr != nil {
+		return nil, ErrorNotInTrash
+	}
+	if restoreErr := bs.BoardRepository.SetDeletedAt(ctx, boardId, nil); restoreErr != nil {
+		return nil, restoreErr
+	}
+	restoredBoard, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
+	return restoredBoard, searchErr
+}
+
+// ArchiveBoard moves board to archive, archived board isn't listed by default
+func (bs *BoardService) ArchiveBoard(ctx context.Context, boardId sqlddl.ID) (*models.Board, error) {
+	board, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
+	if searchErr != nil {
+		return nil, boardNotExistErr
+	}
+	if board.ArchivedAt != nil {
+		return nil, boardArchivedErr
+	}
+	archivedAt := bs.Now()
+	if archiveErr := bs.BoardRepository.SetArchivedAt(ctx, boardId, &archivedAt); archiveErr != nil {
+		return nil, archiveErr
+	}
+	archivedBoard, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
+	return archivedBoard, searchErr
+}
+
+// UnarchiveBoard returns board from archive
+func (bs *BoardService) UnarchiveBoard(ctx context.Context, boardId sqlddl.ID) (*models.Board, error) {
+	board, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
+	if searchErr != nil {
+		return nil, boardNotExistErr
+	}
+	if board.ArchivedAt == nil {
+		return nil, boardNotArchivedErr
+	}
+	if unarchiveErr := bs.BoardRepository.SetArchivedAt(ctx, boardId, nil); unarchiveErr != nil {
+		return nil, unarchiveErr
+	}
+	unarchivedBoard, searchErr := bs.BoardRepository.FindByID(ctx, boardId)
+	return unarchivedBoard, searchErr
+}
+
+// ListDeletedBoards searches for boards moved to trash which user is member of
+func (bs *BoardService) ListDeletedBoards(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
+	return bs.BoardRepository.FindDeletedByUserID(ctx, userId)
+}
+
+// FindAllBoards searches for all boards, it's meant for internal callers only, clients get boards by pages
 func (bs *BoardService) FindAllBoards(ctx context.Context) ([]models.Board, error) {
 	boards, searchErr := bs.BoardRepository.FindAll(ctx)
 	if searchErr != nil {
@@ -92,3 +220,186 @@ func (bs *BoardService) FindAllBoards(ctx context.Context) ([]models.Board, erro
 	}
 	return boards, nil
 }
+
+func (bs *BoardService) ListBoardsPage(ctx context.Context, d *ListBoardsData) (*BoardsPage, error) {
+	after, cursorErr := bs.Paginator.after(&d.KeysetPageData)
+	if cursorErr != nil {
+		return nil, cursorErr
+	}
+	limit := bs.Paginator.limit(&d.KeysetPageData)
+	// one more board is requested to know if the next page exists
+	boards, searchErr := bs.BoardRepository.FindPage(ctx, d.Archived, after, limit+1)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	page := BoardsPage{Boards: boards}
+	if len(boards) > limit {
+		page.Boards = boards[:limit]
+		nextCursor, encodeErr := bs.Paginator.next(&page.Boards[limit-1].Model)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		page.NextCursor = nextCursor
+	}
+	return &page, nil
+}
+
+func (bs *BoardService) ListBoardColumns(ctx context.Context, boardId sqlddl.ID) ([]models.BoardColumn, error) {
+	columns, searchErr := bs.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+	return columns, searchErr
+}
+
+// FindBoardColumnByID searches for column and checks it belongs to provided board
+func (bs *BoardService) FindBoardColumnByID(ctx context.Context, boardId, columnId sqlddl.ID) (*models.BoardColumn, error) {
+	column, searchErr := bs.BoardColumnRepository.FindByID(ctx, columnId)
+	if searchErr != nil || column.BoardID != boardId {
+		return nil, columnNotExistsErr
+	}
+	return column, nil
+}
+
+// CreateBoardColumn adds new column to the end of board workflow
+func (bs *BoardService) CreateBoardColumn(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *CreateBoardColumnData,
+) (*models.BoardColumn, error) {
+	id := sqlddl.ID(identifier.GenerateUUID())
+	txErr := bs.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock keeps concurrently created columns from getting the same order
+		if lockErr := bs.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		columns, searchErr := bs.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+		if searchErr != nil {
+			return searchErr
+		}
+		return bs.BoardColumnRepository.Create(ctx, &models.BoardColumn{
+			Model:    models.Model{ID: id},
+			BoardID:  boardId,
+			Name:     d.Name,
+			Order:    len(columns) + 1,
+			Status:   d.Status,
+			WIPLimit: d.WIPLimit,
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	newColumn, searchErr := bs.BoardColumnRepository.FindByID(ctx, id)
+	return newColumn, searchErr
+}
+
+// UpdateBoardColumn renames column and changes its work in progress limit, only provided fields are changed
+func (bs *BoardService) UpdateBoardColumn(
+	ctx context.Context,
+	boardId,
+	columnId sqlddl.ID,
+	d *UpdateBoardColumnData,
+) (*models.BoardColumn, error) {
+	if _, searchErr := bs.FindBoardColumnByID(ctx, boardId, columnId); searchErr != nil {
+		return nil, searchErr
+	}
+	if d.Name != "" {
+		if renameErr := bs.BoardColumnRepository.Rename(ctx, columnId, d.Name); renameErr != nil {
+			return nil, renameErr
+		}
+	}
+	if d.WIPLimit != nil {
+		if limitErr := bs.BoardColumnRepository.SetWIPLimit(ctx, columnId, *d.WIPLimit); limitErr != nil {
+			return nil, limitErr
+		}
+	}
+	updatedColumn, searchErr := bs.BoardColumnRepository.FindByID(ctx, columnId)
+	return updatedColumn, searchErr
+}
+
+// ReorderBoardColumns changes order of all board columns, provided identifiers must match existing board columns
+func (bs *BoardService) ReorderBoardColumns(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *ReorderBoardColumnsData,
+) ([]models.BoardColumn, error) {
+	txErr := bs.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock keeps columns created or deleted concurrently out of checked set
+		if lockErr := bs.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		columns, searchErr := bs.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+		if searchErr != nil {
+			return searchErr
+		}
+		if len(columns) != len(d.ColumnIDs) {
+			return columnsMismatchErr
+		}
+		boardColumnIds := make(map[sqlddl.ID]bool, len(columns))
+		for _, column := range columns {
+			boardColumnIds[column.ID] = true
+		}
+		for _, columnId := range d.ColumnIDs {
+			if !boardColumnIds[columnId] {
+				return columnsMismatchErr
+			}
+			delete(boardColumnIds, columnId)
+		}
+		return bs.BoardColumnRepository.Reorder(ctx, boardId, d.ColumnIDs)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	reorderedColumns, searchErr := bs.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+	return reorderedColumns, searchErr
+}
+
+// DeleteBoardColumn removes empty column from board, remaining columns are renumbered,
+// archived and moved to trash tasks of column are moved to remaining column of the same status if there is one
+func (bs *BoardService) DeleteBoardColumn(ctx context.Context, boardId, columnId sqlddl.ID) error {
+	return bs.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock keeps tasks from being created in or moved to column while it's emptied and deleted
+		if lockErr := bs.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		if _, searchErr := bs.FindBoardColumnByID(ctx, boardId, columnId); searchErr != nil {
+			return searchErr
+		}
+		columns, searchErr := bs.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+		if searchErr != nil {
+			return searchErr
+		}
+		if len(columns) <= 1 {
+			return lastColumnErr
+		}
+		tasksCount, countErr := bs.TaskService.CountByColumnID(ctx, columnId)
+		if countErr != nil {
+			return countErr
+		}
+		if tasksCount > 0 {
+			return columnNotEmptyErr
+		}
+		var deletedColumn *models.BoardColumn
+		remaining := make([]models.BoardColumn, 0, len(columns)-1)
+		remainingIds := make([]sqlddl.ID, 0, len(columns)-1)
+		for i, column := range columns {
+			if column.ID == columnId {
+				deletedColumn = &columns[i]
+				continue
+			}
+			remaining = append(remaining, column)
+			remainingIds = append(remainingIds, column.ID)
+		}
+		hiddenTasksColumn := &remaining[0]
+		for i, column := range remaining {
+			if column.Status == deletedColumn.Status {
+				hiddenTasksColumn = &remaining[i]
+				break
+			}
+		}
+		if moveErr := bs.TaskService.MoveHiddenTasks(ctx, columnId, hiddenTasksColumn); moveErr != nil {
+			return moveErr
+		}
+		if deleteErr := bs.BoardColumnRepository.Delete(ctx, columnId); deleteErr != nil {
+			return deleteErr
+		}
+		return bs.BoardColumnRepository.Reorder(ctx, boardId, remainingIds)
+	})
+}
@@ -0,0 +1,474 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	// BoardCloneService deep copies boards, it clones boards, saves them as templates
+	// and instantiates new boards from templates
+	BoardCloneService struct {
+		interfaces.BoardRepository
+		interfaces.BoardColumnRepository
+		interfaces.LabelRepository
+		interfaces.BoardRoleRepository
+		interfaces.BoardMemberRepository
+		interfaces.TaskRepository
+		interfaces.ChecklistItemRepository
+		interfaces.TaskDependencyRepository
+		interfaces.Transactor
+	}
+
+	// CloneBoardData names clone of board and chooses which parts of board are copied into it
+	CloneBoardData struct {
+		Name        string `json:"name" validate:"required,min=3,max=255,trimmed"`
+		Description string `json:"description" validate:"max=1000,trimmed"`
+		// Tasks copies tasks with their labels, checklists and dependencies between them,
+		// tasks moved to trash aren't copied
+		Tasks bool `json:"tasks"`
+		// Members copies board members with their roles, requester is the only owner of clone anyway
+		Members bool `json:"members"`
+		// Settings copies columns with their WIP limits, labels and custom roles,
+		// clone starts with default columns otherwise
+		Settings bool `json:"settings"`
+	}
+
+	// SaveTemplateData names template saved from board, template always keeps settings of board
+	SaveTemplateData struct {
+		Name        string `json:"name" validate:"required,min=3,max=255,trimmed"`
+		Description string `json:"description" validate:"max=1000,trimmed"`
+		// Tasks copies tasks of board into template
+		Tasks bool `json:"tasks"`
+	}
+
+	// InstantiateTemplateData names board created from template, board gets settings and tasks of template
+	InstantiateTemplateData struct {
+		Name        string `json:"name" validate:"required,min=3,max=255,trimmed"`
+		Description string `json:"description" validate:"max=1000,trimmed"`
+	}
+
+	// cloneOptions are parts of source board copied into its clone
+	cloneOptions struct {
+		tasks    bool
+		members  bool
+		settings bool
+	}
+
+	// boardCopy maps records of source board to their copies in clone
+	boardCopy struct {
+		sourceId sqlddl.ID
+		cloneId  sqlddl.ID
+		ownerId  sqlddl.ID
+		cloneOptions
+		// columns maps source columns to columns of clone which their tasks are placed into
+		columns map[sqlddl.ID]*models.BoardColumn
+		// sourceColumnOrders are orders of source columns, tasks are copied column by column
+		sourceColumnOrders map[sqlddl.ID]int
+		labelIds           map[sqlddl.ID]sqlddl.ID
+		taskIds            map[sqlddl.ID]sqlddl.ID
+		// memberUserIds are users who are members of clone
+		memberUserIds map[sqlddl.ID]bool
+	}
+)
+
+var ErrorNotTemplate = errors.New("board is not a template")
+
+func NewBoardCloneService(
+	boardRepo interfaces.BoardRepository,
+	columnRepo interfaces.BoardColumnRepository,
+	labelRepo interfaces.LabelRepository,
+	roleRepo interfaces.BoardRoleRepository,
+	memberRepo interfaces.BoardMemberRepository,
+	taskRepo interfaces.TaskRepository,
+	checklistRepo interfaces.ChecklistItemRepository,
+	dependencyRepo interfaces.TaskDependencyRepository,
+	transactor interfaces.Transactor,
+) *BoardCloneService {
+	return &BoardCloneService{
+		boardRepo,
+		columnRepo,
+		labelRepo,
+		roleRepo,
+		memberRepo,
+		taskRepo,
+		checklistRepo,
+		dependencyRepo,
+		transactor,
+	}
+}
+
+// CloneBoard copies board with chosen parts into new board owned by requester
+func (bcs *BoardCloneService) CloneBoard(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *CloneBoardData,
+) (*models.Board, error) {
+	return bcs.cloneBoard(ctx, boardId, &models.Board{
+		Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		Name:        d.Name,
+		Description: d.Description,
+	}, cloneOptions{tasks: d.Tasks, members: d.Members, settings: d.Settings})
+}
+
+// SaveAsTemplate copies settings and optionally tasks of board into new template owned by requester
+func (bcs *BoardCloneService) SaveAsTemplate(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *SaveTemplateData,
+) (*models.Board, error) {
+	return bcs.cloneBoard(ctx, boardId, &models.Board{
+		Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		Name:        d.Name,
+		Description: d.Description,
+		IsTemplate:  true,
+	}, cloneOptions{tasks: d.Tasks, settings: true})
+}
+
+// InstantiateTemplate creates new board owned by requester with settings and tasks of template
+func (bcs *BoardCloneService) InstantiateTemplate(
+	ctx context.Context,
+	templateId sqlddl.ID,
+	d *InstantiateTemplateData,
+) (*models.Board, error) {
+	template, searchErr := bcs.BoardRepository.FindByID(ctx, templateId)
+	if searchErr != nil {
+		return nil, boardNotExistErr
+	}
+	if !template.IsTemplate {
+		return nil, ErrorNotTemplate
+	}
+	return bcs.cloneBoard(ctx, templateId, &models.Board{
+		Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		Name:        d.Name,
+		Description: d.Description,
+	}, cloneOptions{tasks: true, settings: true})
+}
+
+// ListTemplates returns templates which requester is member of
+func (bcs *BoardCloneService) ListTemplates(ctx context.Context) ([]models.Board, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	return bcs.BoardRepository.FindTemplatesByUserID(ctx, userId)
+}
+
+// cloneBoard creates clone and copies parts of source board into it within single transaction,
+// all copied records get fresh identifiers while tasks keep their orders,
+// comments, attachments and history of tasks aren't copied
+func (bcs *BoardCloneService) cloneBoard(
+	ctx context.Context,
+	sourceId sqlddl.ID,
+	clone *models.Board,
+	opts cloneOptions,
+) (*models.Board, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	if _, searchErr := bcs.BoardRepository.FindByID(ctx, sourceId); searchErr != nil {
+		return nil, boardNotExistErr
+	}
+	bc := &boardCopy{
+		sourceId:           sourceId,
+		cloneId:            clone.ID,
+		ownerId:            userId,
+		cloneOptions:       opts,
+		columns:            make(map[sqlddl.ID]*models.BoardColumn),
+		sourceColumnOrders: make(map[sqlddl.ID]int),
+		labelIds:           make(map[sqlddl.ID]sqlddl.ID),
+		taskIds:            make(map[sqlddl.ID]sqlddl.ID),
+		memberUserIds:      map[sqlddl.ID]bool{userId: true},
+	}
+	txErr := bcs.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock keeps tasks of source board in place while they're copied
+		if lockErr := bcs.BoardColumnRepository.Lock(ctx, sourceId); lockErr != nil {
+			return lockErr
+		}
+		if creationErr := bcs.BoardRepository.Create(ctx, clone); creationErr != nil {
+			return creationErr
+		}
+		if columnsErr := bcs.cloneColumns(ctx, bc); columnsErr != nil {
+			return columnsErr
+		}
+		if bc.settings {
+			if labelsErr := bcs.cloneLabels(ctx, bc); labelsErr != nil {
+				return labelsErr
+			}
+			if rolesErr := bcs.cloneRoles(ctx, bc); rolesErr != nil {
+				return rolesErr
+			}
+		}
+		if membersErr := bcs.cloneMembers(ctx, bc); membersErr != nil {
+			return membersErr
+		}
+		if !bc.tasks {
+			return nil
+		}
+		return bcs.cloneTasks(ctx, bc)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	createdClone, searchErr := bcs.BoardRepository.FindByID(ctx, clone.ID)
+	return createdClone, searchErr
+}
+
+// cloneColumns copies columns of source board, clone gets default columns if settings aren't copied
+// and tasks of source column are placed into default column of the same status
+func (bcs *BoardCloneService) cloneColumns(ctx context.Context, bc *boardCopy) error {
+	sourceColumns, searchErr := bcs.BoardColumnRepository.FindAllByBoardID(ctx, bc.sourceId)
+	if searchErr != nil {
+		return searchErr
+	}
+	columnsByStatus := make(map[models.TaskStatus]*models.BoardColumn)
+	if !bc.settings {
+		for _, column := range models.DefaultBoardColumns {
+			column.ID = sqlddl.ID(identifier.GenerateUUID())
+			column.BoardID = bc.cloneId
+			if creationErr := bcs.BoardColumnRepository.Create(ctx, &column); creationErr != nil {
+				return creationErr
+			}
+			columnsByStatus[column.Status] = &column
+		}
+	}
+	for _, sourceColumn := range sourceColumns {
+		bc.sourceColumnOrders[sourceColumn.ID] = sourceColumn.Order
+		if !bc.settings {
+			bc.columns[sourceColumn.ID] = columnsByStatus[sourceColumn.Status]
+			continue
+		}
+		column := &models.BoardColumn{
+			Model:    models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:  bc.cloneId,
+			Name:     sourceColumn.Name,
+			Order:    sourceColumn.Order,
+			Status:   sourceColumn.Status,
+			WIPLimit: sourceColumn.WIPLimit,
+		}
+		if creationErr := bcs.BoardColumnRepository.Create(ctx, column); creationErr != nil {
+			return creationErr
+		}
+		bc.columns[sourceColumn.ID] = column
+	}
+	return nil
+}
+
+func (bcs *BoardCloneService) cloneLabels(ctx context.Context, bc *boardCopy) error {
+	sourceLabels, searchErr := bcs.LabelRepository.FindAllByBoardID(ctx, bc.sourceId)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, sourceLabel := range sourceLabels {
+		label := &models.Label{
+			Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID: bc.cloneId,
+			Name:    sourceLabel.Name,
+			Color:   sourceLabel.Color,
+		}
+		if creationErr := bcs.LabelRepository.Create(ctx, label); creationErr != nil {
+			return creationErr
+		}
+		bc.labelIds[sourceLabel.ID] = label.ID
+	}
+	return nil
+}
+
+func (bcs *BoardCloneService) cloneRoles(ctx context.Context, bc *boardCopy) error {
+	sourceRoles, searchErr := bcs.BoardRoleRepository.FindAllByBoardID(ctx, bc.sourceId)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, sourceRole := range sourceRoles {
+		creationErr := bcs.BoardRoleRepository.Create(ctx, &models.BoardRole{
+			Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:     bc.cloneId,
+			Name:        sourceRole.Name,
+			Permissions: sourceRole.Permissions,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+	}
+	return nil
+}
+
+// cloneMembers makes requester the owner of clone and copies members of source board if it's chosen,
+// owners of source board become managers and custom roles fall back to regular one if settings aren't copied
+func (bcs *BoardCloneService) cloneMembers(ctx context.Context, bc *boardCopy) error {
+	creationErr := bcs.BoardMemberRepository.Create(ctx, &models.BoardMember{
+		Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		UserID:  bc.ownerId,
+		BoardID: bc.cloneId,
+		Role:    access.RoleOwner,
+	})
+	if creationErr != nil || !bc.members {
+		return creationErr
+	}
+	sourceMembers, searchErr := bcs.BoardMemberRepository.FindBoardMembers(ctx, bc.sourceId)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, sourceMember := range sourceMembers {
+		if sourceMember.UserID == bc.ownerId {
+			continue
+		}
+		role := sourceMember.Role
+		if role == access.RoleOwner {
+			role = access.RoleManager
+		} else if !bc.settings && !role.IsBuiltIn() {
+			role = access.RoleRegular
+		}
+		creationErr := bcs.BoardMemberRepository.Create(ctx, &models.BoardMember{
+			Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			UserID:  sourceMember.UserID,
+			BoardID: bc.cloneId,
+			Role:    role,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		bc.memberUserIds[sourceMember.UserID] = true
+	}
+	return nil
+}
+
+// cloneTasks copies tasks of source board which aren't moved to trash, tasks keep their orders
+// and are placed into columns of clone in the same sequence they have on source board
+func (bcs *BoardCloneService) cloneTasks(ctx context.Context, bc *boardCopy) error {
+	boardTasks, searchErr := bcs.TaskRepository.FindAllByBoardId(ctx, bc.sourceId)
+	if searchErr != nil {
+		return searchErr
+	}
+	sourceTasks := make([]models.Task, 0, len(boardTasks))
+	sourceTaskIds := make([]sqlddl.ID, 0, len(boardTasks))
+	for _, task := range boardTasks {
+		if task.DeletedAt == nil {
+			sourceTasks = append(sourceTasks, task)
+			sourceTaskIds = append(sourceTaskIds, task.ID)
+		}
+	}
+	sort.SliceStable(sourceTasks, func(i, j int) bool {
+		iOrder, jOrder := bc.sourceColumnOrders[sourceTasks[i].ColumnID], bc.sourceColumnOrders[sourceTasks[j].ColumnID]
+		if iOrder != jOrder {
+			return iOrder < jOrder
+		}
+		return sourceTasks[i].Position < sourceTasks[j].Position
+	})
+	taskLabels, labelsErr := bcs.LabelRepository.FindAllByTaskIDs(ctx, sourceTaskIds)
+	if labelsErr != nil {
+		return labelsErr
+	}
+	columnTasksCounts := make(map[sqlddl.ID]int)
+	for _, sourceTask := range sourceTasks {
+		column := bc.columns[sourceTask.ColumnID]
+		position := 0
+		if sourceTask.ArchivedAt == nil {
+			columnTasksCounts[column.ID]++
+			position = columnTasksCounts[column.ID]
+		}
+		task := &models.Task{
+			Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:     bc.cloneId,
+			CreatorID:   bc.memberOrOwner(sourceTask.CreatorID),
+			AssigneeID:  bc.memberOrOwner(sourceTask.AssigneeID),
+			Order:       sourceTask.Order,
+			Position:    position,
+			Name:        sourceTask.Name,
+			Description: sourceTask.Description,
+			ColumnID:    column.ID,
+			Status:      column.Status,
+			StartAt:     sourceTask.StartAt,
+			DueAt:       sourceTask.DueAt,
+		}
+		if creationErr := bcs.TaskRepository.Create(ctx, task); creationErr != nil {
+			return creationErr
+		}
+		if sourceTask.ArchivedAt != nil {
+			if archiveErr := bcs.TaskRepository.SetArchivedAt(ctx, task.ID, sourceTask.ArchivedAt); archiveErr != nil {
+				return archiveErr
+			}
+		}
+		bc.taskIds[sourceTask.ID] = task.ID
+		if bc.settings {
+			labelIds := make([]sqlddl.ID, 0, len(taskLabels[sourceTask.ID]))
+			for _, label := range taskLabels[sourceTask.ID] {
+				labelIds = append(labelIds, bc.labelIds[label.ID])
+			}
+			if labelsErr := bcs.LabelRepository.ReplaceTaskLabels(ctx, task.ID, labelIds); labelsErr != nil {
+				return labelsErr
+			}
+		}
+		if checklistErr := bcs.cloneChecklist(ctx, bc, sourceTask.ID, task.ID); checklistErr != nil {
+			return checklistErr
+		}
+	}
+	return bcs.cloneDependencies(ctx, bc, sourceTaskIds)
+}
+
+func (bcs *BoardCloneService) cloneChecklist(ctx context.Context, bc *boardCopy, sourceTaskId, taskId sqlddl.ID) error {
+	sourceItems, searchErr := bcs.ChecklistItemRepository.FindAllByTaskID(ctx, sourceTaskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, sourceItem := range sourceItems {
+		var assigneeId *sqlddl.ID
+		if sourceItem.AssigneeID != nil && bc.memberUserIds[*sourceItem.AssigneeID] {
+			assigneeId = sourceItem.AssigneeID
+		}
+		creationErr := bcs.ChecklistItemRepository.Create(ctx, &models.ChecklistItem{
+			Model:      models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			TaskID:     taskId,
+			Text:       sourceItem.Text,
+			Done:       sourceItem.Done,
+			AssigneeID: assigneeId,
+			Order:      sourceItem.Order,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+	}
+	return nil
+}
+
+// cloneDependencies copies dependencies between copied tasks, links to tasks of other boards aren't copied
+func (bcs *BoardCloneService) cloneDependencies(ctx context.Context, bc *boardCopy, sourceTaskIds []sqlddl.ID) error {
+	blockers, searchErr := bcs.TaskDependencyRepository.FindBlockers(ctx, sourceTaskIds)
+	if searchErr != nil {
+		return searchErr
+	}
+	for blockedId, blockedBy := range blockers {
+		for _, blocker := range blockedBy {
+			blockerId, ok := bc.taskIds[blocker.ID]
+			if !ok {
+				continue
+			}
+			creationErr := bcs.TaskDependencyRepository.Create(ctx, &models.TaskDependency{
+				Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+				BlockerID: blockerId,
+				BlockedID: bc.taskIds[blockedId],
+			})
+			if creationErr != nil {
+				return creationErr
+			}
+		}
+	}
+	return nil
+}
+
+// memberOrOwner keeps user who is member of clone, otherwise replaces them with owner of clone
+func (bc *boardCopy) memberOrOwner(userId sqlddl.ID) sqlddl.ID {
+	if bc.memberUserIds[userId] {
+		return userId
+	}
+	return bc.ownerId
+}
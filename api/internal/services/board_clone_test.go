@@ -0,0 +1,287 @@
+package services_test
+
+import (
+	"go.uber.org/mock/gomock"
+
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/mocks"
+	"just-kanban/pkg/sqlddl"
+)
+
+// cloneRecorder keeps records created by services.BoardCloneService
+type cloneRecorder struct {
+	boards       []models.Board
+	columns      []models.BoardColumn
+	labels       []models.Label
+	members      []models.BoardMember
+	tasks        []models.Task
+	taskLabels   map[sqlddl.ID][]sqlddl.ID
+	items        []models.ChecklistItem
+	dependencies []models.TaskDependency
+}
+
+// cloneSource is content of board being cloned
+type cloneSource struct {
+	board      models.Board
+	columns    []models.BoardColumn
+	labels     []models.Label
+	members    []models.BoardMember
+	tasks      []models.Task
+	taskLabels map[sqlddl.ID][]models.Label
+	items      map[sqlddl.ID][]models.ChecklistItem
+	blockers   map[sqlddl.ID][]models.TaskReference
+}
+
+// newCloneService creates service cloning source board and recording created records
+func newCloneService(ctrl *gomock.Controller, source *cloneSource) (*services.BoardCloneService, *cloneRecorder) {
+	recorder := &cloneRecorder{taskLabels: make(map[sqlddl.ID][]sqlddl.ID)}
+	boardRepo := mocks.NewMockBoardRepository(ctrl)
+	boardRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, id sqlddl.ID) (*models.Board, error) {
+			if id == source.board.ID {
+				return &source.board, nil
+			}
+			for _, board := range recorder.boards {
+				if board.ID == id {
+					return &board, nil
+				}
+			}
+			return nil, errors.New("not found")
+		},
+	).AnyTimes()
+	boardRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, board *models.Board) error {
+		recorder.boards = append(recorder.boards, *board)
+		return nil
+	}).AnyTimes()
+	columnRepo := mocks.NewMockBoardColumnRepository(ctrl)
+	columnRepo.EXPECT().Lock(gomock.Any(), source.board.ID).Return(nil).AnyTimes()
+	columnRepo.EXPECT().FindAllByBoardID(gomock.Any(), source.board.ID).Return(source.columns, nil).AnyTimes()
+	columnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, column *models.BoardColumn) error {
+			recorder.columns = append(recorder.columns, *column)
+			return nil
+		},
+	).AnyTimes()
+	labelRepo := mocks.NewMockLabelRepository(ctrl)
+	labelRepo.EXPECT().FindAllByBoardID(gomock.Any(), source.board.ID).Return(source.labels, nil).AnyTimes()
+	labelRepo.EXPECT().FindAllByTaskIDs(gomock.Any(), gomock.Any()).Return(source.taskLabels, nil).AnyTimes()
+	labelRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, label *models.Label) error {
+		recorder.labels = append(recorder.labels, *label)
+		return nil
+	}).AnyTimes()
+	labelRepo.EXPECT().ReplaceTaskLabels(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, taskId sqlddl.ID, labelIds []sqlddl.ID) error {
+			recorder.taskLabels[taskId] = labelIds
+			return nil
+		},
+	).AnyTimes()
+	roleRepo := mocks.NewMockBoardRoleRepository(ctrl)
+	roleRepo.EXPECT().FindAllByBoardID(gomock.Any(), source.board.ID).Return(nil, nil).AnyTimes()
+	memberRepo := mocks.NewMockBoardMemberRepository(ctrl)
+	memberRepo.EXPECT().FindBoardMembers(gomock.Any(), source.board.ID).Return(source.members, nil).AnyTimes()
+	memberRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, member *models.BoardMember) error {
+			recorder.members = append(recorder.members, *member)
+			return nil
+		},
+	).AnyTimes()
+	taskRepo := mocks.NewMockTaskRepository(ctrl)
+	taskRepo.EXPECT().FindAllByBoardId(gomock.Any(), source.board.ID).Return(source.tasks, nil).AnyTimes()
+	taskRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, task *models.Task) error {
+		recorder.tasks = append(recorder.tasks, *task)
+		return nil
+	}).AnyTimes()
+	checklistRepo := mocks.NewMockChecklistItemRepository(ctrl)
+	checklistRepo.EXPECT().FindAllByTaskID(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, taskId sqlddl.ID) ([]models.ChecklistItem, error) {
+			return source.items[taskId], nil
+		},
+	).AnyTimes()
+	checklistRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, item *models.ChecklistItem) error {
+			recorder.items = append(recorder.items, *item)
+			return nil
+		},
+	).AnyTimes()
+	dependencyRepo := mocks.NewMockTaskDependencyRepository(ctrl)
+	dependencyRepo.EXPECT().FindBlockers(gomock.Any(), gomock.Any()).Return(source.blockers, nil).AnyTimes()
+	dependencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, dependency *models.TaskDependency) error {
+			recorder.dependencies = append(recorder.dependencies, *dependency)
+			return nil
+		},
+	).AnyTimes()
+	transactor := mocks.NewMockTransactor(ctrl)
+	transactor.EXPECT().WithinTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	).AnyTimes()
+	return services.NewBoardCloneService(
+		boardRepo,
+		columnRepo,
+		labelRepo,
+		roleRepo,
+		memberRepo,
+		taskRepo,
+		checklistRepo,
+		dependencyRepo,
+		transactor,
+	), recorder
+}
+
+func TestBoardCloneService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.WithValue(context.Background(), contextkeys.KeyUserId, sqlddl.ID("cloner"))
+	other := sqlddl.ID("other")
+	deletedAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Board is deeply cloned with fresh identifiers and kept task orders", func(t *testing.T) {
+		source := &cloneSource{
+			board: models.Board{Model: models.Model{ID: "source"}, Name: "Source"},
+			columns: []models.BoardColumn{
+				{Model: models.Model{ID: "todo"}, Name: "Todo", Order: 1, Status: models.TaskStatusBacklog, WIPLimit: 2},
+				{Model: models.Model{ID: "done"}, Name: "Done", Order: 2, Status: models.TaskStatusDone},
+			},
+			labels: []models.Label{{Model: models.Model{ID: "bug"}, Name: "Bug", Color: "#ff0000"}},
+			members: []models.BoardMember{
+				{UserID: other, Role: access.RoleOwner},
+				{UserID: "cloner", Role: access.RoleRegular},
+			},
+			tasks: []models.Task{
+				{Model: models.Model{ID: "fix"}, Order: 5, ColumnID: "done", Position: 1, CreatorID: other, AssigneeID: other},
+				{Model: models.Model{ID: "plan"}, Order: 2, ColumnID: "todo", Position: 1, CreatorID: "cloner"},
+				{Model: models.Model{ID: "trashed"}, Order: 3, ColumnID: "todo", DeletedAt: &deletedAt},
+			},
+			taskLabels: map[sqlddl.ID][]models.Label{"fix": {{Model: models.Model{ID: "bug"}}}},
+			items: map[sqlddl.ID][]models.ChecklistItem{
+				"fix": {{Model: models.Model{ID: "step"}, Text: "Reproduce", Order: 1, AssigneeID: &other}},
+			},
+			blockers: map[sqlddl.ID][]models.TaskReference{"fix": {{ID: "plan"}, {ID: "foreign"}}},
+		}
+		service, recorder := newCloneService(ctrl, source)
+		clone, err := service.CloneBoard(ctx, "source", &services.CloneBoardData{
+			Name:     "Clone",
+			Tasks:    true,
+			Members:  true,
+			Settings: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clone.ID == source.board.ID || clone.Name != "Clone" || clone.IsTemplate {
+			t.Fatalf("got clone %+v", clone)
+		}
+		columnIds := make(map[string]sqlddl.ID)
+		for _, column := range recorder.columns {
+			if column.ID == "todo" || column.ID == "done" || column.BoardID != clone.ID {
+				t.Fatalf("column %+v isn't fresh copy", column)
+			}
+			columnIds[column.Name] = column.ID
+		}
+		if len(recorder.columns) != 2 || recorder.columns[0].WIPLimit != 2 {
+			t.Fatalf("got columns %+v", recorder.columns)
+		}
+		roles := make(map[sqlddl.ID]access.Role)
+		for _, member := range recorder.members {
+			roles[member.UserID] = member.Role
+		}
+		if roles["cloner"] != access.RoleOwner || roles[other] != access.RoleManager || len(recorder.members) != 2 {
+			t.Fatalf("got members %+v", recorder.members)
+		}
+		if len(recorder.tasks) != 2 {
+			t.Fatalf("got %d tasks, expected 2", len(recorder.tasks))
+		}
+		plan, fix := recorder.tasks[0], recorder.tasks[1]
+		if plan.Order != 2 || plan.ColumnID != columnIds["Todo"] || plan.ID == "plan" {
+			t.Fatalf("got task %+v", plan)
+		}
+		if fix.Order != 5 || fix.ColumnID != columnIds["Done"] || fix.AssigneeID != other || fix.Position != 1 {
+			t.Fatalf("got task %+v", fix)
+		}
+		if labels := recorder.taskLabels[fix.ID]; len(labels) != 1 || labels[0] != recorder.labels[0].ID {
+			t.Fatalf("got labels %v of task", labels)
+		}
+		if len(recorder.items) != 1 || recorder.items[0].TaskID != fix.ID || recorder.items[0].ID == "step" {
+			t.Fatalf("got checklist items %+v", recorder.items)
+		}
+		expectedDependency := models.TaskDependency{BlockerID: plan.ID, BlockedID: fix.ID}
+		if len(recorder.dependencies) != 1 ||
+			recorder.dependencies[0].BlockerID != expectedDependency.BlockerID ||
+			recorder.dependencies[0].BlockedID != expectedDependency.BlockedID {
+			t.Fatalf("got dependencies %+v", recorder.dependencies)
+		}
+	})
+
+	t.Run("Clone without settings and members gets default columns", func(t *testing.T) {
+		source := &cloneSource{
+			board: models.Board{Model: models.Model{ID: "source"}, Name: "Source"},
+			columns: []models.BoardColumn{
+				{Model: models.Model{ID: "review"}, Name: "Review", Order: 1, Status: models.TaskStatusProcess},
+				{Model: models.Model{ID: "coding"}, Name: "Coding", Order: 2, Status: models.TaskStatusProcess},
+			},
+			members: []models.BoardMember{{UserID: other, Role: access.RoleOwner}},
+			tasks: []models.Task{
+				{Model: models.Model{ID: "later"}, Order: 1, ColumnID: "coding", Position: 1, CreatorID: other},
+				{Model: models.Model{ID: "first"}, Order: 2, ColumnID: "review", Position: 1, CreatorID: other},
+			},
+			items: map[sqlddl.ID][]models.ChecklistItem{
+				"first": {{Model: models.Model{ID: "step"}, Text: "Check", Order: 1, AssigneeID: &other}},
+			},
+		}
+		service, recorder := newCloneService(ctrl, source)
+		_, err := service.CloneBoard(ctx, "source", &services.CloneBoardData{Name: "Clone", Tasks: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(recorder.columns) != len(models.DefaultBoardColumns) || len(recorder.labels) != 0 {
+			t.Fatalf("got columns %+v and labels %+v", recorder.columns, recorder.labels)
+		}
+		var processColumnId sqlddl.ID
+		for _, column := range recorder.columns {
+			if column.Status == models.TaskStatusProcess {
+				processColumnId = column.ID
+			}
+		}
+		if len(recorder.members) != 1 || recorder.members[0].UserID != "cloner" {
+			t.Fatalf("got members %+v", recorder.members)
+		}
+		first, later := recorder.tasks[0], recorder.tasks[1]
+		if first.Order != 2 || first.Position != 1 || later.Position != 2 {
+			t.Fatalf("got tasks %+v", recorder.tasks)
+		}
+		for _, task := range recorder.tasks {
+			if task.ColumnID != processColumnId || task.CreatorID != "cloner" || task.AssigneeID != "cloner" {
+				t.Fatalf("got task %+v", task)
+			}
+		}
+		if recorder.items[0].AssigneeID != nil {
+			t.Fatalf("checklist item is assigned to %s who isn't member", *recorder.items[0].AssigneeID)
+		}
+	})
+
+	t.Run("Board is saved as template and only template is instantiated", func(t *testing.T) {
+		source := &cloneSource{board: models.Board{Model: models.Model{ID: "source"}, Name: "Source"}}
+		service, recorder := newCloneService(ctrl, source)
+		template, err := service.SaveAsTemplate(ctx, "source", &services.SaveTemplateData{Name: "Template"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !template.IsTemplate || len(recorder.tasks) != 0 {
+			t.Fatalf("got template %+v with tasks %+v", template, recorder.tasks)
+		}
+		_, err = service.InstantiateTemplate(ctx, "source", &services.InstantiateTemplateData{Name: "Board"})
+		if !errors.Is(err, services.ErrorNotTemplate) {
+			t.Fatalf("got %v, expected %v", err, services.ErrorNotTemplate)
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+const (
+	// DefaultBoardEventBufferSize is count of the latest events of each board kept for resuming subscribers
+	DefaultBoardEventBufferSize = 256
+	// DefaultBoardEventIdleTimeout is how long events of board are kept after its last subscriber is gone
+	DefaultBoardEventIdleTimeout = 5 * time.Minute
+	// subscriptionQueueSize is count of events waiting for delivery to subscriber, slower subscribers are dropped
+	subscriptionQueueSize = 64
+)
+
+type (
+	// BoardEventPublisher delivers events of board changes to their listeners, publishing must not block
+	BoardEventPublisher interface {
+		PublishBoardEvent(event *models.BoardEvent)
+	}
+
+	// BoardEventPublishers is BoardEventPublisher which passes events to every publisher in order,
+	// so publisher numbering events goes first
+	BoardEventPublishers []BoardEventPublisher
+
+	// BoardEventHub fans out events of boards to their subscribers and keeps the latest events of watched boards,
+	// so subscribers may resume after reconnect. Subscribers which don't keep up with events are dropped
+	BoardEventHub struct {
+		Clock
+		// BufferSize is count of the latest events kept for each board
+		BufferSize int
+		// IdleTimeout is how long events of board are kept after its last subscriber is gone
+		IdleTimeout time.Duration
+		mu          sync.Mutex
+		rooms       map[sqlddl.ID]*boardEventRoom
+		lastId      uint64
+		closed      bool
+		cancel      context.CancelFunc
+		done        chan struct{}
+	}
+
+	// boardEventRoom is subscribers of board along with the latest events of board
+	boardEventRoom struct {
+		subscriptions map[*BoardEventSubscription]bool
+		events        []models.BoardEvent
+		// sinceId is the last event identifier published before room was opened, later events of board are known
+		sinceId uint64
+		// trimmedId is identifier of the latest event pushed out of buffer
+		trimmedId uint64
+		// idleSince is time when the last subscriber left room, zero while room has subscribers
+		idleSince time.Time
+	}
+
+	// BoardEventSubscription receives events of board until it's closed by subscriber or dropped by hub
+	BoardEventSubscription struct {
+		hub     *BoardEventHub
+		boardId sqlddl.ID
+		events  chan models.BoardEvent
+	}
+)
+
+// NewBoardEventHub creates new instance of BoardEventHub
+func NewBoardEventHub(clock Clock, bufferSize int, idleTimeout time.Duration) *BoardEventHub {
+	return &BoardEventHub{
+		Clock:       clock,
+		BufferSize:  bufferSize,
+		IdleTimeout: idleTimeout,
+		rooms:       make(map[sqlddl.ID]*boardEventRoom),
+		// identifiers start from current time, so identifiers known to clients before restart aren't reused
+		lastId: uint64(clock.Now().UnixNano()),
+	}
+}
+
+// newBoardEvent creates event of change made by requester
+func newBoardEvent(
+	ctx context.Context,
+	eventType models.BoardEventType,
+	boardId sqlddl.ID,
+	data any,
+) *models.BoardEvent {
+	actorId, _ := contextkeys.GetUserId(ctx)
+	return &models.BoardEvent{Type: eventType, BoardID: boardId, ActorID: actorId, Data: data}
+}
+
+func (bep BoardEventPublishers) PublishBoardEvent(event *models.BoardEvent) {
+	for _, publisher := range bep {
+		publisher.PublishBoardEvent(event)
+	}
+}
+
+// PublishBoardEvent numbers event and delivers it to subscribers of its board, events of boards nobody watches
+// are dropped. Subscribers whose queues are full are dropped instead of waiting for them
+func (beh *BoardEventHub) PublishBoardEvent(event *models.BoardEvent) {
+	beh.mu.Lock()
+	defer beh.mu.Unlock()
+	if beh.closed {
+		return
+	}
+	beh.lastId++
+	event.ID = beh.lastId
+	event.OccurredAt = beh.Now()
+	room, ok := beh.rooms[event.BoardID]
+	if !ok {
+		return
+	}
+	room.events = append(room.events, *event)
+	if overflow := len(room.events) - beh.BufferSize; overflow > 0 {
+		room.trimmedId = room.events[overflow-1].ID
+		room.events = room.events[overflow:]
+	}
+	for subscription := range room.subscriptions {
+		select {
+		case subscription.events <- *event:
+		default:
+			beh.drop(room, subscription)
+		}
+	}
+}
+
+// Subscribe starts delivery of board events. If lastEventId isn't nil, buffered events published after it
+// are returned for replay, complete is false if some of them aren't known and client has to reload board
+func (beh *BoardEventHub) Subscribe(
+	boardId sqlddl.ID,
+	lastEventId *uint64,
+) (subscription *BoardEventSubscription, missed []models.BoardEvent, complete bool) {
+	beh.mu.Lock()
+	defer beh.mu.Unlock()
+	subscription = &BoardEventSubscription{
+		hub:     beh,
+		boardId: boardId,
+		events:  make(chan models.BoardEvent, subscriptionQueueSize),
+	}
+	if beh.closed {
+		close(subscription.events)
+		return subscription, nil, lastEventId == nil
+	}
+	room, ok := beh.rooms[boardId]
+	if !ok {
+		room = &boardEventRoom{subscriptions: make(map[*BoardEventSubscription]bool), sinceId: beh.lastId}
+		beh.rooms[boardId] = room
+	}
+	room.subscriptions[subscription] = true
+	room.idleSince = time.Time{}
+	if lastEventId == nil {
+		return subscription, nil, true
+	}
+	known := *lastEventId >= room.sinceId && *lastEventId >= room.trimmedId && *lastEventId <= beh.lastId
+	for _, event := range room.events {
+		if event.ID > *lastEventId {
+			missed = append(missed, event)
+		}
+	}
+	return subscription, missed, known
+}
+
+// drop removes subscription from room and closes its channel, must be called under lock
+func (beh *BoardEventHub) drop(room *boardEventRoom, subscription *BoardEventSubscription) {
+	delete(room.subscriptions, subscription)
+	close(subscription.events)
+	if len(room.subscriptions) == 0 {
+		room.idleSince = beh.Now()
+	}
+}
+
+// PruneIdle forgets events of boards which have had no subscribers for longer than IdleTimeout
+func (beh *BoardEventHub) PruneIdle() {
+	beh.mu.Lock()
+	defer beh.mu.Unlock()
+	now := beh.Now()
+	for boardId, room := range beh.rooms {
+		if len(room.subscriptions) == 0 && now.Sub(room.idleSince) > beh.IdleTimeout {
+			delete(beh.rooms, boardId)
+		}
+	}
+}
+
+// Start runs goroutine which prunes idle boards every IdleTimeout until Stop is called or ctx is done
+func (beh *BoardEventHub) Start(ctx context.Context) {
+	ctx, beh.cancel = context.WithCancel(ctx)
+	beh.done = make(chan struct{})
+	go func() {
+		defer close(beh.done)
+		ticker := time.NewTicker(beh.IdleTimeout)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				beh.PruneIdle()
+			}
+		}
+	}()
+}
+
+// Stop stops pruning goroutine and waits for it
+func (beh *BoardEventHub) Stop() {
+	if beh.cancel == nil {
+		return
+	}
+	beh.cancel()
+	<-beh.done
+}
+
+// Close drops all subscriptions and stops delivery of events, it's called on shutdown to end open streams
+func (beh *BoardEventHub) Close() {
+	beh.mu.Lock()
+	defer beh.mu.Unlock()
+	for _, room := range beh.rooms {
+		for subscription := range room.subscriptions {
+			beh.drop(room, subscription)
+		}
+	}
+	beh.rooms = make(map[sqlddl.ID]*boardEventRoom)
+	beh.closed = true
+}
+
+// Events returns channel of board events, it's closed when subscription is closed or dropped by hub
+func (sub *BoardEventSubscription) Events() <-chan models.BoardEvent {
+	return sub.events
+}
+
+// Close stops delivery of events, it may be called after subscription is dropped by hub
+func (sub *BoardEventSubscription) Close() {
+	sub.hub.mu.Lock()
+	defer sub.hub.mu.Unlock()
+	if room, ok := sub.hub.rooms[sub.boardId]; ok && room.subscriptions[sub] {
+		sub.hub.drop(room, sub)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// movingClock is clock which is moved forward by tests
+type movingClock struct {
+	now time.Time
+}
+
+func (mc *movingClock) Now() time.Time {
+	return mc.now
+}
+
+func publishTaskEvents(hub *BoardEventHub, boardId sqlddl.ID, count int) []models.BoardEvent {
+	events := make([]models.BoardEvent, 0, count)
+	for i := 0; i < count; i++ {
+		event := &models.BoardEvent{Type: models.BoardEventTaskUpdated, BoardID: boardId}
+		hub.PublishBoardEvent(event)
+		events = append(events, *event)
+	}
+	return events
+}
+
+func TestBoardEventHub(t *testing.T) {
+	clock := &movingClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("Events are delivered to subscribers of their board", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout)
+		first, _, _ := hub.Subscribe("first", nil)
+		defer first.Close()
+		second, _, _ := hub.Subscribe("second", nil)
+		defer second.Close()
+		published := publishTaskEvents(hub, "first", 2)
+		for _, expected := range published {
+			if event := <-first.Events(); event.ID != expected.ID || event.OccurredAt != clock.now {
+				t.Fatalf("got event %+v, expected %+v", event, expected)
+			}
+		}
+		if published[1].ID != published[0].ID+1 {
+			t.Fatalf("got identifiers %d and %d", published[0].ID, published[1].ID)
+		}
+		select {
+		case event := <-second.Events():
+			t.Fatalf("got event %+v of another board", event)
+		default:
+		}
+	})
+
+	t.Run("Subscriber resumes from buffered events", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, 2, DefaultBoardEventIdleTimeout)
+		watcher, _, _ := hub.Subscribe("board", nil)
+		published := publishTaskEvents(hub, "board", 3)
+		watcher.Close()
+
+		resumed, missed, complete := hub.Subscribe("board", &published[0].ID)
+		defer resumed.Close()
+		if !complete || len(missed) != 2 || missed[0].ID != published[1].ID || missed[1].ID != published[2].ID {
+			t.Fatalf("got missed %+v, complete %t", missed, complete)
+		}
+		beforeTrimmed := published[0].ID - 1
+		_, missed, complete = hub.Subscribe("board", &beforeTrimmed)
+		if complete || len(missed) != 2 {
+			t.Fatalf("got missed %+v, complete %t for trimmed events", missed, complete)
+		}
+		unknown := published[2].ID + 10
+		if _, _, complete = hub.Subscribe("board", &unknown); complete {
+			t.Fatal("unknown identifier is treated as complete")
+		}
+	})
+
+	t.Run("Events published before board is watched aren't known", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout)
+		published := publishTaskEvents(hub, "board", 1)
+		subscription, missed, complete := hub.Subscribe("board", &published[0].ID)
+		defer subscription.Close()
+		if !complete || len(missed) != 0 {
+			t.Fatalf("got missed %+v, complete %t", missed, complete)
+		}
+		before := published[0].ID - 1
+		if _, _, complete = hub.Subscribe("board", &before); complete {
+			t.Fatal("event published before board was watched is treated as known")
+		}
+	})
+
+	t.Run("Slow subscriber is dropped", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout)
+		slow, _, _ := hub.Subscribe("board", nil)
+		fast, _, _ := hub.Subscribe("board", nil)
+		defer fast.Close()
+		for i := 0; i < subscriptionQueueSize+1; i++ {
+			publishTaskEvents(hub, "board", 1)
+			<-fast.Events()
+		}
+		received := 0
+		for range slow.Events() {
+			received++
+		}
+		if received != subscriptionQueueSize {
+			t.Fatalf("got %d events, expected %d", received, subscriptionQueueSize)
+		}
+		slow.Close()
+		publishTaskEvents(hub, "board", 1)
+		if _, ok := <-fast.Events(); !ok {
+			t.Fatal("fast subscriber is dropped")
+		}
+	})
+
+	t.Run("Idle boards are pruned", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, DefaultBoardEventBufferSize, time.Minute)
+		subscription, _, _ := hub.Subscribe("board", nil)
+		published := publishTaskEvents(hub, "board", 1)
+		subscription.Close()
+		hub.PruneIdle()
+		if _, ok := hub.rooms["board"]; !ok {
+			t.Fatal("board is pruned before idle timeout")
+		}
+		clock.now = clock.now.Add(2 * time.Minute)
+		hub.PruneIdle()
+		beforePublished := published[0].ID - 1
+		resumed, _, complete := hub.Subscribe("board", &beforePublished)
+		defer resumed.Close()
+		if complete {
+			t.Fatal("events of pruned board are treated as known")
+		}
+	})
+
+	t.Run("Closed hub ends subscriptions", func(t *testing.T) {
+		hub := NewBoardEventHub(clock, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout)
+		subscription, _, _ := hub.Subscribe("board", nil)
+		hub.Close()
+		if _, ok := <-subscription.Events(); ok {
+			t.Fatal("subscription is open after hub is closed")
+		}
+		subscription.Close()
+		late, _, _ := hub.Subscribe("board", nil)
+		if _, ok := <-late.Events(); ok {
+			t.Fatal("subscription to closed hub is open")
+		}
+	})
+}
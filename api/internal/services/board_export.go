@@ -0,0 +1,692 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	// BoardExportService exports boards into versioned documents and imports boards from them,
+	// it uses repositories of BoardCloneService for copying board records
+	BoardExportService struct {
+		*BoardCloneService
+		interfaces.CommentRepository
+		UserService
+		Clock
+	}
+
+	// ImportReport is board imported from document along with document references which weren't resolved,
+	// records referencing them are imported without them
+	ImportReport struct {
+		Board      *models.Board         `json:"board"`
+		Unresolved []UnresolvedReference `json:"unresolved"`
+	}
+
+	// UnresolvedReference is reference of imported document which isn't found on board or in app
+	UnresolvedReference struct {
+		// Kind is kind of referenced record, one of user, member, role, column, label, task or comment
+		Kind string `json:"kind"`
+		// Reference is value of reference, e.g. email of user or order of task
+		Reference string `json:"reference"`
+		// Usage describes what references record and how it's imported instead
+		Usage string `json:"usage"`
+		// TaskOrder is order of task containing reference, 0 for references of board itself
+		TaskOrder int `json:"task_order,omitempty"`
+	}
+
+	// boardImport keeps state of board being imported from document
+	boardImport struct {
+		doc        *models.BoardExport
+		boardId    sqlddl.ID
+		importerId sqlddl.ID
+		// users caches resolved user references, empty identifier marks unknown user
+		users         map[models.UserReference]sqlddl.ID
+		memberUserIds map[sqlddl.ID]bool
+		// columns maps orders of document columns to created columns
+		columns     map[int]*models.BoardColumn
+		firstColumn *models.BoardColumn
+		labelIds    map[string]sqlddl.ID
+		customRole  map[access.Role]bool
+		// taskIds maps orders of document tasks to identifiers of created tasks
+		taskIds    map[int]sqlddl.ID
+		unresolved []UnresolvedReference
+	}
+)
+
+const (
+	referenceUser    = "user"
+	referenceMember  = "member"
+	referenceRole    = "role"
+	referenceColumn  = "column"
+	referenceLabel   = "label"
+	referenceTask    = "task"
+	referenceComment = "comment"
+)
+
+var (
+	ErrorIncompatibleExportVersion = fmt.Errorf(
+		"document format version is incompatible, only version %d is supported",
+		models.BoardExportVersion,
+	)
+	ErrorInvalidExportedRole = errors.New("exported custom role is invalid")
+)
+
+func NewBoardExportService(
+	bcs *BoardCloneService,
+	commentRepo interfaces.CommentRepository,
+	userService UserService,
+	clock Clock,
+) *BoardExportService {
+	return &BoardExportService{bcs, commentRepo, userService, clock}
+}
+
+// CheckExportVersion checks document of provided format version may be imported
+func CheckExportVersion(version int) error {
+	if version != models.BoardExportVersion {
+		return ErrorIncompatibleExportVersion
+	}
+	return nil
+}
+
+// ExportBoard builds document with board, its settings, members and tasks with their checklists,
+// dependencies between them and comments, tasks moved to trash and attachments aren't exported
+func (bes *BoardExportService) ExportBoard(ctx context.Context, boardId sqlddl.ID) (*models.BoardExport, error) {
+	board, searchErr := bes.BoardRepository.FindByID(ctx, boardId)
+	if searchErr != nil {
+		return nil, boardNotExistErr
+	}
+	users := make(map[sqlddl.ID]models.UserReference)
+	doc := &models.BoardExport{
+		Version:    models.BoardExportVersion,
+		ExportedAt: bes.Now(),
+		Board:      models.ExportedBoard{Name: board.Name, Description: board.Description},
+		Columns:    make([]models.ExportedColumn, 0),
+		Labels:     make([]models.ExportedLabel, 0),
+		Roles:      make([]models.ExportedRole, 0),
+		Members:    make([]models.ExportedMember, 0),
+		Tasks:      make([]models.ExportedTask, 0),
+	}
+	columns, columnsErr := bes.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+	if columnsErr != nil {
+		return nil, columnsErr
+	}
+	columnOrders := make(map[sqlddl.ID]int)
+	for _, column := range columns {
+		columnOrders[column.ID] = column.Order
+		doc.Columns = append(doc.Columns, models.ExportedColumn{
+			Name:     column.Name,
+			Order:    column.Order,
+			Status:   column.Status,
+			WIPLimit: column.WIPLimit,
+		})
+	}
+	labels, labelsErr := bes.LabelRepository.FindAllByBoardID(ctx, boardId)
+	if labelsErr != nil {
+		return nil, labelsErr
+	}
+	for _, label := range labels {
+		doc.Labels = append(doc.Labels, models.ExportedLabel{Name: label.Name, Color: label.Color})
+	}
+	roles, rolesErr := bes.BoardRoleRepository.FindAllByBoardID(ctx, boardId)
+	if rolesErr != nil {
+		return nil, rolesErr
+	}
+	for _, role := range roles {
+		doc.Roles = append(doc.Roles, models.ExportedRole{Name: role.Name, Permissions: role.Permissions})
+	}
+	members, membersErr := bes.BoardMemberRepository.FindBoardMembers(ctx, boardId)
+	if membersErr != nil {
+		return nil, membersErr
+	}
+	for _, member := range members {
+		user, userErr := bes.userReference(ctx, users, member.UserID)
+		if userErr != nil {
+			return nil, userErr
+		}
+		doc.Members = append(doc.Members, models.ExportedMember{User: user, Role: member.Role})
+	}
+	tasksErr := bes.exportTasks(ctx, doc, boardId, columnOrders, users)
+	if tasksErr != nil {
+		return nil, tasksErr
+	}
+	return doc, nil
+}
+
+// exportTasks adds tasks of board which aren't moved to trash into document, the first created first
+func (bes *BoardExportService) exportTasks(
+	ctx context.Context,
+	doc *models.BoardExport,
+	boardId sqlddl.ID,
+	columnOrders map[sqlddl.ID]int,
+	users map[sqlddl.ID]models.UserReference,
+) error {
+	boardTasks, searchErr := bes.TaskRepository.FindAllByBoardId(ctx, boardId)
+	if searchErr != nil {
+		return searchErr
+	}
+	tasks := make([]models.Task, 0, len(boardTasks))
+	taskIds := make([]sqlddl.ID, 0, len(boardTasks))
+	taskOrders := make(map[sqlddl.ID]int)
+	for _, task := range boardTasks {
+		if task.DeletedAt == nil {
+			tasks = append(tasks, task)
+			taskIds = append(taskIds, task.ID)
+			taskOrders[task.ID] = task.Order
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Order < tasks[j].Order
+	})
+	taskLabels, labelsErr := bes.LabelRepository.FindAllByTaskIDs(ctx, taskIds)
+	if labelsErr != nil {
+		return labelsErr
+	}
+	blockers, blockersErr := bes.TaskDependencyRepository.FindBlockers(ctx, taskIds)
+	if blockersErr != nil {
+		return blockersErr
+	}
+	for _, task := range tasks {
+		creator, creatorErr := bes.userReference(ctx, users, task.CreatorID)
+		if creatorErr != nil {
+			return creatorErr
+		}
+		assignee, assigneeErr := bes.userReference(ctx, users, task.AssigneeID)
+		if assigneeErr != nil {
+			return assigneeErr
+		}
+		exportedTask := models.ExportedTask{
+			Order:       task.Order,
+			Name:        task.Name,
+			Description: task.Description,
+			Column:      columnOrders[task.ColumnID],
+			Position:    task.Position,
+			Creator:     creator,
+			Assignee:    assignee,
+			StartAt:     task.StartAt,
+			DueAt:       task.DueAt,
+			ArchivedAt:  task.ArchivedAt,
+			Labels:      make([]string, 0, len(taskLabels[task.ID])),
+			Checklist:   make([]models.ExportedChecklistItem, 0),
+			BlockedBy:   make([]int, 0),
+			Comments:    make([]models.ExportedComment, 0),
+		}
+		for _, label := range taskLabels[task.ID] {
+			exportedTask.Labels = append(exportedTask.Labels, label.Name)
+		}
+		for _, blocker := range blockers[task.ID] {
+			if blockerOrder, ok := taskOrders[blocker.ID]; ok {
+				exportedTask.BlockedBy = append(exportedTask.BlockedBy, blockerOrder)
+			}
+		}
+		items, itemsErr := bes.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+		if itemsErr != nil {
+			return itemsErr
+		}
+		for _, item := range items {
+			exportedItem := models.ExportedChecklistItem{Text: item.Text, Done: item.Done, Order: item.Order}
+			if item.AssigneeID != nil {
+				itemAssignee, itemAssigneeErr := bes.userReference(ctx, users, *item.AssigneeID)
+				if itemAssigneeErr != nil {
+					return itemAssigneeErr
+				}
+				exportedItem.Assignee = &itemAssignee
+			}
+			exportedTask.Checklist = append(exportedTask.Checklist, exportedItem)
+		}
+		if commentsErr := bes.exportComments(ctx, &exportedTask, task.ID, users); commentsErr != nil {
+			return commentsErr
+		}
+		doc.Tasks = append(doc.Tasks, exportedTask)
+	}
+	return nil
+}
+
+// exportComments adds comments of task into exported task, comments are numbered in order they were written
+func (bes *BoardExportService) exportComments(
+	ctx context.Context,
+	exportedTask *models.ExportedTask,
+	taskId sqlddl.ID,
+	users map[sqlddl.ID]models.UserReference,
+) error {
+	comments, searchErr := bes.CommentRepository.FindAllByTaskID(ctx, taskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	numbers := make(map[sqlddl.ID]int)
+	for i, comment := range comments {
+		author, authorErr := bes.userReference(ctx, users, comment.AuthorID)
+		if authorErr != nil {
+			return authorErr
+		}
+		numbers[comment.ID] = i + 1
+		exportedComment := models.ExportedComment{
+			Number:    i + 1,
+			Author:    author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt,
+		}
+		if comment.ParentID != nil {
+			if parentNumber, ok := numbers[*comment.ParentID]; ok {
+				exportedComment.ReplyTo = &parentNumber
+			}
+		}
+		exportedTask.Comments = append(exportedTask.Comments, exportedComment)
+	}
+	return nil
+}
+
+// userReference searches for user and caches reference to them
+func (bes *BoardExportService) userReference(
+	ctx context.Context,
+	users map[sqlddl.ID]models.UserReference,
+	userId sqlddl.ID,
+) (models.UserReference, error) {
+	if reference, ok := users[userId]; ok {
+		return reference, nil
+	}
+	user, searchErr := bes.UserService.FindByID(ctx, userId)
+	if searchErr != nil {
+		return models.UserReference{}, searchErr
+	}
+	reference := models.UserReference{Username: user.Username, Email: user.Email}
+	users[userId] = reference
+	return reference, nil
+}
+
+// ImportBoard creates new board owned by requester from document within single transaction,
+// users are remapped by their email or username, owners of exported board become managers
+// and records referencing users who aren't board members are imported on behalf of requester
+func (bes *BoardExportService) ImportBoard(ctx context.Context, doc *models.BoardExport) (*ImportReport, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	if versionErr := CheckExportVersion(doc.Version); versionErr != nil {
+		return nil, versionErr
+	}
+	for _, role := range doc.Roles {
+		if role.Name.IsBuiltIn() {
+			return nil, fmt.Errorf("%w: %s is built-in role", ErrorInvalidExportedRole, role.Name)
+		}
+		if permissionsErr := checkPermissions(role.Permissions); permissionsErr != nil {
+			return nil, fmt.Errorf("%w: %w", ErrorInvalidExportedRole, permissionsErr)
+		}
+	}
+	bi := &boardImport{
+		doc:           doc,
+		boardId:       sqlddl.ID(identifier.GenerateUUID()),
+		importerId:    userId,
+		users:         make(map[models.UserReference]sqlddl.ID),
+		memberUserIds: map[sqlddl.ID]bool{userId: true},
+		columns:       make(map[int]*models.BoardColumn),
+		labelIds:      make(map[string]sqlddl.ID),
+		customRole:    make(map[access.Role]bool),
+		taskIds:       make(map[int]sqlddl.ID),
+		unresolved:    make([]UnresolvedReference, 0),
+	}
+	txErr := bes.WithinTransaction(ctx, func(ctx context.Context) error {
+		creationErr := bes.BoardRepository.Create(ctx, &models.Board{
+			Model:       models.Model{ID: bi.boardId},
+			Name:        doc.Board.Name,
+			Description: doc.Board.Description,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		if settingsErr := bes.importSettings(ctx, bi); settingsErr != nil {
+			return settingsErr
+		}
+		if membersErr := bes.importMembers(ctx, bi); membersErr != nil {
+			return membersErr
+		}
+		return bes.importTasks(ctx, bi)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	board, searchErr := bes.BoardRepository.FindByID(ctx, bi.boardId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	return &ImportReport{Board: board, Unresolved: bi.unresolved}, nil
+}
+
+// importSettings creates columns, labels and custom roles of document, columns are renumbered from 1
+func (bes *BoardExportService) importSettings(ctx context.Context, bi *boardImport) error {
+	columns := append([]models.ExportedColumn(nil), bi.doc.Columns...)
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Order < columns[j].Order
+	})
+	for i, exportedColumn := range columns {
+		column := &models.BoardColumn{
+			Model:    models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:  bi.boardId,
+			Name:     exportedColumn.Name,
+			Order:    i + 1,
+			Status:   exportedColumn.Status,
+			WIPLimit: exportedColumn.WIPLimit,
+		}
+		if creationErr := bes.BoardColumnRepository.Create(ctx, column); creationErr != nil {
+			return creationErr
+		}
+		bi.columns[exportedColumn.Order] = column
+		if i == 0 {
+			bi.firstColumn = column
+		}
+	}
+	for _, exportedLabel := range bi.doc.Labels {
+		label := &models.Label{
+			Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID: bi.boardId,
+			Name:    exportedLabel.Name,
+			Color:   exportedLabel.Color,
+		}
+		if creationErr := bes.LabelRepository.Create(ctx, label); creationErr != nil {
+			return creationErr
+		}
+		bi.labelIds[label.Name] = label.ID
+	}
+	for _, exportedRole := range bi.doc.Roles {
+		creationErr := bes.BoardRoleRepository.Create(ctx, &models.BoardRole{
+			Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:     bi.boardId,
+			Name:        exportedRole.Name,
+			Permissions: exportedRole.Permissions,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		bi.customRole[exportedRole.Name] = true
+	}
+	return nil
+}
+
+// importMembers makes requester the owner of board and adds exported members found in app
+func (bes *BoardExportService) importMembers(ctx context.Context, bi *boardImport) error {
+	creationErr := bes.BoardMemberRepository.Create(ctx, &models.BoardMember{
+		Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		UserID:  bi.importerId,
+		BoardID: bi.boardId,
+		Role:    access.RoleOwner,
+	})
+	if creationErr != nil {
+		return creationErr
+	}
+	for _, exportedMember := range bi.doc.Members {
+		userId := bes.resolveUser(ctx, bi, exportedMember.User)
+		if userId == "" {
+			bi.report(referenceUser, exportedMember.User.String(), "member is skipped", 0)
+			continue
+		}
+		if bi.memberUserIds[userId] {
+			continue
+		}
+		role := exportedMember.Role
+		if role == access.RoleOwner {
+			role = access.RoleManager
+		} else if !role.IsBuiltIn() && !bi.customRole[role] {
+			bi.report(referenceRole, string(role), "member gets regular role", 0)
+			role = access.RoleRegular
+		}
+		creationErr := bes.BoardMemberRepository.Create(ctx, &models.BoardMember{
+			Model:   models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			UserID:  userId,
+			BoardID: bi.boardId,
+			Role:    role,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		bi.memberUserIds[userId] = true
+	}
+	return nil
+}
+
+// importTasks creates tasks of document with their checklists and comments, then links them by dependencies,
+// tasks keep their orders and are placed into columns in sequence of their exported positions
+func (bes *BoardExportService) importTasks(ctx context.Context, bi *boardImport) error {
+	tasks := append([]models.ExportedTask(nil), bi.doc.Tasks...)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Column != tasks[j].Column {
+			return tasks[i].Column < tasks[j].Column
+		}
+		return tasks[i].Position < tasks[j].Position
+	})
+	columnTasksCounts := make(map[sqlddl.ID]int)
+	for _, exportedTask := range tasks {
+		column, ok := bi.columns[exportedTask.Column]
+		if !ok {
+			bi.report(
+				referenceColumn,
+				strconv.Itoa(exportedTask.Column),
+				"task is placed into first column",
+				exportedTask.Order,
+			)
+			column = bi.firstColumn
+		}
+		position := 0
+		if exportedTask.ArchivedAt == nil {
+			columnTasksCounts[column.ID]++
+			position = columnTasksCounts[column.ID]
+		}
+		task := &models.Task{
+			Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			BoardID:     bi.boardId,
+			CreatorID:   bes.resolveMember(ctx, bi, exportedTask.Creator, "task creator", exportedTask.Order),
+			AssigneeID:  bes.resolveMember(ctx, bi, exportedTask.Assignee, "task assignee", exportedTask.Order),
+			Order:       exportedTask.Order,
+			Position:    position,
+			Name:        exportedTask.Name,
+			Description: exportedTask.Description,
+			ColumnID:    column.ID,
+			Status:      column.Status,
+			StartAt:     exportedTask.StartAt,
+			DueAt:       exportedTask.DueAt,
+		}
+		if creationErr := bes.TaskRepository.Create(ctx, task); creationErr != nil {
+			return creationErr
+		}
+		if exportedTask.ArchivedAt != nil {
+			if archiveErr := bes.TaskRepository.SetArchivedAt(ctx, task.ID, exportedTask.ArchivedAt); archiveErr != nil {
+				return archiveErr
+			}
+		}
+		bi.taskIds[exportedTask.Order] = task.ID
+		labelIds := make([]sqlddl.ID, 0, len(exportedTask.Labels))
+		for _, labelName := range exportedTask.Labels {
+			labelId, ok := bi.labelIds[labelName]
+			if !ok {
+				bi.report(referenceLabel, labelName, "label isn't attached to task", exportedTask.Order)
+				continue
+			}
+			labelIds = append(labelIds, labelId)
+		}
+		if labelsErr := bes.LabelRepository.ReplaceTaskLabels(ctx, task.ID, labelIds); labelsErr != nil {
+			return labelsErr
+		}
+		if checklistErr := bes.importChecklist(ctx, bi, &exportedTask, task.ID); checklistErr != nil {
+			return checklistErr
+		}
+		if commentsErr := bes.importComments(ctx, bi, &exportedTask, task.ID); commentsErr != nil {
+			return commentsErr
+		}
+	}
+	return bes.importDependencies(ctx, bi)
+}
+
+func (bes *BoardExportService) importChecklist(
+	ctx context.Context,
+	bi *boardImport,
+	exportedTask *models.ExportedTask,
+	taskId sqlddl.ID,
+) error {
+	for _, exportedItem := range exportedTask.Checklist {
+		var assigneeId *sqlddl.ID
+		if exportedItem.Assignee != nil {
+			userId := bes.resolveUser(ctx, bi, *exportedItem.Assignee)
+			if bi.memberUserIds[userId] {
+				assigneeId = &userId
+			} else {
+				bi.report(
+					bi.missingUserKind(userId),
+					exportedItem.Assignee.String(),
+					"checklist item is unassigned",
+					exportedTask.Order,
+				)
+			}
+		}
+		creationErr := bes.ChecklistItemRepository.Create(ctx, &models.ChecklistItem{
+			Model:      models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			TaskID:     taskId,
+			Text:       exportedItem.Text,
+			Done:       exportedItem.Done,
+			AssigneeID: assigneeId,
+			Order:      exportedItem.Order,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+	}
+	return nil
+}
+
+// importComments creates comments of task, authors who aren't found are replaced with requester
+// while former board members remain authors of their comments
+func (bes *BoardExportService) importComments(
+	ctx context.Context,
+	bi *boardImport,
+	exportedTask *models.ExportedTask,
+	taskId sqlddl.ID,
+) error {
+	comments := append([]models.ExportedComment(nil), exportedTask.Comments...)
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].Number < comments[j].Number
+	})
+	commentIds := make(map[int]sqlddl.ID)
+	for _, exportedComment := range comments {
+		authorId := bes.resolveUser(ctx, bi, exportedComment.Author)
+		if authorId == "" {
+			bi.report(
+				referenceUser,
+				exportedComment.Author.String(),
+				"comment is written on behalf of importer",
+				exportedTask.Order,
+			)
+			authorId = bi.importerId
+		}
+		var parentId *sqlddl.ID
+		if exportedComment.ReplyTo != nil {
+			if replyToId, ok := commentIds[*exportedComment.ReplyTo]; ok {
+				parentId = &replyToId
+			} else {
+				bi.report(
+					referenceComment,
+					strconv.Itoa(*exportedComment.ReplyTo),
+					"reply becomes top level comment",
+					exportedTask.Order,
+				)
+			}
+		}
+		comment := &models.Comment{
+			Model:    models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			TaskID:   taskId,
+			AuthorID: authorId,
+			ParentID: parentId,
+			Body:     exportedComment.Body,
+		}
+		if creationErr := bes.CommentRepository.Create(ctx, comment); creationErr != nil {
+			return creationErr
+		}
+		commentIds[exportedComment.Number] = comment.ID
+	}
+	return nil
+}
+
+func (bes *BoardExportService) importDependencies(ctx context.Context, bi *boardImport) error {
+	for _, exportedTask := range bi.doc.Tasks {
+		for _, blockerOrder := range exportedTask.BlockedBy {
+			blockerId, ok := bi.taskIds[blockerOrder]
+			if !ok || blockerOrder == exportedTask.Order {
+				bi.report(referenceTask, strconv.Itoa(blockerOrder), "dependency is skipped", exportedTask.Order)
+				continue
+			}
+			creationErr := bes.TaskDependencyRepository.Create(ctx, &models.TaskDependency{
+				Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+				BlockerID: blockerId,
+				BlockedID: bi.taskIds[exportedTask.Order],
+			})
+			if creationErr != nil {
+				return creationErr
+			}
+		}
+	}
+	return nil
+}
+
+// resolveMember returns user who is member of imported board or requester if user isn't found or isn't member
+func (bes *BoardExportService) resolveMember(
+	ctx context.Context,
+	bi *boardImport,
+	reference models.UserReference,
+	usage string,
+	taskOrder int,
+) sqlddl.ID {
+	userId := bes.resolveUser(ctx, bi, reference)
+	if bi.memberUserIds[userId] {
+		return userId
+	}
+	bi.report(bi.missingUserKind(userId), reference.String(), usage+" is replaced with importer", taskOrder)
+	return bi.importerId
+}
+
+// resolveUser searches for referenced user by email and then by username, empty identifier is returned
+// if user isn't found
+func (bes *BoardExportService) resolveUser(
+	ctx context.Context,
+	bi *boardImport,
+	reference models.UserReference,
+) sqlddl.ID {
+	if userId, ok := bi.users[reference]; ok {
+		return userId
+	}
+	var userId sqlddl.ID
+	if reference.Email != "" {
+		if user, searchErr := bes.UserService.FindByEmail(ctx, reference.Email); searchErr == nil {
+			userId = user.ID
+		}
+	}
+	if userId == "" && reference.Username != "" {
+		if user, searchErr := bes.UserService.FindByUsername(ctx, reference.Username); searchErr == nil {
+			userId = user.ID
+		}
+	}
+	bi.users[reference] = userId
+	return userId
+}
+
+// missingUserKind tells whether user isn't found in app at all or just isn't member of imported board
+func (bi *boardImport) missingUserKind(userId sqlddl.ID) string {
+	if userId == "" {
+		return referenceUser
+	}
+	return referenceMember
+}
+
+func (bi *boardImport) report(kind, reference, usage string, taskOrder int) {
+	bi.unresolved = append(bi.unresolved, UnresolvedReference{
+		Kind:      kind,
+		Reference: reference,
+		Usage:     usage,
+		TaskOrder: taskOrder,
+	})
+}
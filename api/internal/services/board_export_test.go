@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"go.uber.org/mock/gomock"
+
+	"context"
+	"errors"
+	"testing"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/mocks"
+	"just-kanban/pkg/sqlddl"
+)
+
+func TestBoardExportServiceImport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.WithValue(context.Background(), contextkeys.KeyUserId, sqlddl.ID("importer"))
+	known := models.UserReference{Username: "known", Email: "known@example.com"}
+	ghost := models.UserReference{Username: "ghost"}
+
+	newExportService := func() (*services.BoardExportService, *cloneRecorder, *[]models.Comment) {
+		cloneService, recorder := newCloneService(ctrl, &cloneSource{})
+		userService := mocks.NewMockUserService(ctrl)
+		userService.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, email string) (*models.User, error) {
+				if email == known.Email {
+					return &models.User{Model: models.Model{ID: "known"}, Email: email}, nil
+				}
+				return nil, errors.New("not found")
+			},
+		).AnyTimes()
+		userService.EXPECT().FindByUsername(gomock.Any(), gomock.Any()).Return(nil, errors.New("not found")).AnyTimes()
+		var comments []models.Comment
+		commentRepo := mocks.NewMockCommentRepository(ctrl)
+		commentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, comment *models.Comment) error {
+				comments = append(comments, *comment)
+				return nil
+			},
+		).AnyTimes()
+		return services.NewBoardExportService(cloneService, commentRepo, userService, fixedClock{}), recorder, &comments
+	}
+
+	t.Run("Document of another version is rejected", func(t *testing.T) {
+		service, recorder, _ := newExportService()
+		_, err := service.ImportBoard(ctx, &models.BoardExport{Version: models.BoardExportVersion + 1})
+		if !errors.Is(err, services.ErrorIncompatibleExportVersion) {
+			t.Fatalf("got %v, expected %v", err, services.ErrorIncompatibleExportVersion)
+		}
+		if len(recorder.boards) != 0 {
+			t.Fatal("board is created")
+		}
+	})
+
+	t.Run("Built-in role can't be imported as custom one", func(t *testing.T) {
+		service, _, _ := newExportService()
+		_, err := service.ImportBoard(ctx, &models.BoardExport{
+			Version: models.BoardExportVersion,
+			Roles:   []models.ExportedRole{{Name: access.RoleOwner}},
+		})
+		if !errors.Is(err, services.ErrorInvalidExportedRole) {
+			t.Fatalf("got %v, expected %v", err, services.ErrorInvalidExportedRole)
+		}
+	})
+
+	t.Run("Users are remapped and unresolved references are reported", func(t *testing.T) {
+		service, recorder, comments := newExportService()
+		replyTo := 1
+		report, err := service.ImportBoard(ctx, &models.BoardExport{
+			Version: models.BoardExportVersion,
+			Board:   models.ExportedBoard{Name: "Imported"},
+			Columns: []models.ExportedColumn{{Name: "Todo", Order: 3, Status: models.TaskStatusBacklog}},
+			Labels:  []models.ExportedLabel{{Name: "Bug", Color: "#ff0000"}},
+			Members: []models.ExportedMember{
+				{User: known, Role: access.RoleOwner},
+				{User: ghost, Role: access.RoleRegular},
+			},
+			Tasks: []models.ExportedTask{
+				{
+					Order:     7,
+					Name:      "Fix",
+					Column:    3,
+					Position:  1,
+					Creator:   ghost,
+					Assignee:  known,
+					Labels:    []string{"Bug", "Missing"},
+					BlockedBy: []int{9, 8},
+				},
+				{
+					Order:    9,
+					Name:     "Plan",
+					Column:   5,
+					Position: 1,
+					Creator:  known,
+					Assignee: known,
+					Comments: []models.ExportedComment{
+						{Number: 1, Author: ghost, Body: "Who?"},
+						{Number: 2, ReplyTo: &replyTo, Author: known, Body: "Me"},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if report.Board == nil || report.Board.Name != "Imported" {
+			t.Fatalf("got board %+v", report.Board)
+		}
+		roles := make(map[sqlddl.ID]access.Role)
+		for _, member := range recorder.members {
+			roles[member.UserID] = member.Role
+		}
+		if len(roles) != 2 || roles["importer"] != access.RoleOwner || roles["known"] != access.RoleManager {
+			t.Fatalf("got members %+v", recorder.members)
+		}
+		if len(recorder.columns) != 1 || recorder.columns[0].Order != 1 {
+			t.Fatalf("got columns %+v", recorder.columns)
+		}
+		fix, plan := recorder.tasks[0], recorder.tasks[1]
+		if fix.Order != 7 || fix.CreatorID != "importer" || fix.AssigneeID != "known" {
+			t.Fatalf("got task %+v", fix)
+		}
+		if plan.Order != 9 || plan.ColumnID != recorder.columns[0].ID || plan.Position != 2 {
+			t.Fatalf("got task %+v", plan)
+		}
+		if labels := recorder.taskLabels[fix.ID]; len(labels) != 1 || labels[0] != recorder.labels[0].ID {
+			t.Fatalf("got labels %v", labels)
+		}
+		if len(recorder.dependencies) != 1 || recorder.dependencies[0].BlockerID != plan.ID {
+			t.Fatalf("got dependencies %+v", recorder.dependencies)
+		}
+		if len(*comments) != 2 || (*comments)[0].AuthorID != "importer" || *(*comments)[1].ParentID != (*comments)[0].ID {
+			t.Fatalf("got comments %+v", *comments)
+		}
+		expectedUnresolved := []services.UnresolvedReference{
+			{Kind: "user", Reference: "ghost", Usage: "member is skipped"},
+			{Kind: "user", Reference: "ghost", Usage: "task creator is replaced with importer", TaskOrder: 7},
+			{Kind: "label", Reference: "Missing", Usage: "label isn't attached to task", TaskOrder: 7},
+			{Kind: "column", Reference: "5", Usage: "task is placed into first column", TaskOrder: 9},
+			{Kind: "user", Reference: "ghost", Usage: "comment is written on behalf of importer", TaskOrder: 9},
+			{Kind: "task", Reference: "8", Usage: "dependency is skipped", TaskOrder: 7},
+		}
+		if len(report.Unresolved) != len(expectedUnresolved) {
+			t.Fatalf("got unresolved %+v", report.Unresolved)
+		}
+		for i, reference := range expectedUnresolved {
+			if report.Unresolved[i] != reference {
+				t.Fatalf("got unresolved %+v, expected %+v", report.Unresolved[i], reference)
+			}
+		}
+	})
+}
@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
 	"just-kanban/internal/models"
 	"just-kanban/internal/repositories/interfaces"
 	"just-kanban/pkg/identifier"
@@ -14,37 +16,79 @@ import (
 type (
 	BoardMemberService struct {
 		interfaces.BoardMemberRepository
+		interfaces.Transactor
 		*BoardService
+		*BoardRoleService
 		UserService
+		BoardEventPublisher
 	}
 	CreateBoardMemberData struct {
-		UserId sqlddl.ID   `json:"user_id" validate:"required"`
-		Role   access.Role `json:"role" validate:"required,oneof=owner manager regular"`
+		UserId sqlddl.ID `json:"user_id" validate:"required"`
+		// Role is built-in or custom role of board
+		Role access.Role `json:"role" validate:"required,max=100"`
 	}
 	UpdateBoardMemberData struct {
-		Role access.Role `json:"role" validate:"required,oneof=owner manager regular"`
+		// Role is built-in or custom role of board
+		Role access.Role `json:"role" validate:"required,max=100"`
+	}
+	// TransferOwnershipData is member who becomes board owner instead of requester
+	TransferOwnershipData struct {
+		MemberID sqlddl.ID `json:"member_id" validate:"required"`
 	}
 )
 
 var (
-	memberAlreadyExistsErr = errors.New("member already exists")
-	noMemberExistsErr      = errors.New("member does not exist")
+	ErrorMemberAlreadyExists   = errors.New("member already exists")
+	ErrorLastOwner             = errors.New("board must keep at least one owner")
+	ErrorOwnershipTransferOnly = errors.New("owner role is given by ownership transfer only")
+	ErrorNotBoardOwner         = errors.New("only board owner can transfer ownership")
+	ErrorOwnerChangeForbidden  = errors.New("only board owner can change role or membership of another owner")
+	ErrorRoleNotGrantable      = errors.New("role grants permissions requester doesn't have")
+	ErrorOwnRoleChange         = errors.New("member can't change own role")
+	noMemberExistsErr          = errors.New("member does not exist")
+	memberAlreadyOwnerErr      = errors.New("member is already board owner")
 )
 
-func NewBoardMemberService(repo interfaces.BoardMemberRepository, bs *BoardService, us UserService) *BoardMemberService {
-	return &BoardMemberService{repo, bs, us}
+func NewBoardMemberService(
+	repo interfaces.BoardMemberRepository,
+	transactor interfaces.Transactor,
+	bs *BoardService,
+	brs *BoardRoleService,
+	us UserService,
+	publisher BoardEventPublisher,
+) *BoardMemberService {
+	return &BoardMemberService{repo, transactor, bs, brs, us, publisher}
 }
 
-// CreateBoardMember adds new member to board, checked before it's possible at all
+// CreateBoardMember adds new member to board and tells listeners of board about it, requester may give only role
+// whose permissions it has itself. It must not be called within transaction, since event can't be taken back
+// on rollback
 func (bms *BoardMemberService) CreateBoardMember(ctx context.Context, boardId sqlddl.ID, d *CreateBoardMemberData) (*models.BoardMember, error) {
+	if grantErr := bms.checkRoleGrantable(ctx, boardId, d.Role); grantErr != nil {
+		return nil, grantErr
+	}
+	newBoardMember, creationErr := bms.addBoardMember(ctx, boardId, d)
+	if creationErr != nil {
+		return nil, creationErr
+	}
+	bms.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventMemberAdded, boardId, newBoardMember))
+	return newBoardMember, nil
+}
+
+// addBoardMember adds new member to board, checked before it's possible at all. No event is published,
+// so callers running it within transaction publish member.added once transaction is committed
+func (bms *BoardMemberService) addBoardMember(ctx context.Context, boardId sqlddl.ID, d *CreateBoardMemberData) (*models.BoardMember, error) {
 	if _, findBoardErr := bms.BoardService.FindBoardByID(ctx, boardId); findBoardErr != nil {
 		return nil, findBoardErr
 	}
 	if _, userFindErr := bms.UserService.FindByID(ctx, d.UserId); userFindErr != nil {
 		return nil, userFindErr
 	}
+	if _, roleErr := bms.RolePermissions(ctx, boardId, d.Role); roleErr != nil {
+		return nil, roleErr
+	}
 	if _, findMemberErr := bms.FindBoardMemberByUserID(ctx, boardId, d.UserId); findMemberErr == nil {
-		return nil, memberAlreadyExistsErr
+		return nil, ErrorMemberAlreadyExists
 	}
 	id := sqlddl.ID(identifier.GenerateUUID())
 	creationErr := bms.BoardMemberRepository.Create(ctx, &models.BoardMember{
@@ -56,31 +100,190 @@ func (bms *BoardMemberService) CreateBoardMember(ctx context.Context, boardId sq
 	if creationErr != nil {
 		return nil, creationErr
 	}
-	newBoardMember, searchErr := bms.BoardMemberRepository.FindByID(ctx, id)
-	return newBoardMember, searchErr
+	return bms.BoardMemberRepository.FindByID(ctx, id)
 }
 
-func (bms *BoardMemberService) ChangeBoardMemberRole(ctx context.Context, memberId sqlddl.ID, role access.Role) (*models.BoardMember, error) {
-	_, findMemberErr := bms.FindBoardMemberByID(ctx, memberId)
-	if findMemberErr != nil {
-		return nil, findMemberErr
+// ChangeBoardMemberRole gives member of board another role, owner role is given by ownership transfer only,
+// owner may be demoted only by an owner and the last owner of board can't be demoted.
+// Requester can't change own role and may give only role whose permissions it has itself
+func (bms *BoardMemberService) ChangeBoardMemberRole(
+	ctx context.Context,
+	boardId,
+	memberId sqlddl.ID,
+	role access.Role,
+) (*models.BoardMember, error) {
+	if role == access.RoleOwner {
+		return nil, ErrorOwnershipTransferOnly
 	}
-	updateErr := bms.BoardMemberRepository.ChangeMemberRole(ctx, memberId, role)
-	if updateErr != nil {
-		return nil, updateErr
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	if grantErr := bms.checkRoleGrantable(ctx, boardId, role); grantErr != nil {
+		return nil, grantErr
+	}
+	txErr := bms.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock prevents concurrent demotions and removals from leaving board without owners
+		if lockErr := bms.BoardMemberRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		member, findMemberErr := bms.findMemberOfBoard(ctx, boardId, memberId)
+		if findMemberErr != nil {
+			return findMemberErr
+		}
+		if ownerErr := bms.checkOwnerChangeAllowed(ctx, member); ownerErr != nil {
+			return ownerErr
+		}
+		if ownerErr := bms.checkOwnerKept(ctx, member); ownerErr != nil {
+			return ownerErr
+		}
+		if member.UserID == userId {
+			return ErrorOwnRoleChange
+		}
+		return bms.BoardMemberRepository.ChangeMemberRole(ctx, memberId, role)
+	})
+	if txErr != nil {
+		return nil, txErr
 	}
 	updatedMember, searchErr := bms.BoardMemberRepository.FindByID(ctx, memberId)
 	return updatedMember, searchErr
 }
 
-// RemoveBoardMember removes member from board
-func (bms *BoardMemberService) RemoveBoardMember(ctx context.Context, memberId sqlddl.ID) error {
-	_, findMemberErr := bms.FindBoardMemberByID(ctx, memberId)
-	if findMemberErr != nil {
-		return findMemberErr
+// RemoveBoardMember removes member from board, owner may be removed only by an owner or leave board
+// and the last owner of board can't be removed
+func (bms *BoardMemberService) RemoveBoardMember(ctx context.Context, boardId, memberId sqlddl.ID) error {
+	var removedMember *models.BoardMember
+	txErr := bms.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := bms.BoardMemberRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		member, findMemberErr := bms.findMemberOfBoard(ctx, boardId, memberId)
+		if findMemberErr != nil {
+			return findMemberErr
+		}
+		if ownerErr := bms.checkOwnerChangeAllowed(ctx, member); ownerErr != nil {
+			return ownerErr
+		}
+		if ownerErr := bms.checkOwnerKept(ctx, member); ownerErr != nil {
+			return ownerErr
+		}
+		removedMember = member
+		return bms.BoardMemberRepository.Delete(ctx, memberId)
+	})
+	if txErr != nil {
+		return txErr
+	}
+	bms.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventMemberRemoved, boardId, removedMember))
+	return nil
+}
+
+// TransferOwnership makes member an owner of board instead of requester, who becomes board manager
+func (bms *BoardMemberService) TransferOwnership(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *TransferOwnershipData,
+) (*models.BoardMember, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	txErr := bms.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := bms.BoardMemberRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		owner, ownerErr := bms.BoardMemberRepository.FindBoardUser(ctx, boardId, userId)
+		if ownerErr != nil || owner.Role != access.RoleOwner {
+			return ErrorNotBoardOwner
+		}
+		member, findMemberErr := bms.findMemberOfBoard(ctx, boardId, d.MemberID)
+		if findMemberErr != nil {
+			return findMemberErr
+		}
+		if member.Role == access.RoleOwner {
+			return memberAlreadyOwnerErr
+		}
+		if promoteErr := bms.BoardMemberRepository.ChangeMemberRole(ctx, member.ID, access.RoleOwner); promoteErr != nil {
+			return promoteErr
+		}
+		return bms.BoardMemberRepository.ChangeMemberRole(ctx, owner.ID, access.RoleManager)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	newOwner, searchErr := bms.BoardMemberRepository.FindByID(ctx, d.MemberID)
+	return newOwner, searchErr
+}
+
+// findMemberOfBoard searches for member and checks it belongs to provided board
+func (bms *BoardMemberService) findMemberOfBoard(ctx context.Context, boardId, memberId sqlddl.ID) (*models.BoardMember, error) {
+	member, findMemberErr := bms.BoardMemberRepository.FindByID(ctx, memberId)
+	if findMemberErr != nil || member.BoardID != boardId {
+		return nil, noMemberExistsErr
+	}
+	return member, nil
+}
+
+// checkOwnerChangeAllowed checks requester may change role or membership of member, owners are changed
+// only by owners or by themselves, so managers can't strip board of its owners
+func (bms *BoardMemberService) checkOwnerChangeAllowed(ctx context.Context, member *models.BoardMember) error {
+	if member.Role != access.RoleOwner {
+		return nil
+	}
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
 	}
-	removeErr := bms.BoardRepository.Delete(ctx, memberId)
-	return removeErr
+	if member.UserID == userId {
+		return nil
+	}
+	requester, requesterErr := bms.BoardMemberRepository.FindBoardUser(ctx, member.BoardID, userId)
+	if requesterErr != nil || requester.Role != access.RoleOwner {
+		return ErrorOwnerChangeForbidden
+	}
+	return nil
+}
+
+// checkRoleGrantable checks role exists on board and requester has every permission it grants,
+// so members can't raise privileges of themselves or others above their own
+func (bms *BoardMemberService) checkRoleGrantable(ctx context.Context, boardId sqlddl.ID, role access.Role) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	permissions, roleErr := bms.RolePermissions(ctx, boardId, role)
+	if roleErr != nil {
+		return roleErr
+	}
+	requester, requesterErr := bms.FindBoardMemberByUserID(ctx, boardId, userId)
+	if requesterErr != nil {
+		return ErrorRoleNotGrantable
+	}
+	requesterPermissions, requesterRoleErr := bms.RolePermissions(ctx, boardId, requester.Role)
+	if requesterRoleErr != nil {
+		return ErrorRoleNotGrantable
+	}
+	for _, permission := range permissions {
+		if !slices.Contains(requesterPermissions, permission) {
+			return ErrorRoleNotGrantable
+		}
+	}
+	return nil
+}
+
+// checkOwnerKept checks board keeps an owner once member stops being owner,
+// must be called within transaction which locked members of board
+func (bms *BoardMemberService) checkOwnerKept(ctx context.Context, member *models.BoardMember) error {
+	if member.Role != access.RoleOwner {
+		return nil
+	}
+	owners, countErr := bms.BoardMemberRepository.CountByRole(ctx, member.BoardID, access.RoleOwner)
+	if countErr != nil {
+		return countErr
+	}
+	if owners <= 1 {
+		return ErrorLastOwner
+	}
+	return nil
 }
 
 func (bms *BoardMemberService) FindBoardMemberByID(ctx context.Context, memberId sqlddl.ID) (*models.BoardMember, error) {
@@ -107,35 +310,15 @@ func (bms *BoardMemberService) ListBoardMembers(ctx context.Context, boardId sql
 	return findMembers, nil
 }
 
-func (bms *BoardMemberService) IsUserBoardOwner(ctx context.Context, userId, boardId sqlddl.ID) bool {
-	findMember, findMemberErr := bms.FindBoardMemberByUserID(ctx, boardId, userId)
+// Can checks if user is a member of board whose role grants provided permission
+func (bms *BoardMemberService) Can(ctx context.Context, userId, boardId sqlddl.ID, permission access.Permission) bool {
+	member, findMemberErr := bms.FindBoardMemberByUserID(ctx, boardId, userId)
 	if findMemberErr != nil {
 		return false
 	}
-	return findMember.Role == access.RoleOwner
-}
-
-func (bms *BoardMemberService) IsUserBoardManager(ctx context.Context, userId, boardId sqlddl.ID) bool {
-	findMember, findMemberErr := bms.FindBoardMemberByUserID(ctx, boardId, userId)
-	if findMemberErr != nil {
-		return false
-	}
-	return findMember.Role == access.RoleManager
-}
-
-// IsUserAllowedManageBoard checks if user (requester) has access to create members of board
-func (bms *BoardMemberService) IsUserAllowedManageBoard(ctx context.Context, userId, boardId sqlddl.ID) bool {
-	findMember, findMemberErr := bms.FindBoardMemberByUserID(ctx, boardId, userId)
-	if findMemberErr != nil {
-		return false
-	}
-	return findMember.Role == access.RoleManager || findMember.Role == access.RoleOwner
-}
-
-func (bms *BoardMemberService) IsUserAllowedDeleteMember(ctx context.Context, userId, memberId sqlddl.ID) bool {
-	findMember, findMemberErr := bms.FindBoardMemberByID(ctx, memberId)
-	if findMemberErr != nil {
+	permissions, permissionsErr := bms.RolePermissions(ctx, boardId, member.Role)
+	if permissionsErr != nil {
 		return false
 	}
-	return bms.IsUserAllowedManageBoard(ctx, userId, findMember.BoardID) || findMember.UserID == userId
+	return slices.Contains(permissions, permission)
 }
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/sqlddl"
+)
+
+const (
+	ownershipBoardId = sqlddl.ID("board")
+	// adminRole is custom role of board which grants more than manager has
+	adminRole = access.Role("admin")
+)
+
+// memoryMemberRepository keeps board members in memory by their identifiers
+type memoryMemberRepository struct {
+	members map[sqlddl.ID]*models.BoardMember
+}
+
+func (mmr *memoryMemberRepository) Create(_ context.Context, member *models.BoardMember) error {
+	mmr.members[member.ID] = member
+	return nil
+}
+
+func (mmr *memoryMemberRepository) ChangeMemberRole(_ context.Context, memberId sqlddl.ID, role access.Role) error {
+	mmr.members[memberId].Role = role
+	return nil
+}
+
+func (mmr *memoryMemberRepository) FindByID(_ context.Context, memberId sqlddl.ID) (*models.BoardMember, error) {
+	member, ok := mmr.members[memberId]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	found := *member
+	return &found, nil
+}
+
+func (mmr *memoryMemberRepository) FindBoardUser(_ context.Context, boardId, userId sqlddl.ID) (*models.BoardMember, error) {
+	for _, member := range mmr.members {
+		if member.BoardID == boardId && member.UserID == userId {
+			found := *member
+			return &found, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (mmr *memoryMemberRepository) FindBoardMembers(_ context.Context, boardId sqlddl.ID) ([]models.BoardMember, error) {
+	var members []models.BoardMember
+	for _, member := range mmr.members {
+		if member.BoardID == boardId {
+			members = append(members, *member)
+		}
+	}
+	return members, nil
+}
+
+func (mmr *memoryMemberRepository) CountByRole(_ context.Context, boardId sqlddl.ID, role access.Role) (int, error) {
+	count := 0
+	for _, member := range mmr.members {
+		if member.BoardID == boardId && member.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (mmr *memoryMemberRepository) Lock(context.Context, sqlddl.ID) error {
+	return nil
+}
+
+func (mmr *memoryMemberRepository) Delete(_ context.Context, memberId sqlddl.ID) error {
+	delete(mmr.members, memberId)
+	return nil
+}
+
+// memoryRoleRepository keeps custom roles of board in memory, only search by name is supported
+type memoryRoleRepository struct {
+	interfaces.BoardRoleRepository
+	roles map[access.Role][]access.Permission
+}
+
+func (mrr memoryRoleRepository) FindByName(_ context.Context, boardId sqlddl.ID, name access.Role) (*models.BoardRole, error) {
+	permissions, ok := mrr.roles[name]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &models.BoardRole{BoardID: boardId, Name: name, Permissions: permissions}, nil
+}
+
+// immediateTransactor runs function without any transaction
+type immediateTransactor struct{}
+
+func (immediateTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// newOwnershipService creates service for board with provided members identified by their user identifiers
+func newOwnershipService(roles map[sqlddl.ID]access.Role) (*BoardMemberService, *memoryMemberRepository) {
+	repo := &memoryMemberRepository{members: make(map[sqlddl.ID]*models.BoardMember)}
+	for userId, role := range roles {
+		repo.members[userId] = &models.BoardMember{
+			Model:   models.Model{ID: userId},
+			BoardID: ownershipBoardId,
+			UserID:  userId,
+			Role:    role,
+		}
+	}
+	repo.members["stranger"] = &models.BoardMember{
+		Model:   models.Model{ID: "stranger"},
+		BoardID: "another",
+		UserID:  "stranger",
+		Role:    access.RoleRegular,
+	}
+	return &BoardMemberService{
+		BoardMemberRepository: repo,
+		Transactor:            immediateTransactor{},
+		BoardRoleService: &BoardRoleService{BoardRoleRepository: memoryRoleRepository{
+			roles: map[access.Role][]access.Permission{
+				adminRole: {access.PermissionBoardDelete, access.PermissionRoleManage, access.PermissionMemberUpdate},
+			},
+		}},
+		BoardEventPublisher: NewBoardEventHub(SystemClock{}, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout),
+	}, repo
+}
+
+// withUser gives context of request made by user
+func withUser(userId sqlddl.ID) context.Context {
+	return context.WithValue(context.Background(), contextkeys.KeyUserId, userId)
+}
+
+func TestBoardMemberServiceKeepsOwner(t *testing.T) {
+	ctx := withUser("owner")
+
+	t.Run("Sole owner can't be demoted", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{"owner": access.RoleOwner})
+		_, err := bms.ChangeBoardMemberRole(ctx, ownershipBoardId, "owner", access.RoleManager)
+		if !errors.Is(err, ErrorLastOwner) {
+			t.Fatalf("got %v, expected %v", err, ErrorLastOwner)
+		}
+		if role := repo.members["owner"].Role; role != access.RoleOwner {
+			t.Fatalf("got role %s, expected %s", role, access.RoleOwner)
+		}
+	})
+
+	t.Run("Sole owner can't be removed", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"regular": access.RoleRegular,
+		})
+		if err := bms.RemoveBoardMember(ctx, ownershipBoardId, "owner"); !errors.Is(err, ErrorLastOwner) {
+			t.Fatalf("got %v, expected %v", err, ErrorLastOwner)
+		}
+		if _, ok := repo.members["owner"]; !ok {
+			t.Fatal("owner is removed")
+		}
+	})
+
+	t.Run("One of owners can be demoted", func(t *testing.T) {
+		bms, _ := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":  access.RoleOwner,
+			"second": access.RoleOwner,
+		})
+		member, err := bms.ChangeBoardMemberRole(ctx, ownershipBoardId, "second", access.RoleRegular)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.Role != access.RoleRegular {
+			t.Fatalf("got role %s, expected %s", member.Role, access.RoleRegular)
+		}
+	})
+
+	t.Run("Non-owner can be removed", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"regular": access.RoleRegular,
+		})
+		if err := bms.RemoveBoardMember(ctx, ownershipBoardId, "regular"); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := repo.members["regular"]; ok {
+			t.Fatal("member isn't removed")
+		}
+	})
+
+	t.Run("Owner role can't be given by role change", func(t *testing.T) {
+		bms, _ := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"regular": access.RoleRegular,
+		})
+		_, err := bms.ChangeBoardMemberRole(ctx, ownershipBoardId, "regular", access.RoleOwner)
+		if !errors.Is(err, ErrorOwnershipTransferOnly) {
+			t.Fatalf("got %v, expected %v", err, ErrorOwnershipTransferOnly)
+		}
+	})
+
+	t.Run("Manager can't demote or remove owner", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"second":  access.RoleOwner,
+			"manager": access.RoleManager,
+		})
+		_, err := bms.ChangeBoardMemberRole(withUser("manager"), ownershipBoardId, "second", access.RoleRegular)
+		if !errors.Is(err, ErrorOwnerChangeForbidden) {
+			t.Fatalf("got %v, expected %v", err, ErrorOwnerChangeForbidden)
+		}
+		err = bms.RemoveBoardMember(withUser("manager"), ownershipBoardId, "second")
+		if !errors.Is(err, ErrorOwnerChangeForbidden) {
+			t.Fatalf("got %v, expected %v", err, ErrorOwnerChangeForbidden)
+		}
+		if role := repo.members["second"].Role; role != access.RoleOwner {
+			t.Fatalf("got role %s, expected %s", role, access.RoleOwner)
+		}
+	})
+
+	t.Run("One of owners can leave", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":  access.RoleOwner,
+			"second": access.RoleOwner,
+		})
+		if err := bms.RemoveBoardMember(withUser("second"), ownershipBoardId, "second"); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := repo.members["second"]; ok {
+			t.Fatal("owner hasn't left")
+		}
+	})
+
+	t.Run("Member of another board isn't found", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{"owner": access.RoleOwner})
+		if err := bms.RemoveBoardMember(ctx, ownershipBoardId, "stranger"); !errors.Is(err, noMemberExistsErr) {
+			t.Fatalf("got %v, expected %v", err, noMemberExistsErr)
+		}
+		if _, ok := repo.members["stranger"]; !ok {
+			t.Fatal("member of another board is removed")
+		}
+	})
+}
+
+func TestBoardMemberServiceRoleGrants(t *testing.T) {
+	roles := map[sqlddl.ID]access.Role{
+		"owner":   access.RoleOwner,
+		"manager": access.RoleManager,
+		"regular": access.RoleRegular,
+	}
+
+	t.Run("Manager can't give role stronger than own", func(t *testing.T) {
+		bms, repo := newOwnershipService(roles)
+		_, err := bms.ChangeBoardMemberRole(withUser("manager"), ownershipBoardId, "regular", adminRole)
+		if !errors.Is(err, ErrorRoleNotGrantable) {
+			t.Fatalf("got %v, expected %v", err, ErrorRoleNotGrantable)
+		}
+		_, err = bms.CreateBoardMember(withUser("manager"), ownershipBoardId, &CreateBoardMemberData{
+			UserId: "newcomer",
+			Role:   adminRole,
+		})
+		if !errors.Is(err, ErrorRoleNotGrantable) {
+			t.Fatalf("got %v, expected %v", err, ErrorRoleNotGrantable)
+		}
+		if role := repo.members["regular"].Role; role != access.RoleRegular {
+			t.Fatalf("got role %s, expected %s", role, access.RoleRegular)
+		}
+	})
+
+	t.Run("Manager gives role within own permissions", func(t *testing.T) {
+		bms, _ := newOwnershipService(roles)
+		member, err := bms.ChangeBoardMemberRole(withUser("manager"), ownershipBoardId, "regular", access.RoleManager)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.Role != access.RoleManager {
+			t.Fatalf("got role %s, expected %s", member.Role, access.RoleManager)
+		}
+	})
+
+	t.Run("Owner gives custom role", func(t *testing.T) {
+		bms, _ := newOwnershipService(roles)
+		member, err := bms.ChangeBoardMemberRole(withUser("owner"), ownershipBoardId, "manager", adminRole)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if member.Role != adminRole {
+			t.Fatalf("got role %s, expected %s", member.Role, adminRole)
+		}
+	})
+
+	t.Run("Member can't change own role", func(t *testing.T) {
+		bms, repo := newOwnershipService(roles)
+		_, err := bms.ChangeBoardMemberRole(withUser("manager"), ownershipBoardId, "manager", access.RoleRegular)
+		if !errors.Is(err, ErrorOwnRoleChange) {
+			t.Fatalf("got %v, expected %v", err, ErrorOwnRoleChange)
+		}
+		if role := repo.members["manager"].Role; role != access.RoleManager {
+			t.Fatalf("got role %s, expected %s", role, access.RoleManager)
+		}
+	})
+}
+
+func TestBoardMemberServiceTransferOwnership(t *testing.T) {
+	t.Run("Owner transfers ownership", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"regular": access.RoleRegular,
+		})
+		newOwner, err := bms.TransferOwnership(withUser("owner"), ownershipBoardId, &TransferOwnershipData{MemberID: "regular"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if newOwner.Role != access.RoleOwner {
+			t.Fatalf("got role %s of new owner, expected %s", newOwner.Role, access.RoleOwner)
+		}
+		if role := repo.members["owner"].Role; role != access.RoleManager {
+			t.Fatalf("got role %s of previous owner, expected %s", role, access.RoleManager)
+		}
+	})
+
+	t.Run("Non-owner can't transfer ownership", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{
+			"owner":   access.RoleOwner,
+			"manager": access.RoleManager,
+		})
+		_, err := bms.TransferOwnership(withUser("manager"), ownershipBoardId, &TransferOwnershipData{MemberID: "manager"})
+		if !errors.Is(err, ErrorNotBoardOwner) {
+			t.Fatalf("got %v, expected %v", err, ErrorNotBoardOwner)
+		}
+		if role := repo.members["owner"].Role; role != access.RoleOwner {
+			t.Fatalf("got role %s, expected %s", role, access.RoleOwner)
+		}
+	})
+
+	t.Run("Ownership can't be transferred to member of another board", func(t *testing.T) {
+		bms, repo := newOwnershipService(map[sqlddl.ID]access.Role{"owner": access.RoleOwner})
+		_, err := bms.TransferOwnership(withUser("owner"), ownershipBoardId, &TransferOwnershipData{MemberID: "stranger"})
+		if !errors.Is(err, noMemberExistsErr) {
+			t.Fatalf("got %v, expected %v", err, noMemberExistsErr)
+		}
+		if role := repo.members["owner"].Role; role != access.RoleOwner {
+			t.Fatalf("got role %s, expected %s", role, access.RoleOwner)
+		}
+	})
+}
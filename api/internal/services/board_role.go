@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	BoardRoleService struct {
+		interfaces.BoardRoleRepository
+		interfaces.BoardMemberRepository
+	}
+	CreateBoardRoleData struct {
+		Name        access.Role         `json:"name" validate:"required,max=100,trimmed"`
+		Permissions []access.Permission `json:"permissions" validate:"required,dive,required"`
+	}
+	UpdateBoardRoleData struct {
+		Permissions []access.Permission `json:"permissions" validate:"required,dive,required"`
+	}
+)
+
+var (
+	roleAlreadyExistsErr = errors.New("role with this name already exists")
+	ErrorRoleNotExists   = errors.New("board role does not exist")
+	ErrorRoleInUse       = errors.New("board role is given to members")
+	unknownPermissionErr = errors.New("unknown permission")
+)
+
+// builtInBoardRoles lists roles available on every board in order of their privileges
+var builtInBoardRoles = []access.Role{access.RoleOwner, access.RoleManager, access.RoleRegular}
+
+func NewBoardRoleService(repo interfaces.BoardRoleRepository, memberRepo interfaces.BoardMemberRepository) *BoardRoleService {
+	return &BoardRoleService{repo, memberRepo}
+}
+
+// ListBoardRoles returns built-in roles followed by custom roles of board
+func (brs *BoardRoleService) ListBoardRoles(ctx context.Context, boardId sqlddl.ID) ([]models.BoardRole, error) {
+	customRoles, searchErr := brs.BoardRoleRepository.FindAllByBoardID(ctx, boardId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	roles := make([]models.BoardRole, 0, len(builtInBoardRoles)+len(customRoles))
+	for _, role := range builtInBoardRoles {
+		roles = append(roles, models.BoardRole{
+			BoardID:     boardId,
+			Name:        role,
+			Permissions: access.BuiltInRoles[role],
+			BuiltIn:     true,
+		})
+	}
+	return append(roles, customRoles...), nil
+}
+
+// FindBoardRoleByID searches for custom role and checks it belongs to provided board
+func (brs *BoardRoleService) FindBoardRoleByID(ctx context.Context, boardId, roleId sqlddl.ID) (*models.BoardRole, error) {
+	role, searchErr := brs.BoardRoleRepository.FindByID(ctx, roleId)
+	if searchErr != nil || role.BoardID != boardId {
+		return nil, ErrorRoleNotExists
+	}
+	return role, nil
+}
+
+func (brs *BoardRoleService) CreateBoardRole(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *CreateBoardRoleData,
+) (*models.BoardRole, error) {
+	if d.Name.IsBuiltIn() {
+		return nil, roleAlreadyExistsErr
+	}
+	if _, notExistErr := brs.BoardRoleRepository.FindByName(ctx, boardId, d.Name); notExistErr == nil {
+		return nil, roleAlreadyExistsErr
+	}
+	if permissionsErr := checkPermissions(d.Permissions); permissionsErr != nil {
+		return nil, permissionsErr
+	}
+	id := sqlddl.ID(identifier.GenerateUUID())
+	creationErr := brs.BoardRoleRepository.Create(ctx, &models.BoardRole{
+		Model:       models.Model{ID: id},
+		BoardID:     boardId,
+		Name:        d.Name,
+		Permissions: d.Permissions,
+	})
+	if creationErr != nil {
+		return nil, creationErr
+	}
+	createdRole, searchErr := brs.BoardRoleRepository.FindByID(ctx, id)
+	return createdRole, searchErr
+}
+
+// UpdateBoardRole replaces permissions of custom role, members having it get new permissions at once
+func (brs *BoardRoleService) UpdateBoardRole(
+	ctx context.Context,
+	boardId,
+	roleId sqlddl.ID,
+	d *UpdateBoardRoleData,
+) (*models.BoardRole, error) {
+	if _, searchErr := brs.FindBoardRoleByID(ctx, boardId, roleId); searchErr != nil {
+		return nil, searchErr
+	}
+	if permissionsErr := checkPermissions(d.Permissions); permissionsErr != nil {
+		return nil, permissionsErr
+	}
+	if updateErr := brs.BoardRoleRepository.UpdatePermissions(ctx, roleId, d.Permissions); updateErr != nil {
+		return nil, updateErr
+	}
+	updatedRole, searchErr := brs.BoardRoleRepository.FindByID(ctx, roleId)
+	return updatedRole, searchErr
+}
+
+// DeleteBoardRole removes custom role of board, role given to any member can't be removed
+func (brs *BoardRoleService) DeleteBoardRole(ctx context.Context, boardId, roleId sqlddl.ID) error {
+	role, searchErr := brs.FindBoardRoleByID(ctx, boardId, roleId)
+	if searchErr != nil {
+		return searchErr
+	}
+	members, membersErr := brs.BoardMemberRepository.FindBoardMembers(ctx, boardId)
+	if membersErr != nil {
+		return membersErr
+	}
+	for _, member := range members {
+		if member.Role == role.Name {
+			return ErrorRoleInUse
+		}
+	}
+	return brs.BoardRoleRepository.Delete(ctx, roleId)
+}
+
+// RolePermissions returns permissions of built-in or custom role of board
+func (brs *BoardRoleService) RolePermissions(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	role access.Role,
+) ([]access.Permission, error) {
+	if permissions, ok := access.BuiltInRoles[role]; ok {
+		return permissions, nil
+	}
+	customRole, searchErr := brs.BoardRoleRepository.FindByName(ctx, boardId, role)
+	if searchErr != nil {
+		return nil, ErrorRoleNotExists
+	}
+	return customRole.Permissions, nil
+}
+
+// checkPermissions checks all permissions are known ones
+func checkPermissions(permissions []access.Permission) error {
+	for _, permission := range permissions {
+		if !permission.IsKnown() {
+			return fmt.Errorf("%w: %s", unknownPermissionErr, permission)
+		}
+	}
+	return nil
+}
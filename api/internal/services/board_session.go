@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+const (
+	// DefaultPresenceTimeout is how long session stays on board without any message from its client
+	DefaultPresenceTimeout = time.Minute
+	// DefaultTaskLockTimeout is how long task lock is held unless its session renews it
+	DefaultTaskLockTimeout = 2 * time.Minute
+	// sessionQueueSize is count of messages waiting for delivery to session, slower sessions are dropped
+	sessionQueueSize = 32
+)
+
+var (
+	ErrorTaskLocked       = errors.New("task is being edited in another session")
+	ErrorSessionTooSlow   = errors.New("session doesn't keep up with board messages")
+	ErrorSessionTimedOut  = errors.New("session has been silent for too long")
+	ErrorSessionHubClosed = errors.New("board sessions are closed")
+	taskLockNotHeldErr    = errors.New("task isn't locked by session")
+)
+
+type (
+	// SessionCommandData is message sent by client of board session
+	SessionCommandData struct {
+		Type   models.SessionMessageType `json:"type" validate:"required,oneof=typing.started typing.stopped lock.acquire lock.release"`
+		TaskID sqlddl.ID                 `json:"task_id" validate:"required"`
+		// Field is task field which user types in, it's used by typing messages only
+		Field string `json:"field" validate:"omitempty,oneof=name description comment"`
+	}
+
+	// BoardSessionHub keeps collaborative sessions of boards: who views board, who types in which task
+	// and who edits which task description. Sessions not heard of for PresenceTimeout and sessions which
+	// don't keep up with messages are dropped
+	BoardSessionHub struct {
+		Clock
+		// PresenceTimeout is how long session stays on board without any message from its client
+		PresenceTimeout time.Duration
+		// LockTimeout is how long task lock is held unless its session renews it
+		LockTimeout time.Duration
+		mu          sync.Mutex
+		rooms       map[sqlddl.ID]*boardSessionRoom
+		closed      bool
+		cancel      context.CancelFunc
+		done        chan struct{}
+	}
+
+	// boardSessionRoom is open sessions of board along with task locks held by them
+	boardSessionRoom struct {
+		sessions map[*BoardSession]bool
+		locks    map[sqlddl.ID]*models.TaskLock
+	}
+
+	// BoardSession is client viewing board, it receives messages until it's closed by client or dropped by hub
+	BoardSession struct {
+		models.BoardViewer
+		hub      *BoardSessionHub
+		boardId  sqlddl.ID
+		lastSeen time.Time
+		messages chan models.SessionMessage
+		err      error
+	}
+)
+
+// NewBoardSessionHub creates new instance of BoardSessionHub
+func NewBoardSessionHub(clock Clock, presenceTimeout, lockTimeout time.Duration) *BoardSessionHub {
+	return &BoardSessionHub{
+		Clock:           clock,
+		PresenceTimeout: presenceTimeout,
+		LockTimeout:     lockTimeout,
+		rooms:           make(map[sqlddl.ID]*boardSessionRoom),
+	}
+}
+
+// Join opens session of user on board. Session receives presence.state message first,
+// other sessions of board receive presence.joined
+func (bsh *BoardSessionHub) Join(boardId, userId sqlddl.ID) *BoardSession {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	now := bsh.Now()
+	session := &BoardSession{
+		BoardViewer: models.BoardViewer{
+			SessionID: sqlddl.ID(identifier.GenerateUUID()),
+			UserID:    userId,
+			JoinedAt:  now,
+		},
+		hub:      bsh,
+		boardId:  boardId,
+		lastSeen: now,
+		messages: make(chan models.SessionMessage, sessionQueueSize),
+	}
+	if bsh.closed {
+		session.err = ErrorSessionHubClosed
+		close(session.messages)
+		return session
+	}
+	room, ok := bsh.rooms[boardId]
+	if !ok {
+		room = &boardSessionRoom{
+			sessions: make(map[*BoardSession]bool),
+			locks:    make(map[sqlddl.ID]*models.TaskLock),
+		}
+		bsh.rooms[boardId] = room
+	}
+	bsh.broadcast(room, models.SessionMessage{Type: models.SessionMessagePresenceJoined, Viewer: &session.BoardViewer})
+	room.sessions[session] = true
+	state := models.SessionMessage{
+		Type:      models.SessionMessagePresenceState,
+		SessionID: session.SessionID,
+		Viewers:   room.viewers(),
+		Locks:     room.taskLocks(),
+	}
+	bsh.send(room, session, state)
+	return session
+}
+
+// Reply sends message to session only, e.g. error of message received from its client
+func (bsh *BoardSessionHub) Reply(session *BoardSession, message models.SessionMessage) {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	if room, ok := bsh.rooms[session.boardId]; ok && room.sessions[session] {
+		bsh.send(room, session, message)
+	}
+}
+
+// SetTyping relays typing.started or typing.stopped message of session to other sessions of board
+func (bsh *BoardSessionHub) SetTyping(session *BoardSession, taskId sqlddl.ID, field string, typing bool) {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	room, ok := bsh.rooms[session.boardId]
+	if !ok || !room.sessions[session] {
+		return
+	}
+	message := models.SessionMessage{
+		Type:   models.SessionMessageTypingStopped,
+		Viewer: &session.BoardViewer,
+		TaskID: taskId,
+		Field:  field,
+	}
+	if typing {
+		message.Type = models.SessionMessageTypingStarted
+	}
+	bsh.broadcast(room, message, session)
+}
+
+// AcquireTaskLock locks task description for session or renews lock the session already holds.
+// If task is locked by another session, ErrorTaskLocked is returned along with that lock
+func (bsh *BoardSessionHub) AcquireTaskLock(session *BoardSession, taskId sqlddl.ID) (*models.TaskLock, error) {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	room, ok := bsh.rooms[session.boardId]
+	if !ok || !room.sessions[session] {
+		return nil, ErrorSessionHubClosed
+	}
+	now := bsh.Now()
+	if lock, locked := room.locks[taskId]; locked && lock.SessionID != session.SessionID && lock.ExpiresAt.After(now) {
+		held := *lock
+		return &held, ErrorTaskLocked
+	}
+	lock := &models.TaskLock{
+		TaskID:    taskId,
+		SessionID: session.SessionID,
+		UserID:    session.UserID,
+		ExpiresAt: now.Add(bsh.LockTimeout),
+	}
+	room.locks[taskId] = lock
+	acquired := *lock
+	bsh.broadcast(room, models.SessionMessage{Type: models.SessionMessageLockAcquired, Lock: &acquired})
+	return &acquired, nil
+}
+
+// ReleaseTaskLock releases task lock held by session
+func (bsh *BoardSessionHub) ReleaseTaskLock(session *BoardSession, taskId sqlddl.ID) error {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	room, ok := bsh.rooms[session.boardId]
+	if !ok {
+		return taskLockNotHeldErr
+	}
+	lock, locked := room.locks[taskId]
+	if !locked || lock.SessionID != session.SessionID {
+		return taskLockNotHeldErr
+	}
+	bsh.releaseLock(room, lock)
+	return nil
+}
+
+// PruneIdle drops sessions which haven't been heard of for longer than PresenceTimeout
+// and releases expired task locks
+func (bsh *BoardSessionHub) PruneIdle() {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	now := bsh.Now()
+	for boardId, room := range bsh.rooms {
+		for session := range room.sessions {
+			if room.sessions[session] && now.Sub(session.lastSeen) > bsh.PresenceTimeout {
+				bsh.drop(room, session, ErrorSessionTimedOut)
+			}
+		}
+		for _, lock := range room.locks {
+			if !lock.ExpiresAt.After(now) {
+				bsh.releaseLock(room, lock)
+			}
+		}
+		if len(room.sessions) == 0 {
+			delete(bsh.rooms, boardId)
+		}
+	}
+}
+
+// Start runs goroutine which prunes idle sessions and expired locks until Stop is called or ctx is done
+func (bsh *BoardSessionHub) Start(ctx context.Context) {
+	ctx, bsh.cancel = context.WithCancel(ctx)
+	bsh.done = make(chan struct{})
+	go func() {
+		defer close(bsh.done)
+		ticker := time.NewTicker(min(bsh.PresenceTimeout, bsh.LockTimeout) / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				bsh.PruneIdle()
+			}
+		}
+	}()
+}
+
+// Stop stops pruning goroutine and waits for it
+func (bsh *BoardSessionHub) Stop() {
+	if bsh.cancel == nil {
+		return
+	}
+	bsh.cancel()
+	<-bsh.done
+}
+
+// Close drops all sessions with ErrorSessionHubClosed, it's called on shutdown to end open connections
+func (bsh *BoardSessionHub) Close() {
+	bsh.mu.Lock()
+	defer bsh.mu.Unlock()
+	for _, room := range bsh.rooms {
+		for session := range room.sessions {
+			if room.sessions[session] {
+				bsh.drop(room, session, ErrorSessionHubClosed)
+			}
+		}
+	}
+	bsh.rooms = make(map[sqlddl.ID]*boardSessionRoom)
+	bsh.closed = true
+}
+
+// send queues message for session, session whose queue is full is dropped. It must be called under lock
+func (bsh *BoardSessionHub) send(room *boardSessionRoom, session *BoardSession, message models.SessionMessage) {
+	select {
+	case session.messages <- message:
+	default:
+		bsh.drop(room, session, ErrorSessionTooSlow)
+	}
+}
+
+// broadcast sends message to sessions of room except provided ones, it must be called under lock
+func (bsh *BoardSessionHub) broadcast(room *boardSessionRoom, message models.SessionMessage, except ...*BoardSession) {
+	var slow []*BoardSession
+	for session := range room.sessions {
+		if slices.Contains(except, session) {
+			continue
+		}
+		select {
+		case session.messages <- message:
+		default:
+			slow = append(slow, session)
+		}
+	}
+	// slow sessions are dropped after loop since dropping broadcasts to room too
+	for _, session := range slow {
+		if room.sessions[session] {
+			bsh.drop(room, session, ErrorSessionTooSlow)
+		}
+	}
+}
+
+// drop removes session from room, releases its locks and tells others that it has left.
+// Session channel is closed, err is reason of closing and nil if client has left. It must be called under lock
+func (bsh *BoardSessionHub) drop(room *boardSessionRoom, session *BoardSession, err error) {
+	delete(room.sessions, session)
+	session.err = err
+	close(session.messages)
+	for _, lock := range room.locks {
+		if lock.SessionID == session.SessionID {
+			bsh.releaseLock(room, lock)
+		}
+	}
+	bsh.broadcast(room, models.SessionMessage{Type: models.SessionMessagePresenceLeft, Viewer: &session.BoardViewer})
+}
+
+// releaseLock removes lock and tells sessions of room about it, it must be called under lock
+func (bsh *BoardSessionHub) releaseLock(room *boardSessionRoom, lock *models.TaskLock) {
+	delete(room.locks, lock.TaskID)
+	bsh.broadcast(room, models.SessionMessage{Type: models.SessionMessageLockReleased, Lock: lock})
+}
+
+// viewers lists viewers of room in order of joining
+func (bsr *boardSessionRoom) viewers() []models.BoardViewer {
+	viewers := make([]models.BoardViewer, 0, len(bsr.sessions))
+	for session := range bsr.sessions {
+		viewers = append(viewers, session.BoardViewer)
+	}
+	sort.Slice(viewers, func(i, j int) bool {
+		if viewers[i].JoinedAt.Equal(viewers[j].JoinedAt) {
+			return viewers[i].SessionID < viewers[j].SessionID
+		}
+		return viewers[i].JoinedAt.Before(viewers[j].JoinedAt)
+	})
+	return viewers
+}
+
+// taskLocks lists locks of room
+func (bsr *boardSessionRoom) taskLocks() []models.TaskLock {
+	locks := make([]models.TaskLock, 0, len(bsr.locks))
+	for _, lock := range bsr.locks {
+		locks = append(locks, *lock)
+	}
+	return locks
+}
+
+// Messages returns channel of messages for client, it's closed when session is closed or dropped by hub
+func (bs *BoardSession) Messages() <-chan models.SessionMessage {
+	return bs.messages
+}
+
+// Err returns reason of dropping session by hub once Messages channel is closed, it's nil if client has left
+func (bs *BoardSession) Err() error {
+	return bs.err
+}
+
+// Touch marks session as alive, it's called on every message or pong of client
+func (bs *BoardSession) Touch() {
+	bs.hub.mu.Lock()
+	defer bs.hub.mu.Unlock()
+	bs.lastSeen = bs.hub.Now()
+}
+
+// Close leaves board, locks of session are released and others are told that it has left.
+// It may be called after session is dropped by hub
+func (bs *BoardSession) Close() {
+	bs.hub.mu.Lock()
+	defer bs.hub.mu.Unlock()
+	room, ok := bs.hub.rooms[bs.boardId]
+	if !ok || !room.sessions[bs] {
+		return
+	}
+	bs.hub.drop(room, bs, nil)
+	if len(room.sessions) == 0 {
+		delete(bs.hub.rooms, bs.boardId)
+	}
+}
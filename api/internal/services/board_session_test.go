@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+)
+
+// receiveMessage takes queued message of session, test fails if there's none
+func receiveMessage(t *testing.T, session *BoardSession) models.SessionMessage {
+	t.Helper()
+	select {
+	case message, ok := <-session.Messages():
+		if !ok {
+			t.Fatalf("session is closed with %v", session.Err())
+		}
+		return message
+	default:
+		t.Fatal("no message is sent to session")
+		return models.SessionMessage{}
+	}
+}
+
+func TestBoardSessionHub(t *testing.T) {
+	clock := &movingClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("Viewers are told about each other", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, DefaultPresenceTimeout, DefaultTaskLockTimeout)
+		alice := hub.Join("board", "alice")
+		defer alice.Close()
+		if state := receiveMessage(t, alice); state.Type != models.SessionMessagePresenceState ||
+			state.SessionID != alice.SessionID || len(state.Viewers) != 1 {
+			t.Fatalf("got message %+v", state)
+		}
+		bob := hub.Join("board", "bob")
+		if state := receiveMessage(t, bob); state.SessionID != bob.SessionID || len(state.Viewers) != 2 {
+			t.Fatalf("got message %+v", state)
+		}
+		if joined := receiveMessage(t, alice); joined.Type != models.SessionMessagePresenceJoined ||
+			joined.Viewer.UserID != "bob" {
+			t.Fatalf("got message %+v", joined)
+		}
+		other := hub.Join("other", "carol")
+		defer other.Close()
+		bob.Close()
+		if left := receiveMessage(t, alice); left.Type != models.SessionMessagePresenceLeft ||
+			left.Viewer.SessionID != bob.SessionID {
+			t.Fatalf("got message %+v", left)
+		}
+		if _, ok := <-bob.Messages(); ok || bob.Err() != nil {
+			t.Fatalf("left session is open or has error %v", bob.Err())
+		}
+		receiveMessage(t, other)
+		select {
+		case message := <-other.Messages():
+			t.Fatalf("got message %+v of another board", message)
+		default:
+		}
+	})
+
+	t.Run("Typing is relayed to others", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, DefaultPresenceTimeout, DefaultTaskLockTimeout)
+		alice := hub.Join("board", "alice")
+		defer alice.Close()
+		bob := hub.Join("board", "bob")
+		defer bob.Close()
+		receiveMessage(t, alice)
+		receiveMessage(t, alice)
+		receiveMessage(t, bob)
+		hub.SetTyping(alice, "task", "description", true)
+		if typing := receiveMessage(t, bob); typing.Type != models.SessionMessageTypingStarted ||
+			typing.Viewer.UserID != "alice" || typing.TaskID != "task" || typing.Field != "description" {
+			t.Fatalf("got message %+v", typing)
+		}
+		select {
+		case message := <-alice.Messages():
+			t.Fatalf("got own typing message %+v", message)
+		default:
+		}
+	})
+
+	t.Run("Task is locked by one session", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, DefaultPresenceTimeout, time.Minute)
+		alice := hub.Join("board", "alice")
+		defer alice.Close()
+		bob := hub.Join("board", "bob")
+		receiveMessage(t, alice)
+		receiveMessage(t, alice)
+		receiveMessage(t, bob)
+		lock, lockErr := hub.AcquireTaskLock(alice, "task")
+		if lockErr != nil || lock.UserID != "alice" || !lock.ExpiresAt.Equal(clock.now.Add(time.Minute)) {
+			t.Fatalf("got lock %+v, error %v", lock, lockErr)
+		}
+		receiveMessage(t, alice)
+		if acquired := receiveMessage(t, bob); acquired.Type != models.SessionMessageLockAcquired ||
+			acquired.Lock.TaskID != "task" {
+			t.Fatalf("got message %+v", acquired)
+		}
+		held, lockErr := hub.AcquireTaskLock(bob, "task")
+		if !errors.Is(lockErr, ErrorTaskLocked) || held.UserID != "alice" {
+			t.Fatalf("got lock %+v, error %v", held, lockErr)
+		}
+		if releaseErr := hub.ReleaseTaskLock(bob, "task"); releaseErr == nil {
+			t.Fatal("lock of another session is released")
+		}
+		if _, renewErr := hub.AcquireTaskLock(alice, "task"); renewErr != nil {
+			t.Fatal(renewErr)
+		}
+		receiveMessage(t, alice)
+		receiveMessage(t, bob)
+		alice.Close()
+		if released := receiveMessage(t, bob); released.Type != models.SessionMessageLockReleased ||
+			released.Lock.TaskID != "task" {
+			t.Fatalf("got message %+v", released)
+		}
+		if left := receiveMessage(t, bob); left.Type != models.SessionMessagePresenceLeft {
+			t.Fatalf("got message %+v", left)
+		}
+		if _, lockErr := hub.AcquireTaskLock(bob, "task"); lockErr != nil {
+			t.Fatal(lockErr)
+		}
+		bob.Close()
+	})
+
+	t.Run("Silent sessions and expired locks are pruned", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, time.Minute, 2*time.Minute)
+		alice := hub.Join("board", "alice")
+		bob := hub.Join("board", "bob")
+		defer bob.Close()
+		receiveMessage(t, alice)
+		receiveMessage(t, alice)
+		receiveMessage(t, bob)
+		if _, lockErr := hub.AcquireTaskLock(bob, "task"); lockErr != nil {
+			t.Fatal(lockErr)
+		}
+		receiveMessage(t, alice)
+		receiveMessage(t, bob)
+		clock.now = clock.now.Add(90 * time.Second)
+		bob.Touch()
+		hub.PruneIdle()
+		for range alice.Messages() {
+		}
+		if !errors.Is(alice.Err(), ErrorSessionTimedOut) {
+			t.Fatalf("got error %v of silent session", alice.Err())
+		}
+		if left := receiveMessage(t, bob); left.Type != models.SessionMessagePresenceLeft {
+			t.Fatalf("got message %+v", left)
+		}
+		clock.now = clock.now.Add(30 * time.Second)
+		bob.Touch()
+		hub.PruneIdle()
+		if released := receiveMessage(t, bob); released.Type != models.SessionMessageLockReleased {
+			t.Fatalf("got message %+v", released)
+		}
+	})
+
+	t.Run("Slow session is dropped", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, DefaultPresenceTimeout, DefaultTaskLockTimeout)
+		slow := hub.Join("board", "slow")
+		fast := hub.Join("board", "fast")
+		defer fast.Close()
+		receiveMessage(t, fast)
+		for i := 0; i < sessionQueueSize; i++ {
+			hub.SetTyping(fast, "task", "name", i%2 == 0)
+		}
+		received := 0
+		for range slow.Messages() {
+			received++
+		}
+		if received != sessionQueueSize || !errors.Is(slow.Err(), ErrorSessionTooSlow) {
+			t.Fatalf("got %d messages and error %v", received, slow.Err())
+		}
+		if left := receiveMessage(t, fast); left.Type != models.SessionMessagePresenceLeft {
+			t.Fatalf("got message %+v", left)
+		}
+		slow.Close()
+	})
+
+	t.Run("Closed hub ends sessions", func(t *testing.T) {
+		hub := NewBoardSessionHub(clock, DefaultPresenceTimeout, DefaultTaskLockTimeout)
+		session := hub.Join("board", "alice")
+		hub.Close()
+		for range session.Messages() {
+		}
+		if !errors.Is(session.Err(), ErrorSessionHubClosed) {
+			t.Fatalf("got error %v", session.Err())
+		}
+		late := hub.Join("board", "bob")
+		if _, ok := <-late.Messages(); ok || !errors.Is(late.Err(), ErrorSessionHubClosed) {
+			t.Fatal("session of closed hub is open")
+		}
+	})
+}
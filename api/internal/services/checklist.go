@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	ChecklistService struct {
+		interfaces.ChecklistItemRepository
+		interfaces.Transactor
+		*BoardMemberService
+	}
+	CreateChecklistItemData struct {
+		Text string `json:"text" validate:"required,max=500,trimmed"`
+		// AssigneeID is board member responsible for item, item isn't assigned if it's empty
+		AssigneeID sqlddl.ID `json:"assignee_id"`
+	}
+	UpdateChecklistItemData struct {
+		Text string `json:"text" validate:"omitempty,max=500,trimmed"`
+		// Done ticks or unticks item, it's kept if nil
+		Done *bool `json:"done"`
+		// AssigneeID is new assignee of item, it's kept if nil and unassigned if empty
+		AssigneeID *sqlddl.ID `json:"assignee_id"`
+	}
+	ReorderChecklistItemsData struct {
+		ItemIDs []sqlddl.ID `json:"item_ids" validate:"required"`
+	}
+)
+
+var (
+	checklistItemNotExistsErr = errors.New("checklist item does not exist")
+	checklistItemsMismatchErr = errors.New("provided items must match all items of task checklist")
+	ErrorAssigneeNotMember    = errors.New("assignee is not a member of board")
+)
+
+func (ucid *UpdateChecklistItemData) ToUpdateChecklistItemModel() *models.UpdateChecklistItem {
+	var model models.UpdateChecklistItem
+	if ucid.Text != "" {
+		model.Text = &ucid.Text
+	}
+	model.Done = ucid.Done
+	model.AssigneeID = ucid.AssigneeID
+	return &model
+}
+
+func NewChecklistService(
+	repo interfaces.ChecklistItemRepository,
+	transactor interfaces.Transactor,
+	bms *BoardMemberService,
+) *ChecklistService {
+	return &ChecklistService{repo, transactor, bms}
+}
+
+func (cs *ChecklistService) ListChecklistItems(ctx context.Context, task *models.Task) ([]models.ChecklistItem, error) {
+	items, searchErr := cs.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+	return items, searchErr
+}
+
+// FindChecklistItemByID searches for checklist item and checks it belongs to provided task
+func (cs *ChecklistService) FindChecklistItemByID(
+	ctx context.Context,
+	task *models.Task,
+	itemId sqlddl.ID,
+) (*models.ChecklistItem, error) {
+	item, searchErr := cs.ChecklistItemRepository.FindByID(ctx, itemId)
+	if searchErr != nil || item.TaskID != task.ID {
+		return nil, checklistItemNotExistsErr
+	}
+	return item, nil
+}
+
+// CreateChecklistItem adds new item to the end of task checklist
+func (cs *ChecklistService) CreateChecklistItem(
+	ctx context.Context,
+	task *models.Task,
+	d *CreateChecklistItemData,
+) (*models.ChecklistItem, error) {
+	var assigneeId *sqlddl.ID
+	if d.AssigneeID != "" {
+		if assigneeErr := cs.checkAssignee(ctx, task.BoardID, d.AssigneeID); assigneeErr != nil {
+			return nil, assigneeErr
+		}
+		assigneeId = &d.AssigneeID
+	}
+	id := sqlddl.ID(identifier.GenerateUUID())
+	txErr := cs.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, searchErr := cs.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+		if searchErr != nil {
+			return searchErr
+		}
+		return cs.ChecklistItemRepository.Create(ctx, &models.ChecklistItem{
+			Model:      models.Model{ID: id},
+			TaskID:     task.ID,
+			Text:       d.Text,
+			AssigneeID: assigneeId,
+			Order:      len(items) + 1,
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	createdItem, searchErr := cs.ChecklistItemRepository.FindByID(ctx, id)
+	return createdItem, searchErr
+}
+
+// UpdateChecklistItem changes text, assignee of item or ticks it
+func (cs *ChecklistService) UpdateChecklistItem(
+	ctx context.Context,
+	task *models.Task,
+	itemId sqlddl.ID,
+	d *UpdateChecklistItemData,
+) (*models.ChecklistItem, error) {
+	if _, searchErr := cs.FindChecklistItemByID(ctx, task, itemId); searchErr != nil {
+		return nil, searchErr
+	}
+	if d.AssigneeID != nil && *d.AssigneeID != "" {
+		if assigneeErr := cs.checkAssignee(ctx, task.BoardID, *d.AssigneeID); assigneeErr != nil {
+			return nil, assigneeErr
+		}
+	}
+	updateErr := cs.ChecklistItemRepository.Update(ctx, itemId, d.ToUpdateChecklistItemModel())
+	if updateErr != nil {
+		return nil, updateErr
+	}
+	updatedItem, searchErr := cs.ChecklistItemRepository.FindByID(ctx, itemId)
+	return updatedItem, searchErr
+}
+
+// ReorderChecklistItems changes order of all task checklist items, provided identifiers must match existing items
+func (cs *ChecklistService) ReorderChecklistItems(
+	ctx context.Context,
+	task *models.Task,
+	d *ReorderChecklistItemsData,
+) ([]models.ChecklistItem, error) {
+	items, searchErr := cs.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if len(items) != len(d.ItemIDs) {
+		return nil, checklistItemsMismatchErr
+	}
+	taskItemIds := make(map[sqlddl.ID]bool, len(items))
+	for _, item := range items {
+		taskItemIds[item.ID] = true
+	}
+	for _, itemId := range d.ItemIDs {
+		if !taskItemIds[itemId] {
+			return nil, checklistItemsMismatchErr
+		}
+		delete(taskItemIds, itemId)
+	}
+	if reorderErr := cs.ChecklistItemRepository.Reorder(ctx, task.ID, d.ItemIDs); reorderErr != nil {
+		return nil, reorderErr
+	}
+	reorderedItems, searchErr := cs.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+	return reorderedItems, searchErr
+}
+
+// DeleteChecklistItem removes item from task checklist, remaining items are renumbered
+func (cs *ChecklistService) DeleteChecklistItem(ctx context.Context, task *models.Task, itemId sqlddl.ID) error {
+	if _, searchErr := cs.FindChecklistItemByID(ctx, task, itemId); searchErr != nil {
+		return searchErr
+	}
+	return cs.WithinTransaction(ctx, func(ctx context.Context) error {
+		if deleteErr := cs.ChecklistItemRepository.Delete(ctx, itemId); deleteErr != nil {
+			return deleteErr
+		}
+		items, searchErr := cs.ChecklistItemRepository.FindAllByTaskID(ctx, task.ID)
+		if searchErr != nil {
+			return searchErr
+		}
+		remainingIds := make([]sqlddl.ID, 0, len(items))
+		for _, item := range items {
+			remainingIds = append(remainingIds, item.ID)
+		}
+		return cs.ChecklistItemRepository.Reorder(ctx, task.ID, remainingIds)
+	})
+}
+
+// checkAssignee checks user can be assigned to checklist item of board task
+func (cs *ChecklistService) checkAssignee(ctx context.Context, boardId, userId sqlddl.ID) error {
+	if _, memberErr := cs.BoardMemberService.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		return ErrorAssigneeNotMember
+	}
+	return nil
+}
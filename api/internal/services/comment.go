@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	CommentService struct {
+		interfaces.CommentRepository
+		*BoardMemberService
+	}
+	CreateCommentData struct {
+		Body string `json:"body" validate:"required,max=5000,trimmed"`
+		// ParentID is comment which new one replies to, replies to replies are attached to top level comment
+		ParentID sqlddl.ID `json:"parent_id"`
+	}
+	UpdateCommentData struct {
+		Body string `json:"body" validate:"required,max=5000,trimmed"`
+	}
+)
+
+var (
+	ErrorNotBoardMember       = errors.New("user is not a member of the board")
+	ErrorCommentNotAllowed    = errors.New("only author or board manager can change comment")
+	commentNotExistsErr       = errors.New("comment does not exist")
+	parentCommentNotInTaskErr = errors.New("replied comment belongs to another task")
+)
+
+func NewCommentService(repo interfaces.CommentRepository, bms *BoardMemberService) *CommentService {
+	return &CommentService{repo, bms}
+}
+
+// CreateComment adds comment or reply to task, requester must be member of task's board
+func (cs *CommentService) CreateComment(ctx context.Context, task *models.Task, d *CreateCommentData) (*models.Comment, error) {
+	userId, memberErr := cs.requireBoardMember(ctx, task.BoardID)
+	if memberErr != nil {
+		return nil, memberErr
+	}
+	var parentId *sqlddl.ID
+	if d.ParentID != "" {
+		parent, searchErr := cs.FindCommentByID(ctx, task, d.ParentID)
+		if searchErr != nil {
+			return nil, parentCommentNotInTaskErr
+		}
+		parentId = &parent.ID
+		if parent.ParentID != nil {
+			parentId = parent.ParentID
+		}
+	}
+	id := sqlddl.ID(identifier.GenerateUUID())
+	creationErr := cs.CommentRepository.Create(ctx, &models.Comment{
+		Model:    models.Model{ID: id},
+		TaskID:   task.ID,
+		AuthorID: userId,
+		ParentID: parentId,
+		Body:     d.Body,
+	})
+	if creationErr != nil {
+		return nil, creationErr
+	}
+	createdComment, searchErr := cs.CommentRepository.FindByID(ctx, id)
+	return createdComment, searchErr
+}
+
+// UpdateComment changes text of comment, allowed for its author and board managers
+func (cs *CommentService) UpdateComment(
+	ctx context.Context,
+	task *models.Task,
+	commentId sqlddl.ID,
+	d *UpdateCommentData,
+) (*models.Comment, error) {
+	if _, accessErr := cs.requireCommentManagement(ctx, task, commentId); accessErr != nil {
+		return nil, accessErr
+	}
+	if updateErr := cs.CommentRepository.UpdateBody(ctx, commentId, d.Body); updateErr != nil {
+		return nil, updateErr
+	}
+	updatedComment, searchErr := cs.CommentRepository.FindByID(ctx, commentId)
+	return updatedComment, searchErr
+}
+
+// DeleteComment removes comment with its replies, allowed for its author and board managers
+func (cs *CommentService) DeleteComment(ctx context.Context, task *models.Task, commentId sqlddl.ID) error {
+	if _, accessErr := cs.requireCommentManagement(ctx, task, commentId); accessErr != nil {
+		return accessErr
+	}
+	deleteErr := cs.CommentRepository.Delete(ctx, commentId)
+	return deleteErr
+}
+
+// ListTaskComments returns top level comments of task with their replies
+func (cs *CommentService) ListTaskComments(ctx context.Context, task *models.Task) ([]models.Comment, error) {
+	if _, memberErr := cs.requireBoardMember(ctx, task.BoardID); memberErr != nil {
+		return nil, memberErr
+	}
+	comments, searchErr := cs.CommentRepository.FindAllByTaskID(ctx, task.ID)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	var threads []models.Comment
+	threadIndexes := make(map[sqlddl.ID]int)
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			threadIndexes[comment.ID] = len(threads)
+			threads = append(threads, comment)
+		}
+	}
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			continue
+		}
+		if index, ok := threadIndexes[*comment.ParentID]; ok {
+			threads[index].Replies = append(threads[index].Replies, comment)
+		}
+	}
+	return threads, nil
+}
+
+// GetTaskComment returns comment of task if requester is member of task board
+func (cs *CommentService) GetTaskComment(ctx context.Context, task *models.Task, commentId sqlddl.ID) (*models.Comment, error) {
+	if _, memberErr := cs.requireBoardMember(ctx, task.BoardID); memberErr != nil {
+		return nil, memberErr
+	}
+	return cs.FindCommentByID(ctx, task, commentId)
+}
+
+// FindCommentByID searches for comment and checks it's written to provided task
+func (cs *CommentService) FindCommentByID(ctx context.Context, task *models.Task, commentId sqlddl.ID) (*models.Comment, error) {
+	comment, searchErr := cs.CommentRepository.FindByID(ctx, commentId)
+	if searchErr != nil || comment.TaskID != task.ID {
+		return nil, commentNotExistsErr
+	}
+	return comment, nil
+}
+
+// requireBoardMember checks requester is member of board and returns his identifier
+func (cs *CommentService) requireBoardMember(ctx context.Context, boardId sqlddl.ID) (sqlddl.ID, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return "", userIdErr
+	}
+	if _, memberErr := cs.BoardMemberService.FindBoardMemberByUserID(ctx, boardId, userId); memberErr != nil {
+		return "", ErrorNotBoardMember
+	}
+	return userId, nil
+}
+
+// requireCommentManagement checks requester is author of comment or allowed to manage comments of others
+func (cs *CommentService) requireCommentManagement(
+	ctx context.Context,
+	task *models.Task,
+	commentId sqlddl.ID,
+) (*models.Comment, error) {
+	userId, memberErr := cs.requireBoardMember(ctx, task.BoardID)
+	if memberErr != nil {
+		return nil, memberErr
+	}
+	comment, searchErr := cs.FindCommentByID(ctx, task, commentId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	if comment.AuthorID != userId && !cs.BoardMemberService.Can(ctx, userId, task.BoardID, access.PermissionCommentManageAny) {
+		return nil, ErrorCommentNotAllowed
+	}
+	return comment, nil
+}
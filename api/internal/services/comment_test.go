@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// memoryCommentRepository keeps comments in memory in order of their creation
+type memoryCommentRepository struct {
+	comments []*models.Comment
+}
+
+func (mcr *memoryCommentRepository) Create(_ context.Context, comment *models.Comment) error {
+	mcr.comments = append(mcr.comments, comment)
+	return nil
+}
+
+func (mcr *memoryCommentRepository) UpdateBody(_ context.Context, commentId sqlddl.ID, body string) error {
+	for _, comment := range mcr.comments {
+		if comment.ID == commentId {
+			comment.Body = body
+		}
+	}
+	return nil
+}
+
+func (mcr *memoryCommentRepository) FindByID(_ context.Context, commentId sqlddl.ID) (*models.Comment, error) {
+	for _, comment := range mcr.comments {
+		if comment.ID == commentId {
+			found := *comment
+			return &found, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (mcr *memoryCommentRepository) FindAllByTaskID(_ context.Context, taskId sqlddl.ID) ([]models.Comment, error) {
+	var comments []models.Comment
+	for _, comment := range mcr.comments {
+		if comment.TaskID == taskId {
+			comments = append(comments, *comment)
+		}
+	}
+	return comments, nil
+}
+
+func (mcr *memoryCommentRepository) Delete(_ context.Context, commentId sqlddl.ID) error {
+	var kept []*models.Comment
+	for _, comment := range mcr.comments {
+		if comment.ID != commentId && (comment.ParentID == nil || *comment.ParentID != commentId) {
+			kept = append(kept, comment)
+		}
+	}
+	mcr.comments = kept
+	return nil
+}
+
+// newCommentService creates service for board of regular members author and other, manager and admin
+// whose custom role doesn't grant management of comments, author has written comment to task
+func newCommentService() (*CommentService, *memoryCommentRepository, *models.Task) {
+	bms, _ := newOwnershipService(map[sqlddl.ID]access.Role{
+		"author":  access.RoleRegular,
+		"other":   access.RoleRegular,
+		"manager": access.RoleManager,
+		"admin":   adminRole,
+	})
+	task := &models.Task{Model: models.Model{ID: "task"}, BoardID: ownershipBoardId}
+	repo := &memoryCommentRepository{comments: []*models.Comment{
+		{Model: models.Model{ID: "comment"}, TaskID: task.ID, AuthorID: "author", Body: "first"},
+	}}
+	return NewCommentService(repo, bms), repo, task
+}
+
+func TestCommentServiceManagement(t *testing.T) {
+	cases := []struct {
+		name     string
+		userId   sqlddl.ID
+		expected error
+	}{
+		{"Author changes own comment", "author", nil},
+		{"Member can't change comment of another", "other", ErrorCommentNotAllowed},
+		{"Manager changes comment of another", "manager", nil},
+		{"Role without comment management can't change comment of another", "admin", ErrorCommentNotAllowed},
+		{"Not member can't change comment", "stranger", ErrorNotBoardMember},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cs, repo, task := newCommentService()
+			ctx := withUser(c.userId)
+			_, updateErr := cs.UpdateComment(ctx, task, "comment", &UpdateCommentData{Body: "changed"})
+			if !errors.Is(updateErr, c.expected) {
+				t.Fatalf("got update error %v, expected %v", updateErr, c.expected)
+			}
+			expectedBody := "first"
+			if c.expected == nil {
+				expectedBody = "changed"
+			}
+			if body := repo.comments[0].Body; body != expectedBody {
+				t.Fatalf("got body %q, expected %q", body, expectedBody)
+			}
+			if deleteErr := cs.DeleteComment(ctx, task, "comment"); !errors.Is(deleteErr, c.expected) {
+				t.Fatalf("got delete error %v, expected %v", deleteErr, c.expected)
+			}
+			if deleted := len(repo.comments) == 0; deleted != (c.expected == nil) {
+				t.Fatalf("got comments %v after deletion", repo.comments)
+			}
+		})
+	}
+}
+
+func TestCommentServiceFlattensReplies(t *testing.T) {
+	cs, _, task := newCommentService()
+	ctx := withUser("other")
+	reply, replyErr := cs.CreateComment(ctx, task, &CreateCommentData{Body: "reply", ParentID: "comment"})
+	if replyErr != nil {
+		t.Fatal(replyErr)
+	}
+	if reply.ParentID == nil || *reply.ParentID != "comment" {
+		t.Fatalf("got reply parent %v, expected comment", reply.ParentID)
+	}
+	nested, nestedErr := cs.CreateComment(ctx, task, &CreateCommentData{Body: "nested", ParentID: reply.ID})
+	if nestedErr != nil {
+		t.Fatal(nestedErr)
+	}
+	if nested.ParentID == nil || *nested.ParentID != "comment" {
+		t.Fatalf("got reply to reply parent %v, expected top level comment", nested.ParentID)
+	}
+
+	threads, listErr := cs.ListTaskComments(ctx, task)
+	if listErr != nil {
+		t.Fatal(listErr)
+	}
+	if len(threads) != 1 || len(threads[0].Replies) != 2 {
+		t.Fatalf("got threads %+v, expected one thread with two replies", threads)
+	}
+	for _, threadReply := range threads[0].Replies {
+		if len(threadReply.Replies) != 0 {
+			t.Fatalf("got nested replies %+v", threadReply.Replies)
+		}
+	}
+
+	t.Run("Reply to comment of another task", func(t *testing.T) {
+		anotherTask := &models.Task{Model: models.Model{ID: "another"}, BoardID: ownershipBoardId}
+		_, creationErr := cs.CreateComment(ctx, anotherTask, &CreateCommentData{Body: "reply", ParentID: "comment"})
+		if !errors.Is(creationErr, parentCommentNotInTaskErr) {
+			t.Fatalf("got error %v, expected %v", creationErr, parentCommentNotInTaskErr)
+		}
+	})
+}
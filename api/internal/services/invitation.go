@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+// DefaultInvitationTTL is how long invitation may be accepted if no expiry is provided
+const DefaultInvitationTTL = 7 * 24 * time.Hour
+
+type (
+	// InvitationService manages invitations to boards, accepted invitation makes invitee a board member
+	InvitationService struct {
+		interfaces.InvitationRepository
+		interfaces.Transactor
+		*BoardMemberService
+		Clock
+	}
+	CreateInvitationData struct {
+		// Email is address of invitee, shareable link invitation is created if it's empty
+		Email string `json:"email" validate:"omitempty,email,max=255"`
+		// Role is built-in or custom role of board given to invitee
+		Role access.Role `json:"role" validate:"required,max=100"`
+		// ExpiresAt is moment invitation expires at, DefaultInvitationTTL is used if it's nil
+		ExpiresAt *time.Time `json:"expires_at"`
+		// MaxUses limits acceptances of link invitation, there is no limit if it's 0,
+		// email invitation may be accepted once only
+		MaxUses int `json:"max_uses" validate:"min=0"`
+	}
+	// RespondInvitationData identifies invitation which requester accepts or declines
+	RespondInvitationData struct {
+		// InvitationID identifies invitation addressed to email of requester
+		InvitationID sqlddl.ID `json:"invitation_id" validate:"required_without=Token,excluded_with=Token"`
+		// Token is secret of shareable link invitation
+		Token string `json:"token" validate:"required_without=InvitationID"`
+	}
+)
+
+var (
+	ErrorInvitationNotExists = errors.New("invitation does not exist")
+	ErrorInvitationClosed    = errors.New("invitation is revoked, declined, expired or used up")
+	invitationExpiryErr      = errors.New("invitation can't expire in the past")
+)
+
+func NewInvitationService(
+	repo interfaces.InvitationRepository,
+	transactor interfaces.Transactor,
+	bms *BoardMemberService,
+	clock Clock,
+) *InvitationService {
+	return &InvitationService{repo, transactor, bms, clock}
+}
+
+// CreateInvitation creates invitation to board on behalf of requester, token of link invitation
+// is returned only here since just its hash is kept.
+// Requester may invite only with role whose permissions it has itself
+func (is *InvitationService) CreateInvitation(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *CreateInvitationData,
+) (*models.Invitation, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	if d.Role == access.RoleOwner {
+		return nil, ErrorOwnershipTransferOnly
+	}
+	// invitee gets role on acceptance without further checks, so it's limited by inviter permissions here
+	if grantErr := is.checkRoleGrantable(ctx, boardId, d.Role); grantErr != nil {
+		return nil, grantErr
+	}
+	now := is.Now()
+	expiresAt := now.Add(DefaultInvitationTTL)
+	if d.ExpiresAt != nil {
+		if !d.ExpiresAt.After(now) {
+			return nil, invitationExpiryErr
+		}
+		expiresAt = *d.ExpiresAt
+	}
+	invitation := &models.Invitation{
+		Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		BoardID:   boardId,
+		InviterID: &userId,
+		Role:      d.Role,
+		ExpiresAt: expiresAt,
+		MaxUses:   d.MaxUses,
+	}
+	if d.Email != "" {
+		if invitee, searchErr := is.UserService.FindByEmail(ctx, d.Email); searchErr == nil {
+			if _, memberErr := is.FindBoardMemberByUserID(ctx, boardId, invitee.ID); memberErr == nil {
+				return nil, ErrorMemberAlreadyExists
+			}
+		}
+		invitation.Email = strings.ToLower(d.Email)
+		invitation.MaxUses = 1
+	} else {
+		token, tokenErr := identifier.GenerateToken()
+		if tokenErr != nil {
+			return nil, tokenErr
+		}
+		invitation.Token = token
+		invitation.TokenHash = identifier.HashToken(token)
+	}
+	if creationErr := is.InvitationRepository.Create(ctx, invitation); creationErr != nil {
+		return nil, creationErr
+	}
+	createdInvitation, searchErr := is.InvitationRepository.FindByID(ctx, invitation.ID)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	createdInvitation.Token = invitation.Token
+	createdInvitation.Status = createdInvitation.ComputeStatus(now)
+	return createdInvitation, nil
+}
+
+func (is *InvitationService) ListBoardInvitations(ctx context.Context, boardId sqlddl.ID) ([]models.Invitation, error) {
+	invitations, searchErr := is.InvitationRepository.FindAllByBoardID(ctx, boardId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	return is.withStatuses(invitations), nil
+}
+
+// FindBoardInvitationByID searches for invitation of board with audit of responses to it
+func (is *InvitationService) FindBoardInvitationByID(
+	ctx context.Context,
+	boardId,
+	invitationId sqlddl.ID,
+) (*models.Invitation, error) {
+	invitation, searchErr := is.InvitationRepository.FindByID(ctx, invitationId)
+	if searchErr != nil || invitation.BoardID != boardId {
+		return nil, ErrorInvitationNotExists
+	}
+	responses, responsesErr := is.InvitationRepository.FindResponses(ctx, invitationId)
+	if responsesErr != nil {
+		return nil, responsesErr
+	}
+	invitation.Responses = responses
+	invitation.Status = invitation.ComputeStatus(is.Now())
+	return invitation, nil
+}
+
+// RevokeInvitation cancels pending invitation of board on behalf of requester
+func (is *InvitationService) RevokeInvitation(ctx context.Context, boardId, invitationId sqlddl.ID) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	return is.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := is.InvitationRepository.Lock(ctx, invitationId); lockErr != nil {
+			return lockErr
+		}
+		invitation, searchErr := is.InvitationRepository.FindByID(ctx, invitationId)
+		if searchErr != nil || invitation.BoardID != boardId {
+			return ErrorInvitationNotExists
+		}
+		now := is.Now()
+		if invitation.ComputeStatus(now) != models.InvitationPending {
+			return ErrorInvitationClosed
+		}
+		return is.InvitationRepository.Revoke(ctx, invitationId, userId, now)
+	})
+}
+
+// ListUserInvitations returns pending invitations addressed to email of requester
+func (is *InvitationService) ListUserInvitations(ctx context.Context) ([]models.Invitation, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	user, userErr := is.UserService.FindByID(ctx, userId)
+	if userErr != nil {
+		return nil, userErr
+	}
+	invitations, searchErr := is.InvitationRepository.FindPendingByEmail(ctx, strings.ToLower(user.Email), is.Now())
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	return is.withStatuses(invitations), nil
+}
+
+// AcceptInvitation makes requester a member of invited board with role of invitation
+func (is *InvitationService) AcceptInvitation(ctx context.Context, d *RespondInvitationData) (*models.BoardMember, error) {
+	var member *models.BoardMember
+	txErr := is.respond(ctx, d, true, func(ctx context.Context, userId sqlddl.ID, invitation *models.Invitation) error {
+		createdMember, creationErr := is.addBoardMember(ctx, invitation.BoardID, &CreateBoardMemberData{
+			UserId: userId,
+			Role:   invitation.Role,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		member = createdMember
+		return is.InvitationRepository.IncrementUses(ctx, invitation.ID)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	is.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventMemberAdded, member.BoardID, member))
+	return member, nil
+}
+
+// DeclineInvitation refuses invitation on behalf of requester, declined email invitation can't be accepted anymore
+func (is *InvitationService) DeclineInvitation(ctx context.Context, d *RespondInvitationData) error {
+	return is.respond(ctx, d, false, func(ctx context.Context, _ sqlddl.ID, invitation *models.Invitation) error {
+		if invitation.IsLink() {
+			return nil
+		}
+		return is.InvitationRepository.Decline(ctx, invitation.ID, is.Now())
+	})
+}
+
+// respond checks invitation is pending and addressed to requester, then applies response and records it
+func (is *InvitationService) respond(
+	ctx context.Context,
+	d *RespondInvitationData,
+	accepted bool,
+	apply func(ctx context.Context, userId sqlddl.ID, invitation *models.Invitation) error,
+) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	invitation, searchErr := is.findAddressedInvitation(ctx, userId, d)
+	if searchErr != nil {
+		return searchErr
+	}
+	return is.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock prevents concurrent acceptances from exceeding usage limit
+		if lockErr := is.InvitationRepository.Lock(ctx, invitation.ID); lockErr != nil {
+			return lockErr
+		}
+		lockedInvitation, searchErr := is.InvitationRepository.FindByID(ctx, invitation.ID)
+		if searchErr != nil {
+			return ErrorInvitationNotExists
+		}
+		if lockedInvitation.ComputeStatus(is.Now()) != models.InvitationPending {
+			return ErrorInvitationClosed
+		}
+		if applyErr := apply(ctx, userId, lockedInvitation); applyErr != nil {
+			return applyErr
+		}
+		return is.InvitationRepository.CreateResponse(ctx, &models.InvitationResponse{
+			Model:        models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			InvitationID: lockedInvitation.ID,
+			UserID:       userId,
+			Accepted:     accepted,
+		})
+	})
+}
+
+// findAddressedInvitation searches for link invitation by its token or for email invitation
+// addressed to user, invitations addressed to someone else are treated as not existing
+func (is *InvitationService) findAddressedInvitation(
+	ctx context.Context,
+	userId sqlddl.ID,
+	d *RespondInvitationData,
+) (*models.Invitation, error) {
+	if d.Token != "" {
+		invitation, searchErr := is.InvitationRepository.FindByTokenHash(ctx, identifier.HashToken(d.Token))
+		if searchErr != nil {
+			return nil, ErrorInvitationNotExists
+		}
+		return invitation, nil
+	}
+	invitation, searchErr := is.InvitationRepository.FindByID(ctx, d.InvitationID)
+	if searchErr != nil || invitation.IsLink() {
+		return nil, ErrorInvitationNotExists
+	}
+	user, userErr := is.UserService.FindByID(ctx, userId)
+	if userErr != nil || !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, ErrorInvitationNotExists
+	}
+	return invitation, nil
+}
+
+// withStatuses fills statuses of invitations computed at current moment
+func (is *InvitationService) withStatuses(invitations []models.Invitation) []models.Invitation {
+	now := is.Now()
+	for i := range invitations {
+		invitations[i].Status = invitations[i].ComputeStatus(now)
+	}
+	return invitations
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	LabelService struct {
+		interfaces.LabelRepository
+	}
+	CreateLabelData struct {
+		Name  string `json:"name" validate:"required,max=50,trimmed"`
+		Color string `json:"color" validate:"required,hexcolor"`
+	}
+	UpdateLabelData struct {
+		Name  string `json:"name" validate:"omitempty,max=50,trimmed"`
+		Color string `json:"color" validate:"omitempty,hexcolor"`
+	}
+)
+
+var labelAlreadyExistsErr = errors.New("label with this name already exists")
+
+func (uld *UpdateLabelData) ToUpdateLabelModel() *models.UpdateLabel {
+	var model models.UpdateLabel
+	if uld.Name != "" {
+		model.Name = &uld.Name
+	}
+	if uld.Color != "" {
+		model.Color = &uld.Color
+	}
+	return &model
+}
+
+func NewLabelService(repo interfaces.LabelRepository) *LabelService {
+	return &LabelService{repo}
+}
+
+func (ls *LabelService) ListBoardLabels(ctx context.Context, boardId sqlddl.ID) ([]models.Label, error) {
+	labels, searchErr := ls.LabelRepository.FindAllByBoardID(ctx, boardId)
+	return labels, searchErr
+}
+
+// FindBoardLabelByID searches for label and checks it belongs to provided board
+func (ls *LabelService) FindBoardLabelByID(ctx context.Context, boardId, labelId sqlddl.ID) (*models.Label, error) {
+	label, searchErr := ls.LabelRepository.FindByID(ctx, labelId)
+	if searchErr != nil || label.BoardID != boardId {
+		return nil, labelNotExistsErr
+	}
+	return label, nil
+}
+
+func (ls *LabelService) CreateBoardLabel(ctx context.Context, boardId sqlddl.ID, d *CreateLabelData) (*models.Label, error) {
+	if _, notExistErr := ls.LabelRepository.FindByName(ctx, boardId, d.Name); notExistErr == nil {
+		return nil, labelAlreadyExistsErr
+	}
+	id := sqlddl.ID(identifier.GenerateUUID())
+	creationErr := ls.LabelRepository.Create(ctx, &models.Label{
+		Model:   models.Model{ID: id},
+		BoardID: boardId,
+		Name:    d.Name,
+		Color:   d.Color,
+	})
+	if creationErr != nil {
+		return nil, creationErr
+	}
+	createdLabel, searchErr := ls.LabelRepository.FindByID(ctx, id)
+	return createdLabel, searchErr
+}
+
+func (ls *LabelService) UpdateBoardLabel(
+	ctx context.Context,
+	boardId,
+	labelId sqlddl.ID,
+	d *UpdateLabelData,
+) (*models.Label, error) {
+	if _, searchErr := ls.FindBoardLabelByID(ctx, boardId, labelId); searchErr != nil {
+		return nil, searchErr
+	}
+	if d.Name != "" {
+		sameNameLabel, notExistErr := ls.LabelRepository.FindByName(ctx, boardId, d.Name)
+		if notExistErr == nil && sameNameLabel.ID != labelId {
+			return nil, labelAlreadyExistsErr
+		}
+	}
+	if updateErr := ls.LabelRepository.Update(ctx, labelId, d.ToUpdateLabelModel()); updateErr != nil {
+		return nil, updateErr
+	}
+	updatedLabel, searchErr := ls.LabelRepository.FindByID(ctx, labelId)
+	return updatedLabel, searchErr
+}
+
+// DeleteBoardLabel removes label of board, it's detached from every task it was attached to
+func (ls *LabelService) DeleteBoardLabel(ctx context.Context, boardId, labelId sqlddl.ID) error {
+	if _, searchErr := ls.FindBoardLabelByID(ctx, boardId, labelId); searchErr != nil {
+		return searchErr
+	}
+	return ls.LabelRepository.Delete(ctx, labelId)
+}
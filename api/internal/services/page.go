@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/cursor"
+)
+
+// DefaultPageLimit is count of records returned in page when limit isn't provided
+const DefaultPageLimit = 50
+
+// PageData is requested slice of records list
+type PageData struct {
+	Limit  int `json:"limit" validate:"min=0,max=100"`
+	Offset int `json:"offset" validate:"min=0"`
+}
+
+// limit returns page size, DefaultPageLimit if it isn't provided
+func (pd *PageData) limit() int {
+	if pd.Limit == 0 {
+		return DefaultPageLimit
+	}
+	return pd.Limit
+}
+
+// ErrorInvalidCursor is returned for page cursor which wasn't issued by app
+var ErrorInvalidCursor = errors.New("invalid page cursor")
+
+// KeysetPageData is requested page of listing sorted by creation time
+type KeysetPageData struct {
+	// Cursor is token of previous page end, first page is requested if it's empty
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit" validate:"min=0"`
+}
+
+// KeysetPaginator issues and reads signed cursors of keyset pages and keeps their size in bounds
+type KeysetPaginator struct {
+	*cursor.Codec
+	// MaxLimit is max count of records returned in page
+	MaxLimit int
+}
+
+func NewKeysetPaginator(secret string, maxLimit int) *KeysetPaginator {
+	return &KeysetPaginator{cursor.NewCodec(secret), maxLimit}
+}
+
+// limit returns page size which doesn't exceed MaxLimit, DefaultPageLimit is used if it isn't provided
+func (kp *KeysetPaginator) limit(d *KeysetPageData) int {
+	limit := d.Limit
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+	if kp.MaxLimit > 0 && limit > kp.MaxLimit {
+		return kp.MaxLimit
+	}
+	return limit
+}
+
+// after decodes position which requested page starts after, nil for first page
+func (kp *KeysetPaginator) after(d *KeysetPageData) (*models.PageKey, error) {
+	if d.Cursor == "" {
+		return nil, nil
+	}
+	var key models.PageKey
+	if decodeErr := kp.Decode(d.Cursor, &key); decodeErr != nil {
+		return nil, ErrorInvalidCursor
+	}
+	return &key, nil
+}
+
+// next issues cursor of page following the one ended with last record
+func (kp *KeysetPaginator) next(last *models.Model) (string, error) {
+	return kp.Encode(last.PageKey())
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+)
+
+func TestKeysetPaginator(t *testing.T) {
+	paginator := NewKeysetPaginator("secret", 20)
+
+	t.Run("Limit is bounded", func(t *testing.T) {
+		cases := map[int]int{0: 20, 5: 5, 20: 20, 500: 20}
+		for requested, expected := range cases {
+			if limit := paginator.limit(&KeysetPageData{Limit: requested}); limit != expected {
+				t.Fatalf("got limit %d for %d, expected %d", limit, requested, expected)
+			}
+		}
+	})
+
+	t.Run("First page has no position", func(t *testing.T) {
+		after, cursorErr := paginator.after(&KeysetPageData{})
+		if cursorErr != nil || after != nil {
+			t.Fatalf("got %v, %v, expected no position", after, cursorErr)
+		}
+	})
+
+	t.Run("Next page starts after last record", func(t *testing.T) {
+		last := models.Model{ID: "uuid", CreatedAt: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)}
+		nextCursor, encodeErr := paginator.next(&last)
+		if encodeErr != nil {
+			t.Fatalf("unexpected error: %v", encodeErr)
+		}
+		after, cursorErr := paginator.after(&KeysetPageData{Cursor: nextCursor})
+		if cursorErr != nil {
+			t.Fatalf("unexpected error: %v", cursorErr)
+		}
+		if after.ID != last.ID || !after.CreatedAt.Equal(last.CreatedAt) {
+			t.Fatalf("got %+v, expected %+v", after, last.PageKey())
+		}
+	})
+
+	t.Run("Foreign cursor is rejected", func(t *testing.T) {
+		foreignCursor, _ := NewKeysetPaginator("another", 20).next(&models.Model{ID: "uuid"})
+		if _, cursorErr := paginator.after(&KeysetPageData{Cursor: foreignCursor}); !errors.Is(cursorErr, ErrorInvalidCursor) {
+			t.Fatalf("got %v, expected %v", cursorErr, ErrorInvalidCursor)
+		}
+	})
+}
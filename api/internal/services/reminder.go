@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+)
+
+const (
+	// DefaultReminderInterval is period between checks of due tasks
+	DefaultReminderInterval = time.Minute
+	// DefaultReminderWindow is how long before due date task is considered due soon
+	DefaultReminderWindow = 24 * time.Hour
+)
+
+type (
+	// Clock provides current time, it's injected to keep time dependent logic testable
+	Clock interface {
+		Now() time.Time
+	}
+	// SystemClock is Clock which returns real current time
+	SystemClock struct{}
+	// ReminderNotifier delivers reminders about tasks due dates
+	ReminderNotifier interface {
+		NotifyTaskReminder(ctx context.Context, reminder *models.TaskReminder) error
+	}
+	// LogReminderNotifier is ReminderNotifier which writes reminders to log
+	LogReminderNotifier struct{}
+	// ReminderService periodically searches for tasks which are due soon or overdue and emits reminders about them
+	ReminderService struct {
+		interfaces.TaskRepository
+		ReminderNotifier
+		Clock
+		// Interval is period between checks of due tasks
+		Interval time.Duration
+		// Window is how long before due date task is considered due soon
+		Window time.Duration
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+)
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (LogReminderNotifier) NotifyTaskReminder(_ context.Context, reminder *models.TaskReminder) error {
+	log.Printf("Task %s of board %s is %s, due at %s", reminder.Task.ID, reminder.Task.BoardID, reminder.Kind, reminder.Task.DueAt)
+	return nil
+}
+
+func NewReminderService(
+	taskRepository interfaces.TaskRepository,
+	notifier ReminderNotifier,
+	clock Clock,
+	interval,
+	window time.Duration,
+) *ReminderService {
+	return &ReminderService{
+		TaskRepository:   taskRepository,
+		ReminderNotifier: notifier,
+		Clock:            clock,
+		Interval:         interval,
+		Window:           window,
+	}
+}
+
+// Start runs scheduler goroutine which checks due tasks every Interval until Stop is called or ctx is done
+func (rs *ReminderService) Start(ctx context.Context) {
+	ctx, rs.cancel = context.WithCancel(ctx)
+	rs.done = make(chan struct{})
+	go func() {
+		defer close(rs.done)
+		rs.Run(ctx)
+	}()
+}
+
+// Stop stops scheduler goroutine and waits until check in progress is finished
+func (rs *ReminderService) Stop() {
+	if rs.cancel == nil {
+		return
+	}
+	rs.cancel()
+	<-rs.done
+}
+
+// Run checks due tasks every Interval, blocks until ctx is done
+func (rs *ReminderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(rs.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if remindErr := rs.RemindDueTasks(ctx); remindErr != nil && ctx.Err() == nil {
+				log.Println("Reminding about due tasks failed:", remindErr)
+			}
+		}
+	}
+}
+
+// RemindDueTasks emits reminder once for each task which became due soon and once for each task which became overdue
+func (rs *ReminderService) RemindDueTasks(ctx context.Context) error {
+	now := rs.Now()
+	tasks, searchErr := rs.FindAllDueBefore(ctx, now.Add(rs.Window))
+	if searchErr != nil {
+		return searchErr
+	}
+	var remindErrs []error
+	for _, task := range tasks {
+		kind := models.TaskReminderOverdue
+		if task.DueAt.After(now) {
+			kind = models.TaskReminderDueSoon
+			// task has been already reminded as due soon within current window
+			if task.RemindedAt != nil && !task.RemindedAt.Before(task.DueAt.Add(-rs.Window)) {
+				continue
+			}
+		}
+		notifyErr := rs.NotifyTaskReminder(ctx, &models.TaskReminder{
+			Kind:       kind,
+			Task:       task,
+			RemindedAt: now,
+		})
+		if notifyErr != nil {
+			remindErrs = append(remindErrs, notifyErr)
+			continue
+		}
+		if saveErr := rs.SetRemindedAt(ctx, task.ID, now); saveErr != nil {
+			remindErrs = append(remindErrs, saveErr)
+		}
+	}
+	return errors.Join(remindErrs...)
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"go.uber.org/mock/gomock"
+
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/services"
+	"just-kanban/mocks"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (fc fixedClock) Now() time.Time {
+	return fc.now
+}
+
+type recordingNotifier struct {
+	sync.Mutex
+	reminders []models.TaskReminder
+}
+
+func (rn *recordingNotifier) NotifyTaskReminder(_ context.Context, reminder *models.TaskReminder) error {
+	rn.Lock()
+	defer rn.Unlock()
+	rn.reminders = append(rn.reminders, *reminder)
+	return nil
+}
+
+func (rn *recordingNotifier) count() int {
+	rn.Lock()
+	defer rn.Unlock()
+	return len(rn.reminders)
+}
+
+func TestReminderService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	window := time.Hour
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	t.Run("Due soon and overdue tasks are reminded", func(t *testing.T) {
+		mockTaskRepository := mocks.NewMockTaskRepository(ctrl)
+		notifier := &recordingNotifier{}
+		service := services.NewReminderService(mockTaskRepository, notifier, fixedClock{now}, time.Minute, window)
+		mockTaskRepository.EXPECT().FindAllDueBefore(gomock.Any(), now.Add(window)).Return([]models.Task{
+			{Model: models.Model{ID: "soon"}, DueAt: at(30 * time.Minute)},
+			{Model: models.Model{ID: "overdue"}, DueAt: at(-time.Minute)},
+		}, nil)
+		mockTaskRepository.EXPECT().SetRemindedAt(gomock.Any(), gomock.Any(), now).Times(2)
+		if remindErr := service.RemindDueTasks(context.Background()); remindErr != nil {
+			t.Fatalf("unexpected error %v", remindErr)
+		}
+		if len(notifier.reminders) != 2 {
+			t.Fatalf("got %d reminders, expected 2", len(notifier.reminders))
+		}
+		if notifier.reminders[0].Kind != models.TaskReminderDueSoon {
+			t.Fatalf("got %s, expected %s", notifier.reminders[0].Kind, models.TaskReminderDueSoon)
+		}
+		if notifier.reminders[1].Kind != models.TaskReminderOverdue {
+			t.Fatalf("got %s, expected %s", notifier.reminders[1].Kind, models.TaskReminderOverdue)
+		}
+	})
+
+	t.Run("Due soon task is reminded once", func(t *testing.T) {
+		mockTaskRepository := mocks.NewMockTaskRepository(ctrl)
+		notifier := &recordingNotifier{}
+		service := services.NewReminderService(mockTaskRepository, notifier, fixedClock{now}, time.Minute, window)
+		mockTaskRepository.EXPECT().FindAllDueBefore(gomock.Any(), gomock.Any()).Return([]models.Task{
+			{Model: models.Model{ID: "soon"}, DueAt: at(30 * time.Minute), RemindedAt: at(-10 * time.Minute)},
+		}, nil)
+		if remindErr := service.RemindDueTasks(context.Background()); remindErr != nil {
+			t.Fatalf("unexpected error %v", remindErr)
+		}
+		if len(notifier.reminders) != 0 {
+			t.Fatalf("got %d reminders, expected 0", len(notifier.reminders))
+		}
+	})
+
+	t.Run("Scheduler stops cleanly", func(t *testing.T) {
+		mockTaskRepository := mocks.NewMockTaskRepository(ctrl)
+		notifier := &recordingNotifier{}
+		service := services.NewReminderService(mockTaskRepository, notifier, fixedClock{now}, time.Millisecond, window)
+		mockTaskRepository.EXPECT().FindAllDueBefore(gomock.Any(), gomock.Any()).Return([]models.Task{
+			{Model: models.Model{ID: "overdue"}, DueAt: at(-time.Minute)},
+		}, nil).AnyTimes()
+		mockTaskRepository.EXPECT().SetRemindedAt(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+		service.Start(context.Background())
+		deadline := time.Now().Add(time.Second)
+		for notifier.count() == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		service.Stop()
+		if notifier.count() == 0 {
+			t.Fatal("got no reminders before stop")
+		}
+		stoppedCount := notifier.count()
+		time.Sleep(10 * time.Millisecond)
+		if notifier.count() != stoppedCount {
+			t.Fatal("got reminders after stop")
+		}
+	})
+}
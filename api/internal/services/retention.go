@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"just-kanban/internal/repositories/interfaces"
+)
+
+const (
+	// DefaultRetentionInterval is period between purges of expired trash
+	DefaultRetentionInterval = time.Hour
+	// DefaultRetentionPeriod is how long boards and tasks are kept in trash
+	DefaultRetentionPeriod = 30 * 24 * time.Hour
+)
+
+// RetentionService periodically purges boards and tasks which have been kept in trash longer than retention period,
+// contents of their attachments are removed as well
+type RetentionService struct {
+	interfaces.BoardRepository
+	interfaces.TaskRepository
+	interfaces.AttachmentRepository
+	interfaces.BlobStore
+	Clock
+	// Interval is period between purges of expired trash
+	Interval time.Duration
+	// Period is how long boards and tasks are kept in trash before they're purged
+	Period time.Duration
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewRetentionService(
+	boardRepository interfaces.BoardRepository,
+	taskRepository interfaces.TaskRepository,
+	attachmentRepository interfaces.AttachmentRepository,
+	blobStore interfaces.BlobStore,
+	clock Clock,
+	interval,
+	period time.Duration,
+) *RetentionService {
+	return &RetentionService{
+		BoardRepository:      boardRepository,
+		TaskRepository:       taskRepository,
+		AttachmentRepository: attachmentRepository,
+		BlobStore:            blobStore,
+		Clock:                clock,
+		Interval:             interval,
+		Period:               period,
+	}
+}
+
+// Start runs scheduler goroutine which purges expired trash every Interval until Stop is called or ctx is done
+func (rs *RetentionService) Start(ctx context.Context) {
+	ctx, rs.cancel = context.WithCancel(ctx)
+	rs.done = make(chan struct{})
+	go func() {
+		defer close(rs.done)
+		rs.Run(ctx)
+	}()
+}
+
+// Stop stops scheduler goroutine and waits until purge in progress is finished
+func (rs *RetentionService) Stop() {
+	if rs.cancel == nil {
+		return
+	}
+	rs.cancel()
+	<-rs.done
+}
+
+// Run purges expired trash every Interval, blocks until ctx is done
+func (rs *RetentionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(rs.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if purgeErr := rs.PurgeExpired(ctx); purgeErr != nil && ctx.Err() == nil {
+				log.Println("Purging expired trash failed:", purgeErr)
+			}
+		}
+	}
+}
+
+// PurgeExpired permanently removes boards and tasks which were moved to trash more than Period ago,
+// records of purged boards are removed along with them. Attachment records are removed by cascade,
+// so their contents are removed first
+func (rs *RetentionService) PurgeExpired(ctx context.Context) error {
+	deadline := rs.Now().Add(-rs.Period)
+	attachmentIds, searchErr := rs.AttachmentRepository.FindIDsDeletedBefore(ctx, deadline)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, attachmentId := range attachmentIds {
+		// failure only leaves unreachable blob, so purge goes on
+		if deleteErr := rs.BlobStore.Delete(ctx, string(attachmentId)); deleteErr != nil {
+			log.Println("Deleting attachment blob failed:", deleteErr)
+		}
+	}
+	tasksCount, purgeErr := rs.TaskRepository.PurgeDeletedBefore(ctx, deadline)
+	if purgeErr != nil {
+		return purgeErr
+	}
+	boardsCount, purgeErr := rs.BoardRepository.PurgeDeletedBefore(ctx, deadline)
+	if purgeErr != nil {
+		return purgeErr
+	}
+	if tasksCount > 0 || boardsCount > 0 {
+		log.Printf("Purged %d boards and %d tasks kept in trash since before %s", boardsCount, tasksCount, deadline)
+	}
+	return nil
+}
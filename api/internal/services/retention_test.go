@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"go.uber.org/mock/gomock"
+
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/internal/services"
+	"just-kanban/mocks"
+	"just-kanban/pkg/sqlddl"
+)
+
+// expiredAttachments is attachment storage whose only attachments are the ones of expired trash
+type expiredAttachments struct {
+	interfaces.AttachmentRepository
+	ids []sqlddl.ID
+}
+
+func (ea expiredAttachments) FindIDsDeletedBefore(context.Context, time.Time) ([]sqlddl.ID, error) {
+	return ea.ids, nil
+}
+
+// deletionRecorder is blob store which records deleted keys
+type deletionRecorder struct {
+	deleted []string
+}
+
+func (dr *deletionRecorder) Put(context.Context, string, io.Reader) error {
+	return nil
+}
+
+func (dr *deletionRecorder) Get(context.Context, string) (io.ReadCloser, error) {
+	return nil, errors.New("not found")
+}
+
+func (dr *deletionRecorder) Delete(_ context.Context, key string) error {
+	dr.deleted = append(dr.deleted, key)
+	return nil
+}
+
+func TestRetentionService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	period := 30 * 24 * time.Hour
+
+	t.Run("Items deleted before retention period are purged", func(t *testing.T) {
+		mockBoardRepository := mocks.NewMockBoardRepository(ctrl)
+		mockTaskRepository := mocks.NewMockTaskRepository(ctrl)
+		blobs := &deletionRecorder{}
+		service := services.NewRetentionService(
+			mockBoardRepository,
+			mockTaskRepository,
+			expiredAttachments{ids: []sqlddl.ID{"first", "second"}},
+			blobs,
+			fixedClock{now},
+			time.Minute,
+			period,
+		)
+		gomock.InOrder(
+			mockTaskRepository.EXPECT().PurgeDeletedBefore(gomock.Any(), now.Add(-period)).Return(int64(3), nil),
+			mockBoardRepository.EXPECT().PurgeDeletedBefore(gomock.Any(), now.Add(-period)).Return(int64(1), nil),
+		)
+		if err := service.PurgeExpired(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(blobs.deleted, []string{"first", "second"}) {
+			t.Fatalf("got deleted blobs %v", blobs.deleted)
+		}
+	})
+
+	t.Run("Boards aren't purged if tasks purge fails", func(t *testing.T) {
+		mockBoardRepository := mocks.NewMockBoardRepository(ctrl)
+		mockTaskRepository := mocks.NewMockTaskRepository(ctrl)
+		service := services.NewRetentionService(
+			mockBoardRepository,
+			mockTaskRepository,
+			expiredAttachments{},
+			&deletionRecorder{},
+			fixedClock{now},
+			time.Minute,
+			period,
+		)
+		purgeErr := errors.New("purge failed")
+		mockTaskRepository.EXPECT().PurgeDeletedBefore(gomock.Any(), gomock.Any()).Return(int64(0), purgeErr)
+		if err := service.PurgeExpired(context.Background()); !errors.Is(err, purgeErr) {
+			t.Fatalf("got %v, expected %v", err, purgeErr)
+		}
+	})
+}
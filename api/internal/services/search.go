@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+)
+
+type (
+	SearchService struct {
+		interfaces.SearchRepository
+	}
+	SearchData struct {
+		// Query is searched words, quoted phrases, "or" and "-" excluding words are supported
+		Query string `json:"q" validate:"required,min=2,max=200,trimmed"`
+		PageData
+	}
+)
+
+func NewSearchService(repo interfaces.SearchRepository) *SearchService {
+	return &SearchService{repo}
+}
+
+// Search ranks tasks and boards matching query, only boards where requester is a member are searched
+func (ss *SearchService) Search(ctx context.Context, d *SearchData) ([]models.SearchResult, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	results, searchErr := ss.SearchRepository.Search(ctx, userId, d.Query, d.limit(), d.Offset)
+	return results, searchErr
+}
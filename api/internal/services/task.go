@@ -3,6 +3,10 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"just-kanban/internal/contextkeys"
 	"just-kanban/internal/models"
@@ -14,23 +18,88 @@ import (
 var (
 	taskAlreadyExistsErr      = errors.New("task with this name already exists")
 	taskWithOrderNotExistsErr = errors.New("task with this order does not exist")
+	columnNotExistsErr        = errors.New("board column does not exist")
+	labelNotExistsErr         = errors.New("board label does not exist")
+	ErrorTaskStartsAfterDue   = errors.New("task can't start after it's due")
+	ErrorTaskBlocked          = errors.New("task can't be done while it's blocked by unfinished tasks")
+	ErrorWIPLimitExceeded     = errors.New("board column has reached its work in progress limit")
+	ErrorTaskArchived         = errors.New("task is archived, it has to be returned from archive first")
+	taskNotArchivedErr        = errors.New("task isn't archived")
 )
 
 type (
 	TaskService struct {
 		interfaces.TaskRepository
+		interfaces.BoardColumnRepository
+		interfaces.LabelRepository
+		interfaces.ChecklistItemRepository
+		interfaces.TaskHistoryRepository
+		interfaces.TaskDependencyRepository
+		interfaces.BoardMemberRepository
+		interfaces.Transactor
+		Clock
+		BoardEventPublisher
 	}
 	CreateTaskData struct {
 		Name        string    `json:"name" validate:"required,min=3,max=255,trimmed"`
 		Description string    `json:"description" validate:"max=1000,trimmed"`
 		BoardID     sqlddl.ID `json:"board_id" validate:"required"`
 		AssigneeID  sqlddl.ID `json:"assignee_id"`
+		// ColumnID is column task placed into, first board column is used if empty
+		ColumnID sqlddl.ID `json:"column_id"`
+		// LabelIDs are board labels attached to task
+		LabelIDs []sqlddl.ID `json:"label_ids" validate:"dive,required"`
+		StartAt  *time.Time  `json:"start_at"`
+		DueAt    *time.Time  `json:"due_at"`
+		// OverrideWIPLimit places task into column even if column is full, allowed to board managers only
+		OverrideWIPLimit bool `json:"override_wip_limit"`
 	}
 	UpdateTaskData struct {
-		Name        string            `json:"name" validate:"omitempty,min=3,max=255,trimmed"`
-		Description string            `json:"description" validate:"omitempty,max=1000,trimmed"`
-		AssigneeID  sqlddl.ID         `json:"assignee_id"`
-		Status      models.TaskStatus `json:"status"`
+		Name        string    `json:"name" validate:"omitempty,min=3,max=255,trimmed"`
+		Description string    `json:"description" validate:"omitempty,max=1000,trimmed"`
+		AssigneeID  sqlddl.ID `json:"assignee_id"`
+		// ColumnID is column task moved to, task is placed to the end of it
+		ColumnID sqlddl.ID `json:"column_id"`
+		// LabelIDs replace labels attached to task, labels are kept if it's nil and detached if it's empty
+		LabelIDs []sqlddl.ID `json:"label_ids" validate:"omitempty,dive,required"`
+		StartAt  *time.Time  `json:"start_at"`
+		DueAt    *time.Time  `json:"due_at"`
+		// OverrideWIPLimit moves task into column even if column is full, allowed to board managers only
+		OverrideWIPLimit bool `json:"override_wip_limit"`
+	}
+	// ListTasksData is filter, sorting and page of board tasks list, empty filters aren't applied
+	ListTasksData struct {
+		Statuses    []models.TaskStatus `json:"status" validate:"dive,oneof=1 2 3"`
+		AssigneeID  sqlddl.ID           `json:"assignee_id"`
+		CreatorID   sqlddl.ID           `json:"creator_id"`
+		CreatedFrom *time.Time          `json:"created_from"`
+		CreatedTo   *time.Time          `json:"created_to"`
+		UpdatedFrom *time.Time          `json:"updated_from"`
+		UpdatedTo   *time.Time          `json:"updated_to"`
+		// Archived lists archived tasks instead of active ones
+		Archived bool `json:"archived"`
+		// Sort is field tasks are sorted by, tasks are sorted by order if it's empty
+		Sort string `json:"sort" validate:"omitempty,oneof=order created_at updated_at"`
+		// Direction is direction of sorting, ascending if it's empty
+		Direction string `json:"direction" validate:"omitempty,oneof=asc desc"`
+		PageData
+	}
+	// TasksPage is page of board tasks list
+	TasksPage struct {
+		Tasks []models.Task
+		// Total is count of all tasks matching filter
+		Total  int
+		Limit  int
+		Offset int
+	}
+	// MoveTaskData is target place of task on its board
+	MoveTaskData struct {
+		ColumnID sqlddl.ID `json:"column_id" validate:"required"`
+		// Position is place inside column starting from 1,
+		// task is placed to the end of column if it's 0 or exceeds column size
+		Position int `json:"position" validate:"min=0"`
+		// OverrideWIPLimit moves task into column even if column is full, allowed to board managers only
+		OverrideWIPLimit bool `json:"override_wip_limit"`
 	}
 )
 
@@ -42,17 +111,38 @@ func (utd *UpdateTaskData) ToUpdateTaskModel() *models.UpdateTask {
 	if utd.Description != "" {
 		model.Description = &utd.Description
 	}
-	if utd.Status != 0 {
-		model.Status = &utd.Status
-	}
 	if utd.AssigneeID != "" {
 		model.AssigneeID = &utd.AssigneeID
 	}
+	model.StartAt = utd.StartAt
+	model.DueAt = utd.DueAt
 	return &model
 }
 
-func NewTaskService(taskRepository interfaces.TaskRepository) *TaskService {
-	return &TaskService{taskRepository}
+func NewTaskService(
+	taskRepository interfaces.TaskRepository,
+	columnRepository interfaces.BoardColumnRepository,
+	labelRepository interfaces.LabelRepository,
+	checklistRepository interfaces.ChecklistItemRepository,
+	historyRepository interfaces.TaskHistoryRepository,
+	dependencyRepository interfaces.TaskDependencyRepository,
+	memberRepository interfaces.BoardMemberRepository,
+	transactor interfaces.Transactor,
+	clock Clock,
+	publisher BoardEventPublisher,
+) *TaskService {
+	return &TaskService{
+		taskRepository,
+		columnRepository,
+		labelRepository,
+		checklistRepository,
+		historyRepository,
+		dependencyRepository,
+		memberRepository,
+		transactor,
+		clock,
+		publisher,
+	}
 }
 
 func (ts *TaskService) CreateTask(ctx context.Context, d *CreateTaskData) (*models.Task, error) {
@@ -64,31 +154,87 @@ func (ts *TaskService) CreateTask(ctx context.Context, d *CreateTaskData) (*mode
 	if notExistErr == nil {
 		return nil, taskAlreadyExistsErr
 	}
+	if datesErr := checkTaskDates(d.StartAt, d.DueAt); datesErr != nil {
+		return nil, datesErr
+	}
 	id := sqlddl.ID(identifier.GenerateUUID())
 	var assigneeId = userId
 	if d.AssigneeID != "" {
+		if assigneeErr := ts.checkTaskAssignee(ctx, d.BoardID, d.AssigneeID); assigneeErr != nil {
+			return nil, assigneeErr
+		}
 		assigneeId = d.AssigneeID
 	}
-	boardTasks, boardTasksErr := ts.FindAllByBoardId(ctx, d.BoardID)
-	if boardTasksErr != nil {
-		return nil, boardTasksErr
-	}
-	order := ts.findMaxTasksOrder(boardTasks) + 1
-	creationErr := ts.TaskRepository.Create(ctx, &models.Task{
-		Model:       models.Model{ID: id},
-		Name:        d.Name,
-		Description: d.Description,
-		BoardID:     d.BoardID,
-		CreatorID:   userId,
-		AssigneeID:  assigneeId,
-		Status:      models.TaskStatusBacklog,
-		Order:       order,
+	column, columnErr := ts.findTaskColumn(ctx, d.BoardID, d.ColumnID)
+	if columnErr != nil {
+		return nil, columnErr
+	}
+	if labelsErr := ts.checkTaskLabels(ctx, d.BoardID, d.LabelIDs); labelsErr != nil {
+		return nil, labelsErr
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock prevents concurrent creations and moves from taking the same order or position
+		if lockErr := ts.BoardColumnRepository.Lock(ctx, d.BoardID); lockErr != nil {
+			return lockErr
+		}
+		// column is read again, its limit could be changed or it could be deleted while lock was awaited
+		lockedColumn, columnErr := ts.findTaskColumn(ctx, d.BoardID, column.ID)
+		if columnErr != nil {
+			return columnErr
+		}
+		boardTasks, boardTasksErr := ts.TaskRepository.FindAllByBoardId(ctx, d.BoardID)
+		if boardTasksErr != nil {
+			return boardTasksErr
+		}
+		columnTasksCount, countErr := ts.TaskRepository.CountByColumnID(ctx, lockedColumn.ID)
+		if countErr != nil {
+			return countErr
+		}
+		overridden, limitErr := checkWIPLimit(lockedColumn, columnTasksCount, d.OverrideWIPLimit)
+		if limitErr != nil {
+			return limitErr
+		}
+		creationErr := ts.TaskRepository.Create(ctx, &models.Task{
+			Model:       models.Model{ID: id},
+			Name:        d.Name,
+			Description: d.Description,
+			BoardID:     d.BoardID,
+			CreatorID:   userId,
+			AssigneeID:  assigneeId,
+			ColumnID:    lockedColumn.ID,
+			Status:      lockedColumn.Status,
+			Order:       ts.findMaxTasksOrder(boardTasks) + 1,
+			Position:    columnTasksCount + 1,
+			StartAt:     d.StartAt,
+			DueAt:       d.DueAt,
+		})
+		if creationErr != nil {
+			return creationErr
+		}
+		if labelsErr := ts.LabelRepository.ReplaceTaskLabels(ctx, id, d.LabelIDs); labelsErr != nil {
+			return labelsErr
+		}
+		createdTask, searchErr := ts.FindByID(ctx, id)
+		if searchErr != nil {
+			return searchErr
+		}
+		if historyErr := ts.recordTaskHistory(ctx, nil, createdTask); historyErr != nil {
+			return historyErr
+		}
+		if overridden {
+			return ts.recordWIPLimitOverride(ctx, createdTask, lockedColumn, columnTasksCount+1)
+		}
+		return nil
 	})
-	if creationErr != nil {
-		return nil, creationErr
+	if txErr != nil {
+		return nil, txErr
+	}
+	createdTask, searchErr := ts.FindByID(ctx, id)
+	if searchErr != nil {
+		return nil, searchErr
 	}
-	createdTask, searchErr := ts.TaskRepository.FindByID(ctx, id)
-	return createdTask, searchErr
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskCreated, createdTask.BoardID, createdTask))
+	return createdTask, nil
 }
 
 func (ts *TaskService) UpdateTask(ctx context.Context, taskId sqlddl.ID, d *UpdateTaskData) (*models.Task, error) {
@@ -96,22 +242,269 @@ func (ts *TaskService) UpdateTask(ctx context.Context, taskId sqlddl.ID, d *Upda
 	if userIdErr != nil {
 		return nil, userIdErr
 	}
-	updateErr := ts.TaskRepository.Update(ctx, taskId, d.ToUpdateTaskModel())
-	if updateErr != nil {
-		return nil, updateErr
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	var column *models.BoardColumn
+	if d.ColumnID != "" && d.ColumnID != task.ColumnID {
+		targetColumn, columnErr := ts.findTaskColumn(ctx, task.BoardID, d.ColumnID)
+		if columnErr != nil {
+			return nil, columnErr
+		}
+		column = targetColumn
+	}
+	if d.AssigneeID != "" {
+		if assigneeErr := ts.checkTaskAssignee(ctx, task.BoardID, d.AssigneeID); assigneeErr != nil {
+			return nil, assigneeErr
+		}
 	}
-	updatedTask, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
-	return updatedTask, searchErr
+	if labelsErr := ts.checkTaskLabels(ctx, task.BoardID, d.LabelIDs); labelsErr != nil {
+		return nil, labelsErr
+	}
+	startAt, dueAt := task.StartAt, task.DueAt
+	if d.StartAt != nil {
+		startAt = d.StartAt
+	}
+	if d.DueAt != nil {
+		dueAt = d.DueAt
+	}
+	if datesErr := checkTaskDates(startAt, dueAt); datesErr != nil {
+		return nil, datesErr
+	}
+	model := d.ToUpdateTaskModel()
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		return ts.changeTask(ctx, task.BoardID, taskId, func() error {
+			if column != nil {
+				moveErr := ts.moveTask(ctx, task.BoardID, taskId, column, 0, d.OverrideWIPLimit)
+				if moveErr != nil {
+					return moveErr
+				}
+			}
+			if d.LabelIDs != nil {
+				if labelsErr := ts.LabelRepository.ReplaceTaskLabels(ctx, taskId, d.LabelIDs); labelsErr != nil {
+					return labelsErr
+				}
+			}
+			if (column != nil || d.LabelIDs != nil) && model.Name == nil && model.Description == nil &&
+				model.AssigneeID == nil && model.StartAt == nil && model.DueAt == nil {
+				return nil
+			}
+			return ts.TaskRepository.Update(ctx, taskId, model)
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	updatedTask, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskUpdated, updatedTask.BoardID, updatedTask))
+	return updatedTask, nil
+}
+
+// MoveTask places task into provided column and position, other tasks of affected columns are renumbered
+func (ts *TaskService) MoveTask(ctx context.Context, taskId sqlddl.ID, d *MoveTaskData) (*models.Task, error) {
+	_, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	column, columnErr := ts.findTaskColumn(ctx, task.BoardID, d.ColumnID)
+	if columnErr != nil {
+		return nil, columnErr
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		return ts.changeTask(ctx, task.BoardID, taskId, func() error {
+			return ts.moveTask(ctx, task.BoardID, taskId, column, d.Position, d.OverrideWIPLimit)
+		})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	movedTask, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskMoved, movedTask.BoardID, movedTask))
+	return movedTask, nil
+}
+
+// DeleteTask moves task to trash, task is taken out of its column and may be restored until it's purged
+func (ts *TaskService) DeleteTask(ctx context.Context, taskId sqlddl.ID) error {
+	_, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := ts.BoardColumnRepository.Lock(ctx, task.BoardID); lockErr != nil {
+			return lockErr
+		}
+		// task is read again, its position could be changed while lock was awaited
+		lockedTask, searchErr := ts.FindByID(ctx, taskId)
+		if searchErr != nil {
+			return searchErr
+		}
+		if historyErr := ts.recordTaskHistory(ctx, lockedTask, nil); historyErr != nil {
+			return historyErr
+		}
+		// archived task is out of its column already
+		if lockedTask.ArchivedAt == nil {
+			if takeOutErr := ts.takeOutOfColumn(ctx, lockedTask); takeOutErr != nil {
+				return takeOutErr
+			}
+		}
+		deletedAt := ts.Now()
+		return ts.TaskRepository.SetDeletedAt(ctx, taskId, &deletedAt)
+	})
+	if txErr != nil {
+		return txErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskDeleted, task.BoardID, task))
+	return nil
+}
+
+// RestoreTask returns task from trash, active task is placed to the end of its column
+// and archived one stays in archive
+func (ts *TaskService) RestoreTask(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	task, searchErr := ts.TaskRepository.FindDeletedByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, ErrorNotInTrash
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := ts.BoardColumnRepository.Lock(ctx, task.BoardID); lockErr != nil {
+			return lockErr
+		}
+		lockedTask, searchErr := ts.TaskRepository.FindDeletedByID(ctx, taskId)
+		if searchErr != nil {
+			return ErrorNotInTrash
+		}
+		if _, existsErr := ts.TaskRepository.FindByName(ctx, lockedTask.BoardID, lockedTask.Name); existsErr == nil {
+			return taskAlreadyExistsErr
+		}
+		if lockedTask.ArchivedAt == nil {
+			if putBackErr := ts.putBackIntoColumn(ctx, lockedTask); putBackErr != nil {
+				return putBackErr
+			}
+		}
+		if restoreErr := ts.TaskRepository.SetDeletedAt(ctx, taskId, nil); restoreErr != nil {
+			return restoreErr
+		}
+		return ts.recordTaskAction(ctx, lockedTask, models.TaskHistoryRestored)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	restoredTask, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskUpdated, restoredTask.BoardID, restoredTask))
+	return restoredTask, nil
+}
+
+// ArchiveTask takes task out of its column into archive, archived task isn't listed by default
+func (ts *TaskService) ArchiveTask(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := ts.BoardColumnRepository.Lock(ctx, task.BoardID); lockErr != nil {
+			return lockErr
+		}
+		lockedTask, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+		if searchErr != nil {
+			return searchErr
+		}
+		if lockedTask.ArchivedAt != nil {
+			return ErrorTaskArchived
+		}
+		if takeOutErr := ts.takeOutOfColumn(ctx, lockedTask); takeOutErr != nil {
+			return takeOutErr
+		}
+		archivedAt := ts.Now()
+		if archiveErr := ts.TaskRepository.SetArchivedAt(ctx, taskId, &archivedAt); archiveErr != nil {
+			return archiveErr
+		}
+		return ts.recordTaskAction(ctx, lockedTask, models.TaskHistoryArchived)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	archivedTask, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskUpdated, archivedTask.BoardID, archivedTask))
+	return archivedTask, nil
+}
+
+// UnarchiveTask returns task from archive to the end of its column
+func (ts *TaskService) UnarchiveTask(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	txErr := ts.WithinTransaction(ctx, func(ctx context.Context) error {
+		if lockErr := ts.BoardColumnRepository.Lock(ctx, task.BoardID); lockErr != nil {
+			return lockErr
+		}
+		lockedTask, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+		if searchErr != nil {
+			return searchErr
+		}
+		if lockedTask.ArchivedAt == nil {
+			return taskNotArchivedErr
+		}
+		if putBackErr := ts.putBackIntoColumn(ctx, lockedTask); putBackErr != nil {
+			return putBackErr
+		}
+		if unarchiveErr := ts.TaskRepository.SetArchivedAt(ctx, taskId, nil); unarchiveErr != nil {
+			return unarchiveErr
+		}
+		return ts.recordTaskAction(ctx, lockedTask, models.TaskHistoryUnarchived)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	unarchivedTask, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	ts.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskUpdated, unarchivedTask.BoardID, unarchivedTask))
+	return unarchivedTask, nil
+}
+
+// ListDeletedTasks searches for tasks moved to trash from active boards which user is member of
+func (ts *TaskService) ListDeletedTasks(ctx context.Context, userId sqlddl.ID) ([]models.Task, error) {
+	return ts.TaskRepository.FindDeletedByUserID(ctx, userId)
 }
 
 func (ts *TaskService) FindByID(ctx context.Context, id sqlddl.ID) (*models.Task, error) {
 	task, searchErr := ts.TaskRepository.FindByID(ctx, id)
-	return task, searchErr
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	detailsErr := ts.attachDetails(ctx, task)
+	return task, detailsErr
 }
 
 func (ts *TaskService) FindByName(ctx context.Context, boardID sqlddl.ID, name string) (*models.Task, error) {
 	task, searchErr := ts.TaskRepository.FindByName(ctx, boardID, name)
-	return task, searchErr
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	detailsErr := ts.attachDetails(ctx, task)
+	return task, detailsErr
 }
 
 func (ts *TaskService) FindByOrder(ctx context.Context, boardID sqlddl.ID, order uint) (*models.Task, error) {
@@ -119,12 +512,135 @@ func (ts *TaskService) FindByOrder(ctx context.Context, boardID sqlddl.ID, order
 	if searchErr != nil {
 		return nil, taskWithOrderNotExistsErr
 	}
-	return task, searchErr
+	detailsErr := ts.attachDetails(ctx, task)
+	return task, detailsErr
 }
 
 func (ts *TaskService) FindAllByBoardId(ctx context.Context, boardId sqlddl.ID) ([]models.Task, error) {
 	tasks, searchErr := ts.TaskRepository.FindAllByBoardId(ctx, boardId)
-	return tasks, searchErr
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	tasksRefs := make([]*models.Task, 0, len(tasks))
+	for i := range tasks {
+		tasksRefs = append(tasksRefs, &tasks[i])
+	}
+	detailsErr := ts.attachDetails(ctx, tasksRefs...)
+	return tasks, detailsErr
+}
+
+// ListBoardTasks searches for page of board tasks matching filter
+func (ts *TaskService) ListBoardTasks(ctx context.Context, boardId sqlddl.ID, d *ListTasksData) (*TasksPage, error) {
+	filter := models.TaskFilter{
+		Statuses:    d.Statuses,
+		AssigneeID:  d.AssigneeID,
+		CreatorID:   d.CreatorID,
+		CreatedFrom: d.CreatedFrom,
+		CreatedTo:   d.CreatedTo,
+		UpdatedFrom: d.UpdatedFrom,
+		UpdatedTo:   d.UpdatedTo,
+		Archived:    d.Archived,
+		SortBy:      models.TaskSortField(d.Sort),
+		Descending:  d.Direction == "desc",
+		Limit:       d.limit(),
+		Offset:      d.Offset,
+	}
+	tasks, total, searchErr := ts.TaskRepository.FindPageByBoardID(ctx, boardId, &filter)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	tasksRefs := make([]*models.Task, 0, len(tasks))
+	for i := range tasks {
+		tasksRefs = append(tasksRefs, &tasks[i])
+	}
+	if detailsErr := ts.attachDetails(ctx, tasksRefs...); detailsErr != nil {
+		return nil, detailsErr
+	}
+	if tasks == nil {
+		tasks = []models.Task{}
+	}
+	return &TasksPage{Tasks: tasks, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// HasNext checks there are matching tasks after the page
+func (tp *TasksPage) HasNext() bool {
+	return tp.Offset+len(tp.Tasks) < tp.Total
+}
+
+// attachDetails fills labels, checklist progress and dependencies of provided tasks
+// with one storage request for each
+func (ts *TaskService) attachDetails(ctx context.Context, tasks ...*models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	taskIds := make([]sqlddl.ID, 0, len(tasks))
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.ID)
+	}
+	labels, searchErr := ts.LabelRepository.FindAllByTaskIDs(ctx, taskIds)
+	if searchErr != nil {
+		return searchErr
+	}
+	progress, countErr := ts.ChecklistItemRepository.CountProgressByTaskIDs(ctx, taskIds)
+	if countErr != nil {
+		return countErr
+	}
+	blockers, searchErr := ts.TaskDependencyRepository.FindBlockers(ctx, taskIds)
+	if searchErr != nil {
+		return searchErr
+	}
+	blocked, searchErr := ts.TaskDependencyRepository.FindBlocked(ctx, taskIds)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, task := range tasks {
+		task.Labels = labels[task.ID]
+		if task.Labels == nil {
+			task.Labels = []models.Label{}
+		}
+		task.ChecklistProgress = progress[task.ID]
+		task.BlockedBy = blockers[task.ID]
+		if task.BlockedBy == nil {
+			task.BlockedBy = []models.TaskReference{}
+		}
+		task.Blocks = blocked[task.ID]
+		if task.Blocks == nil {
+			task.Blocks = []models.TaskReference{}
+		}
+	}
+	return nil
+}
+
+// checkTaskDates checks task doesn't start after it's due, both dates are optional
+func checkTaskDates(startAt, dueAt *time.Time) error {
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		return ErrorTaskStartsAfterDue
+	}
+	return nil
+}
+
+// checkTaskLabels checks all labels exist on board which task belongs to
+func (ts *TaskService) checkTaskLabels(ctx context.Context, boardId sqlddl.ID, labelIds []sqlddl.ID) error {
+	checked := make(map[sqlddl.ID]bool, len(labelIds))
+	for _, labelId := range labelIds {
+		if checked[labelId] {
+			return labelNotExistsErr
+		}
+		label, searchErr := ts.LabelRepository.FindByID(ctx, labelId)
+		if searchErr != nil || label.BoardID != boardId {
+			return labelNotExistsErr
+		}
+		checked[labelId] = true
+	}
+	return nil
+}
+
+// checkTaskAssignee checks user can be assigned to task of board
+func (ts *TaskService) checkTaskAssignee(ctx context.Context, boardId, userId sqlddl.ID) error {
+	if _, memberErr := ts.BoardMemberRepository.FindBoardUser(ctx, boardId, userId); memberErr != nil {
+		return ErrorAssigneeNotMember
+	}
+	return nil
 }
 
 func (ts *TaskService) findMaxTasksOrder(tasks []models.Task) int {
@@ -136,3 +652,257 @@ func (ts *TaskService) findMaxTasksOrder(tasks []models.Task) int {
 	}
 	return maxOrder
 }
+
+// changeTask applies change to task and records history of changed fields, must be called within transaction
+func (ts *TaskService) changeTask(ctx context.Context, boardId, taskId sqlddl.ID, change func() error) error {
+	// lock keeps task unchanged by others between reading its states
+	if lockErr := ts.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+		return lockErr
+	}
+	before, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	if changeErr := change(); changeErr != nil {
+		return changeErr
+	}
+	after, searchErr := ts.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	return ts.recordTaskHistory(ctx, before, after)
+}
+
+// recordTaskHistory writes history entries of requester's change of task, before is nil for created task
+// and after is nil for deleted one, must be called within transaction of change
+func (ts *TaskService) recordTaskHistory(ctx context.Context, before, after *models.Task) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	var entries []models.TaskHistoryEntry
+	switch {
+	case before == nil:
+		entries = []models.TaskHistoryEntry{{
+			BoardID:   after.BoardID,
+			TaskOrder: after.Order,
+			Action:    models.TaskHistoryCreated,
+			NewValue:  historyValue(after.Name),
+		}}
+	case after == nil:
+		entries = []models.TaskHistoryEntry{{
+			BoardID:   before.BoardID,
+			TaskOrder: before.Order,
+			Action:    models.TaskHistoryDeleted,
+			OldValue:  historyValue(before.Name),
+		}}
+	default:
+		entries = diffTasks(before, after)
+	}
+	task := before
+	if task == nil {
+		task = after
+	}
+	for _, entry := range entries {
+		entry.ID = sqlddl.ID(identifier.GenerateUUID())
+		entry.TaskID = &task.ID
+		entry.ActorID = &userId
+		if creationErr := ts.TaskHistoryRepository.Create(ctx, &entry); creationErr != nil {
+			return creationErr
+		}
+	}
+	return nil
+}
+
+// moveTask places task into position of column, full column is accepted only if overrideWIPLimit is set,
+// must be called within transaction
+func (ts *TaskService) moveTask(
+	ctx context.Context,
+	boardId,
+	taskId sqlddl.ID,
+	column *models.BoardColumn,
+	position int,
+	overrideWIPLimit bool,
+) error {
+	if lockErr := ts.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+		return lockErr
+	}
+	// task is read again, its place could be changed while lock was awaited
+	task, searchErr := ts.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return searchErr
+	}
+	if task.ArchivedAt != nil {
+		return ErrorTaskArchived
+	}
+	// column is read again as well, its limit could be changed or it could be deleted meanwhile
+	column, columnErr := ts.findTaskColumn(ctx, boardId, column.ID)
+	if columnErr != nil {
+		return columnErr
+	}
+	maxPosition, countErr := ts.TaskRepository.CountByColumnID(ctx, column.ID)
+	if countErr != nil {
+		return countErr
+	}
+	overridden := false
+	if task.ColumnID != column.ID {
+		var limitErr error
+		overridden, limitErr = checkWIPLimit(column, maxPosition, overrideWIPLimit)
+		if limitErr != nil {
+			return limitErr
+		}
+		maxPosition++
+	}
+	if position <= 0 || position > maxPosition {
+		position = maxPosition
+	}
+	if task.ColumnID == column.ID && task.Position == position {
+		return nil
+	}
+	if column.Status == models.TaskStatusDone && task.Status != models.TaskStatusDone {
+		if blockedErr := ts.checkTaskUnblocked(ctx, taskId); blockedErr != nil {
+			return blockedErr
+		}
+	}
+	if shiftErr := ts.TaskRepository.ShiftPositions(ctx, task.ColumnID, task.Position+1, -1); shiftErr != nil {
+		return shiftErr
+	}
+	if shiftErr := ts.TaskRepository.ShiftPositions(ctx, column.ID, position, 1); shiftErr != nil {
+		return shiftErr
+	}
+	updateErr := ts.TaskRepository.Update(ctx, taskId, &models.UpdateTask{
+		ColumnID: &column.ID,
+		Status:   &column.Status,
+		Position: &position,
+	})
+	if updateErr != nil {
+		return updateErr
+	}
+	if overridden {
+		return ts.recordWIPLimitOverride(ctx, task, column, maxPosition)
+	}
+	return nil
+}
+
+// takeOutOfColumn removes task from positions of its column, remaining tasks are renumbered,
+// must be called within transaction which locked columns of board
+func (ts *TaskService) takeOutOfColumn(ctx context.Context, task *models.Task) error {
+	if shiftErr := ts.TaskRepository.ShiftPositions(ctx, task.ColumnID, task.Position+1, -1); shiftErr != nil {
+		return shiftErr
+	}
+	position := 0
+	return ts.TaskRepository.Update(ctx, task.ID, &models.UpdateTask{Position: &position})
+}
+
+// putBackIntoColumn places task taken out of its column to the end of it, full column isn't accepted,
+// must be called within transaction which locked columns of board
+func (ts *TaskService) putBackIntoColumn(ctx context.Context, task *models.Task) error {
+	column, searchErr := ts.BoardColumnRepository.FindByID(ctx, task.ColumnID)
+	if searchErr != nil {
+		return searchErr
+	}
+	tasksCount, countErr := ts.TaskRepository.CountByColumnID(ctx, column.ID)
+	if countErr != nil {
+		return countErr
+	}
+	if _, limitErr := checkWIPLimit(column, tasksCount, false); limitErr != nil {
+		return limitErr
+	}
+	position := tasksCount + 1
+	return ts.TaskRepository.Update(ctx, task.ID, &models.UpdateTask{Position: &position})
+}
+
+// recordTaskAction writes history entry about requester's action which doesn't change task fields,
+// must be called within transaction of action
+func (ts *TaskService) recordTaskAction(ctx context.Context, task *models.Task, action models.TaskHistoryAction) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	return ts.TaskHistoryRepository.Create(ctx, &models.TaskHistoryEntry{
+		Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		BoardID:   task.BoardID,
+		TaskID:    &task.ID,
+		TaskOrder: task.Order,
+		ActorID:   &userId,
+		Action:    action,
+	})
+}
+
+// wipLimitHistoryField is name of field written into history entries about work in progress limit overrides
+const wipLimitHistoryField = "wip_limit"
+
+// checkWIPLimit checks column has room for one more task besides tasksCount placed ones,
+// full column is accepted if override is set and then overridden is true
+func checkWIPLimit(column *models.BoardColumn, tasksCount int, override bool) (overridden bool, err error) {
+	if column.WIPLimit == 0 || tasksCount < column.WIPLimit {
+		return false, nil
+	}
+	if !override {
+		return false, ErrorWIPLimitExceeded
+	}
+	return true, nil
+}
+
+// recordWIPLimitOverride writes history entry about task placed into full column by requester,
+// must be called within transaction of placement
+func (ts *TaskService) recordWIPLimitOverride(
+	ctx context.Context,
+	task *models.Task,
+	column *models.BoardColumn,
+	tasksCount int,
+) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	return ts.TaskHistoryRepository.Create(ctx, &models.TaskHistoryEntry{
+		Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		BoardID:   task.BoardID,
+		TaskID:    &task.ID,
+		TaskOrder: task.Order,
+		ActorID:   &userId,
+		Action:    models.TaskHistoryWIPLimitOverridden,
+		Field:     wipLimitHistoryField,
+		OldValue:  historyValue(strconv.Itoa(column.WIPLimit)),
+		NewValue:  historyValue(strconv.Itoa(tasksCount)),
+	})
+}
+
+// checkTaskUnblocked checks all tasks blocking provided one are done
+func (ts *TaskService) checkTaskUnblocked(ctx context.Context, taskId sqlddl.ID) error {
+	blockers, searchErr := ts.TaskDependencyRepository.FindBlockers(ctx, []sqlddl.ID{taskId})
+	if searchErr != nil {
+		return searchErr
+	}
+	var unfinished []string
+	for _, blocker := range blockers[taskId] {
+		if blocker.Status != models.TaskStatusDone {
+			unfinished = append(unfinished, fmt.Sprintf("%q", blocker.Name))
+		}
+	}
+	if len(unfinished) > 0 {
+		return fmt.Errorf("%w: %s", ErrorTaskBlocked, strings.Join(unfinished, ", "))
+	}
+	return nil
+}
+
+// findTaskColumn searches for board column which task can be placed into,
+// if column identifier is not provided then first column of board is used
+func (ts *TaskService) findTaskColumn(ctx context.Context, boardId, columnId sqlddl.ID) (*models.BoardColumn, error) {
+	if columnId == "" {
+		columns, searchErr := ts.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		if len(columns) == 0 {
+			return nil, columnNotExistsErr
+		}
+		return &columns[0], nil
+	}
+	column, searchErr := ts.BoardColumnRepository.FindByID(ctx, columnId)
+	if searchErr != nil || column.BoardID != boardId {
+		return nil, columnNotExistsErr
+	}
+	return column, nil
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	TaskDependencyService struct {
+		interfaces.TaskDependencyRepository
+		interfaces.Transactor
+		*TaskService
+		*BoardMemberService
+	}
+	// CreateTaskDependencyData links task to another one, exactly one of identifiers must be provided
+	CreateTaskDependencyData struct {
+		// BlockerID is task which blocks the task
+		BlockerID sqlddl.ID `json:"blocker_id" validate:"required_without=BlockedID,excluded_with=BlockedID"`
+		// BlockedID is task which is blocked by the task
+		BlockedID sqlddl.ID `json:"blocked_id" validate:"required_without=BlockerID,excluded_with=BlockerID"`
+	}
+	// TaskDependencies are tasks linked to the task
+	TaskDependencies struct {
+		BlockedBy []models.TaskReference `json:"blocked_by"`
+		Blocks    []models.TaskReference `json:"blocks"`
+	}
+)
+
+var (
+	ErrorTaskDependencyCycle   = errors.New("dependency would create a cycle")
+	taskDependencyExistsErr    = errors.New("tasks are already linked")
+	taskDependencyNotExistsErr = errors.New("tasks are not linked")
+	taskDependencySelfErr      = errors.New("task can't depend on itself")
+	linkedTaskNotExistsErr     = errors.New("linked task does not exist")
+	linkedTaskNotAccessibleErr = errors.New("linked task belongs to board requester is not a member of")
+)
+
+func NewTaskDependencyService(
+	repo interfaces.TaskDependencyRepository,
+	transactor interfaces.Transactor,
+	ts *TaskService,
+	bms *BoardMemberService,
+) *TaskDependencyService {
+	return &TaskDependencyService{repo, transactor, ts, bms}
+}
+
+// ListTaskDependencies returns tasks blocking provided one and tasks blocked by it
+func (tds *TaskDependencyService) ListTaskDependencies(ctx context.Context, task *models.Task) (*TaskDependencies, error) {
+	detailedTask, searchErr := tds.TaskService.FindByID(ctx, task.ID)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	return &TaskDependencies{BlockedBy: detailedTask.BlockedBy, Blocks: detailedTask.Blocks}, nil
+}
+
+// AddTaskDependency links task to another one which may be placed on any board requester is a member of,
+// link which would create a cycle of dependencies is rejected
+func (tds *TaskDependencyService) AddTaskDependency(
+	ctx context.Context,
+	task *models.Task,
+	d *CreateTaskDependencyData,
+) (*TaskDependencies, error) {
+	dependency := models.TaskDependency{
+		Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		BlockerID: d.BlockerID,
+		BlockedID: d.BlockedID,
+	}
+	linkedId := d.BlockerID
+	if linkedId == "" {
+		linkedId = d.BlockedID
+		dependency.BlockerID = task.ID
+	} else {
+		dependency.BlockedID = task.ID
+	}
+	if linkedId == task.ID {
+		return nil, taskDependencySelfErr
+	}
+	if accessErr := tds.checkLinkedTask(ctx, linkedId); accessErr != nil {
+		return nil, accessErr
+	}
+	txErr := tds.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock prevents concurrent links from creating a cycle which neither of them sees
+		if lockErr := tds.TaskDependencyRepository.Lock(ctx); lockErr != nil {
+			return lockErr
+		}
+		blocked, searchErr := tds.TaskDependencyRepository.FindBlocked(ctx, []sqlddl.ID{dependency.BlockerID})
+		if searchErr != nil {
+			return searchErr
+		}
+		for _, reference := range blocked[dependency.BlockerID] {
+			if reference.ID == dependency.BlockedID {
+				return taskDependencyExistsErr
+			}
+		}
+		cycle, cycleErr := tds.isReachable(ctx, dependency.BlockedID, dependency.BlockerID)
+		if cycleErr != nil {
+			return cycleErr
+		}
+		if cycle {
+			return ErrorTaskDependencyCycle
+		}
+		return tds.TaskDependencyRepository.Create(ctx, &dependency)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return tds.ListTaskDependencies(ctx, task)
+}
+
+// RemoveTaskDependency removes link between task and another one regardless of its direction
+func (tds *TaskDependencyService) RemoveTaskDependency(ctx context.Context, task *models.Task, linkedId sqlddl.ID) error {
+	dependencies, searchErr := tds.ListTaskDependencies(ctx, task)
+	if searchErr != nil {
+		return searchErr
+	}
+	for _, blocker := range dependencies.BlockedBy {
+		if blocker.ID == linkedId {
+			return tds.TaskDependencyRepository.Delete(ctx, linkedId, task.ID)
+		}
+	}
+	for _, blocked := range dependencies.Blocks {
+		if blocked.ID == linkedId {
+			return tds.TaskDependencyRepository.Delete(ctx, task.ID, linkedId)
+		}
+	}
+	return taskDependencyNotExistsErr
+}
+
+// checkLinkedTask checks linked task exists and requester is a member of its board
+func (tds *TaskDependencyService) checkLinkedTask(ctx context.Context, taskId sqlddl.ID) error {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return userIdErr
+	}
+	linkedTask, searchErr := tds.TaskRepository.FindByID(ctx, taskId)
+	if searchErr != nil {
+		return linkedTaskNotExistsErr
+	}
+	if _, memberErr := tds.FindBoardMemberByUserID(ctx, linkedTask.BoardID, userId); memberErr != nil {
+		return linkedTaskNotAccessibleErr
+	}
+	return nil
+}
+
+// isReachable walks dependencies graph breadth-first from task to tasks blocked by it
+// and reports whether target task is reached
+func (tds *TaskDependencyService) isReachable(ctx context.Context, fromId, targetId sqlddl.ID) (bool, error) {
+	visited := map[sqlddl.ID]bool{fromId: true}
+	frontier := []sqlddl.ID{fromId}
+	for len(frontier) > 0 {
+		if visited[targetId] {
+			return true, nil
+		}
+		blocked, searchErr := tds.TaskDependencyRepository.FindBlocked(ctx, frontier)
+		if searchErr != nil {
+			return false, searchErr
+		}
+		var next []sqlddl.ID
+		for _, taskId := range frontier {
+			for _, reference := range blocked[taskId] {
+				if !visited[reference.ID] {
+					visited[reference.ID] = true
+					next = append(next, reference.ID)
+				}
+			}
+		}
+		frontier = next
+	}
+	return visited[targetId], nil
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// graphDependencyRepository keeps dependencies as adjacency list from blocker to blocked tasks
+type graphDependencyRepository struct {
+	blocks map[sqlddl.ID][]sqlddl.ID
+}
+
+func (gdr *graphDependencyRepository) Create(_ context.Context, d *models.TaskDependency) error {
+	gdr.blocks[d.BlockerID] = append(gdr.blocks[d.BlockerID], d.BlockedID)
+	return nil
+}
+
+func (gdr *graphDependencyRepository) Delete(context.Context, sqlddl.ID, sqlddl.ID) error {
+	return nil
+}
+
+func (gdr *graphDependencyRepository) Lock(context.Context) error {
+	return nil
+}
+
+func (gdr *graphDependencyRepository) FindBlockers(
+	context.Context,
+	[]sqlddl.ID,
+) (map[sqlddl.ID][]models.TaskReference, error) {
+	return nil, nil
+}
+
+func (gdr *graphDependencyRepository) FindBlocked(
+	_ context.Context,
+	taskIds []sqlddl.ID,
+) (map[sqlddl.ID][]models.TaskReference, error) {
+	blocked := make(map[sqlddl.ID][]models.TaskReference)
+	for _, taskId := range taskIds {
+		for _, blockedId := range gdr.blocks[taskId] {
+			blocked[taskId] = append(blocked[taskId], models.TaskReference{ID: blockedId})
+		}
+	}
+	return blocked, nil
+}
+
+func TestTaskDependencyServiceIsReachable(t *testing.T) {
+	repo := &graphDependencyRepository{blocks: map[sqlddl.ID][]sqlddl.ID{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e"},
+		"x": {"y"},
+		"y": {"x"},
+	}}
+	tds := &TaskDependencyService{TaskDependencyRepository: repo}
+	cases := []struct {
+		name     string
+		from, to sqlddl.ID
+		expected bool
+	}{
+		{"Direct link", "a", "b", true},
+		{"Transitive link", "a", "e", true},
+		{"Opposite direction", "e", "a", false},
+		{"Unrelated tasks", "a", "x", false},
+		{"Existing cycle doesn't loop forever", "x", "a", false},
+		{"Task without links", "z", "a", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reachable, walkErr := tds.isReachable(context.Background(), c.from, c.to)
+			if walkErr != nil {
+				t.Fatalf("unexpected error: %v", walkErr)
+			}
+			if reachable != c.expected {
+				t.Fatalf("got %t, expected %t", reachable, c.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/sqlddl"
+)
+
+type TaskHistoryService struct {
+	interfaces.TaskHistoryRepository
+}
+
+func NewTaskHistoryService(repo interfaces.TaskHistoryRepository) *TaskHistoryService {
+	return &TaskHistoryService{repo}
+}
+
+// ListTaskHistory returns page of task changes, the newest first
+func (ths *TaskHistoryService) ListTaskHistory(
+	ctx context.Context,
+	taskId sqlddl.ID,
+	d *PageData,
+) ([]models.TaskHistoryEntry, error) {
+	entries, searchErr := ths.TaskHistoryRepository.FindAllByTaskID(ctx, taskId, d.limit(), d.Offset)
+	return entries, searchErr
+}
+
+// ListBoardActivity returns page of changes of all board tasks including deleted ones, the newest first
+func (ths *TaskHistoryService) ListBoardActivity(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *PageData,
+) ([]models.TaskHistoryEntry, error) {
+	entries, searchErr := ths.TaskHistoryRepository.FindAllByBoardID(ctx, boardId, d.limit(), d.Offset)
+	return entries, searchErr
+}
+
+// diffTasks returns history entry for each field which differs between two states of the same task,
+// entries are not bound to actor
+func diffTasks(before, after *models.Task) []models.TaskHistoryEntry {
+	fields := []struct {
+		name     string
+		action   models.TaskHistoryAction
+		old, new string
+	}{
+		{"name", models.TaskHistoryUpdated, before.Name, after.Name},
+		{"description", models.TaskHistoryUpdated, before.Description, after.Description},
+		{"assignee_id", models.TaskHistoryReassigned, string(before.AssigneeID), string(after.AssigneeID)},
+		{"status", models.TaskHistoryStatusChanged, formatStatus(before.Status), formatStatus(after.Status)},
+		{"column_id", models.TaskHistoryMoved, string(before.ColumnID), string(after.ColumnID)},
+		{"position", models.TaskHistoryMoved, strconv.Itoa(before.Position), strconv.Itoa(after.Position)},
+		{"start_at", models.TaskHistoryUpdated, formatTime(before.StartAt), formatTime(after.StartAt)},
+		{"due_at", models.TaskHistoryUpdated, formatTime(before.DueAt), formatTime(after.DueAt)},
+		{"labels", models.TaskHistoryUpdated, formatLabels(before.Labels), formatLabels(after.Labels)},
+	}
+	var entries []models.TaskHistoryEntry
+	for _, field := range fields {
+		if field.old == field.new {
+			continue
+		}
+		entries = append(entries, models.TaskHistoryEntry{
+			BoardID:   after.BoardID,
+			TaskOrder: after.Order,
+			Action:    field.action,
+			Field:     field.name,
+			OldValue:  historyValue(field.old),
+			NewValue:  historyValue(field.new),
+		})
+	}
+	return entries
+}
+
+// historyValue converts empty value of field to nil
+func historyValue(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func formatStatus(status models.TaskStatus) string {
+	return strconv.Itoa(int(status))
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatLabels(labels []models.Label) string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/validation"
+)
+
+// MaxTaskImportRows is the most rows which may be imported at once
+const MaxTaskImportRows = 1000
+
+// byteOrderMark is prepended to CSV files by some spreadsheet editors
+const byteOrderMark = "\ufeff"
+
+// columns of imported CSV, they are also keys of row errors
+const (
+	csvColumnName        = "name"
+	csvColumnDescription = "description"
+	csvColumnStatus      = "status"
+	csvColumnAssignee    = "assignee"
+	csvColumnDueDate     = "due_date"
+)
+
+type (
+	// TaskImportService creates tasks of board from rows of CSV
+	TaskImportService struct {
+		*TaskService
+		UserService
+	}
+	// TaskImportRow is task described by row of imported CSV
+	TaskImportRow struct {
+		// Line is number of CSV line which row starts at, header is the first line
+		Line        int
+		Name        string `validate:"required,min=3,max=255,trimmed"`
+		Description string `validate:"max=1000,trimmed"`
+		// Status selects the first board column of this status, the first board column is used if it's empty
+		Status models.TaskStatus `validate:"omitempty,oneof=1 2 3"`
+		// Assignee is username or email of board member, importer is assigned if it's empty
+		Assignee string
+		DueDate  *time.Time
+		// Errors are messages of invalid fields keyed by CSV columns
+		Errors map[string]string
+	}
+	ImportTasksData struct {
+		Rows []TaskImportRow
+		// DryRun checks rows without creating tasks
+		DryRun bool
+	}
+	// TaskImportRowError is validation error of CSV row
+	TaskImportRowError struct {
+		Line int `json:"line"`
+		validation.ErrorHTTPResponse
+	}
+	// TaskImportReport is result of import, tasks are created only if none of rows has errors
+	TaskImportReport struct {
+		DryRun bool                 `json:"dry_run"`
+		Rows   int                  `json:"rows"`
+		Errors []TaskImportRowError `json:"errors"`
+		Tasks  []models.Task        `json:"tasks"`
+	}
+	// taskImportPlan is tasks prepared from valid rows and errors of invalid ones
+	taskImportPlan struct {
+		tasks  []models.Task
+		errors []TaskImportRowError
+	}
+)
+
+var (
+	ErrorInvalidCSVHeader   = errors.New("invalid CSV header")
+	ErrorTooManyImportRows  = fmt.Errorf("no more than %d rows may be imported at once", MaxTaskImportRows)
+	invalidTaskStatusErr    = errors.New("status must be one of 1, 2, 3, backlog, process, done")
+	invalidDueDateErr       = errors.New("due date must be date or time in RFC 3339 format")
+	columnOfStatusMissedErr = errors.New("board has no column of this status")
+	csvColumns              = []string{
+		csvColumnName,
+		csvColumnDescription,
+		csvColumnStatus,
+		csvColumnAssignee,
+		csvColumnDueDate,
+	}
+	taskStatusNames = map[string]models.TaskStatus{
+		"backlog": models.TaskStatusBacklog,
+		"process": models.TaskStatusProcess,
+		"done":    models.TaskStatusDone,
+	}
+)
+
+func NewTaskImportService(ts *TaskService, userService UserService) *TaskImportService {
+	return &TaskImportService{ts, userService}
+}
+
+// ParseTasksCSV reads rows of CSV with header, header columns may go in any order and only name is required,
+// values which can't be parsed are recorded in errors of their rows
+func ParseTasksCSV(r io.Reader) ([]TaskImportRow, error) {
+	csvReader := csv.NewReader(r)
+	header, readErr := csvReader.Read()
+	if errors.Is(readErr, io.EOF) {
+		return nil, fmt.Errorf("%w: header is missing", ErrorInvalidCSVHeader)
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	columns := make(map[string]int, len(header))
+	for i, title := range header {
+		column := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(title, byteOrderMark)))
+		if !slices.Contains(csvColumns, column) {
+			return nil, fmt.Errorf("%w: unknown column '%s'", ErrorInvalidCSVHeader, title)
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("%w: column '%s' is repeated", ErrorInvalidCSVHeader, column)
+		}
+		columns[column] = i
+	}
+	if _, ok := columns[csvColumnName]; !ok {
+		return nil, fmt.Errorf("%w: column '%s' is required", ErrorInvalidCSVHeader, csvColumnName)
+	}
+	var rows []TaskImportRow
+	for {
+		record, readErr := csvReader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+		if len(rows) == MaxTaskImportRows {
+			return nil, ErrorTooManyImportRows
+		}
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		line, _ := csvReader.FieldPos(0)
+		row := TaskImportRow{
+			Line:        line,
+			Name:        value(csvColumnName),
+			Description: value(csvColumnDescription),
+			Assignee:    value(csvColumnAssignee),
+			Errors:      make(map[string]string),
+		}
+		if status := value(csvColumnStatus); status != "" {
+			parsedStatus, parseErr := parseTaskStatus(status)
+			if parseErr != nil {
+				row.Errors[csvColumnStatus] = parseErr.Error()
+			}
+			row.Status = parsedStatus
+		}
+		if dueDate := value(csvColumnDueDate); dueDate != "" {
+			parsedDate, parseErr := parseDueDate(dueDate)
+			if parseErr != nil {
+				row.Errors[csvColumnDueDate] = parseErr.Error()
+			}
+			row.DueDate = parsedDate
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseTaskStatus reads status by its number or name
+func parseTaskStatus(value string) (models.TaskStatus, error) {
+	if status, ok := taskStatusNames[strings.ToLower(value)]; ok {
+		return status, nil
+	}
+	number, parseErr := strconv.Atoi(value)
+	if parseErr != nil {
+		return 0, invalidTaskStatusErr
+	}
+	status := models.TaskStatus(number)
+	if status < models.TaskStatusBacklog || status > models.TaskStatusDone {
+		return 0, invalidTaskStatusErr
+	}
+	return status, nil
+}
+
+// parseDueDate reads time in RFC 3339 format or date which is treated as midnight of UTC
+func parseDueDate(value string) (*time.Time, error) {
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, parseErr := time.Parse(layout, value); parseErr == nil {
+			return &parsed, nil
+		}
+	}
+	return nil, invalidDueDateErr
+}
+
+// ImportTasks checks rows against board and creates tasks of them in one transaction, tasks get orders
+// in sequence of rows and are placed to the end of their columns. Nothing is created if any row is invalid
+// or it's dry run, errors of rows are returned in report then
+func (tis *TaskImportService) ImportTasks(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *ImportTasksData,
+) (*TaskImportReport, error) {
+	if _, userIdErr := contextkeys.GetUserId(ctx); userIdErr != nil {
+		return nil, userIdErr
+	}
+	report := &TaskImportReport{
+		DryRun: d.DryRun,
+		Rows:   len(d.Rows),
+		Errors: []TaskImportRowError{},
+		Tasks:  []models.Task{},
+	}
+	if d.DryRun {
+		plan, planErr := tis.planImport(ctx, boardId, d.Rows)
+		if planErr != nil {
+			return nil, planErr
+		}
+		report.Errors = append(report.Errors, plan.errors...)
+		return report, nil
+	}
+	var ids []sqlddl.ID
+	txErr := tis.WithinTransaction(ctx, func(ctx context.Context) error {
+		// lock prevents concurrent creations and moves from taking the same order or position
+		if lockErr := tis.BoardColumnRepository.Lock(ctx, boardId); lockErr != nil {
+			return lockErr
+		}
+		plan, planErr := tis.planImport(ctx, boardId, d.Rows)
+		if planErr != nil {
+			return planErr
+		}
+		if len(plan.errors) > 0 {
+			report.Errors = append(report.Errors, plan.errors...)
+			return nil
+		}
+		for _, task := range plan.tasks {
+			if creationErr := tis.TaskRepository.Create(ctx, &task); creationErr != nil {
+				return creationErr
+			}
+			createdTask, searchErr := tis.TaskService.FindByID(ctx, task.ID)
+			if searchErr != nil {
+				return searchErr
+			}
+			if historyErr := tis.recordTaskHistory(ctx, nil, createdTask); historyErr != nil {
+				return historyErr
+			}
+			ids = append(ids, task.ID)
+		}
+		return nil
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	for _, id := range ids {
+		createdTask, searchErr := tis.TaskService.FindByID(ctx, id)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		report.Tasks = append(report.Tasks, *createdTask)
+		tis.PublishBoardEvent(newBoardEvent(ctx, models.BoardEventTaskCreated, boardId, createdTask))
+	}
+	return report, nil
+}
+
+// planImport checks rows against board state and prepares tasks of valid rows,
+// names have to be unique among board tasks and rows and columns have to fit rows under their WIP limits
+func (tis *TaskImportService) planImport(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	rows []TaskImportRow,
+) (*taskImportPlan, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	columns, columnsErr := tis.BoardColumnRepository.FindAllByBoardID(ctx, boardId)
+	if columnsErr != nil {
+		return nil, columnsErr
+	}
+	if len(columns) == 0 {
+		return nil, columnNotExistsErr
+	}
+	boardTasks, boardTasksErr := tis.TaskRepository.FindAllByBoardId(ctx, boardId)
+	if boardTasksErr != nil {
+		return nil, boardTasksErr
+	}
+	names := make(map[string]bool, len(boardTasks)+len(rows))
+	for _, task := range boardTasks {
+		names[task.Name] = true
+	}
+	counts := make(map[sqlddl.ID]int, len(columns))
+	for _, column := range columns {
+		count, countErr := tis.TaskRepository.CountByColumnID(ctx, column.ID)
+		if countErr != nil {
+			return nil, countErr
+		}
+		counts[column.ID] = count
+	}
+	assignees := make(map[string]sqlddl.ID)
+	order := tis.findMaxTasksOrder(boardTasks)
+	plan := &taskImportPlan{}
+	for _, row := range rows {
+		fields := maps.Clone(row.Errors)
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+		if names[row.Name] {
+			fields[csvColumnName] = taskAlreadyExistsErr.Error()
+		}
+		column := columnOfStatus(columns, row.Status)
+		if column == nil {
+			fields[csvColumnStatus] = columnOfStatusMissedErr.Error()
+		} else if _, limitErr := checkWIPLimit(column, counts[column.ID], false); limitErr != nil {
+			fields[csvColumnStatus] = limitErr.Error()
+		}
+		assigneeId := userId
+		if row.Assignee != "" {
+			assigneeId = tis.resolveAssignee(ctx, boardId, row.Assignee, assignees)
+			if assigneeId == "" {
+				fields[csvColumnAssignee] = ErrorAssigneeNotMember.Error()
+			}
+		}
+		if len(fields) > 0 {
+			plan.errors = append(plan.errors, TaskImportRowError{
+				Line:              row.Line,
+				ErrorHTTPResponse: validation.NewFieldsErr(fields),
+			})
+			continue
+		}
+		names[row.Name] = true
+		counts[column.ID]++
+		order++
+		plan.tasks = append(plan.tasks, models.Task{
+			Model:       models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+			Name:        row.Name,
+			Description: row.Description,
+			BoardID:     boardId,
+			CreatorID:   userId,
+			AssigneeID:  assigneeId,
+			ColumnID:    column.ID,
+			Status:      column.Status,
+			Order:       order,
+			Position:    counts[column.ID],
+			DueAt:       row.DueDate,
+		})
+	}
+	return plan, nil
+}
+
+// columnOfStatus finds the first column of status, the first column of board is returned for empty status
+func columnOfStatus(columns []models.BoardColumn, status models.TaskStatus) *models.BoardColumn {
+	for i := range columns {
+		if status == 0 || columns[i].Status == status {
+			return &columns[i]
+		}
+	}
+	return nil
+}
+
+// resolveAssignee finds board member by email or username, empty identifier is returned if member isn't found
+func (tis *TaskImportService) resolveAssignee(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	reference string,
+	resolved map[string]sqlddl.ID,
+) sqlddl.ID {
+	if userId, ok := resolved[reference]; ok {
+		return userId
+	}
+	var user *models.User
+	var searchErr error
+	if strings.Contains(reference, "@") {
+		user, searchErr = tis.UserService.FindByEmail(ctx, reference)
+	} else {
+		user, searchErr = tis.UserService.FindByUsername(ctx, reference)
+	}
+	var userId sqlddl.ID
+	if searchErr == nil && tis.checkTaskAssignee(ctx, boardId, user.ID) == nil {
+		userId = user.ID
+	}
+	resolved[reference] = userId
+	return userId
+}
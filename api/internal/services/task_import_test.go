@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+)
+
+func TestParseTasksCSV(t *testing.T) {
+	t.Run("Columns are mapped by header", func(t *testing.T) {
+		content := byteOrderMark + "Due_Date,name,Assignee,status\n" +
+			"2030-01-02, Write docs ,alice,process\n" +
+			"\"2030-01-02T10:00:00Z\",\"Multi\nline\",bob@example.com,3\n"
+		rows, parseErr := ParseTasksCSV(strings.NewReader(content))
+		if parseErr != nil {
+			t.Fatal(parseErr)
+		}
+		if len(rows) != 2 {
+			t.Fatalf("got %d rows, expected 2", len(rows))
+		}
+		first, second := rows[0], rows[1]
+		if first.Line != 2 || first.Name != "Write docs" || first.Assignee != "alice" ||
+			first.Status != models.TaskStatusProcess || !first.DueDate.Equal(time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("got first row %+v", first)
+		}
+		if second.Line != 3 || second.Status != models.TaskStatusDone || second.Assignee != "bob@example.com" ||
+			!second.DueDate.Equal(time.Date(2030, 1, 2, 10, 0, 0, 0, time.UTC)) {
+			t.Fatalf("got second row %+v", second)
+		}
+		if len(first.Errors) != 0 || len(second.Errors) != 0 {
+			t.Fatalf("got errors %v and %v", first.Errors, second.Errors)
+		}
+	})
+
+	t.Run("Unparsed values are recorded as row errors", func(t *testing.T) {
+		rows, parseErr := ParseTasksCSV(strings.NewReader("name,status,due_date\nTask,review,tomorrow\nNext,4,\n"))
+		if parseErr != nil {
+			t.Fatal(parseErr)
+		}
+		if rows[0].Errors[csvColumnStatus] == "" || rows[0].Errors[csvColumnDueDate] == "" {
+			t.Fatalf("got errors %v", rows[0].Errors)
+		}
+		if rows[1].Errors[csvColumnStatus] == "" || rows[1].DueDate != nil {
+			t.Fatalf("got row %+v", rows[1])
+		}
+	})
+
+	headerCases := []struct {
+		name, content string
+	}{
+		{"Empty file", ""},
+		{"Unknown column", "name,priority\n"},
+		{"Repeated column", "name,Name\n"},
+		{"Missing name column", "description,status\n"},
+	}
+	for _, c := range headerCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, parseErr := ParseTasksCSV(strings.NewReader(c.content))
+			if !errors.Is(parseErr, ErrorInvalidCSVHeader) {
+				t.Fatalf("got error %v, expected %v", parseErr, ErrorInvalidCSVHeader)
+			}
+		})
+	}
+
+	t.Run("Too many rows", func(t *testing.T) {
+		content := "name\n" + strings.Repeat("Task\n", MaxTaskImportRows+1)
+		if _, parseErr := ParseTasksCSV(strings.NewReader(content)); !errors.Is(parseErr, ErrorTooManyImportRows) {
+			t.Fatalf("got error %v, expected %v", parseErr, ErrorTooManyImportRows)
+		}
+	})
+}
+
+func TestColumnOfStatus(t *testing.T) {
+	columns := []models.BoardColumn{
+		{Model: models.Model{ID: "todo"}, Status: models.TaskStatusBacklog},
+		{Model: models.Model{ID: "doing"}, Status: models.TaskStatusProcess},
+		{Model: models.Model{ID: "review"}, Status: models.TaskStatusProcess},
+	}
+	if column := columnOfStatus(columns, 0); column == nil || column.ID != "todo" {
+		t.Fatalf("got column %+v for empty status", column)
+	}
+	if column := columnOfStatus(columns, models.TaskStatusProcess); column == nil || column.ID != "doing" {
+		t.Fatalf("got column %+v for process status", column)
+	}
+	if column := columnOfStatus(columns, models.TaskStatusDone); column != nil {
+		t.Fatalf("got column %+v for done status", column)
+	}
+}
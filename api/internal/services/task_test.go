@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/sqlddl"
+)
+
+// memoryTaskRepository keeps tasks in memory, only what moving of tasks needs is supported
+type memoryTaskRepository struct {
+	interfaces.TaskRepository
+	tasks map[sqlddl.ID]*models.Task
+}
+
+func (mtr *memoryTaskRepository) FindByID(_ context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	task, ok := mtr.tasks[taskId]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	found := *task
+	return &found, nil
+}
+
+func (mtr *memoryTaskRepository) CountByColumnID(_ context.Context, columnId sqlddl.ID) (int, error) {
+	count := 0
+	for _, task := range mtr.tasks {
+		if task.ColumnID == columnId && task.Position > 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (mtr *memoryTaskRepository) ShiftPositions(_ context.Context, columnId sqlddl.ID, fromPosition, delta int) error {
+	for _, task := range mtr.tasks {
+		if task.ColumnID == columnId && task.Position >= fromPosition {
+			task.Position += delta
+		}
+	}
+	return nil
+}
+
+func (mtr *memoryTaskRepository) Update(_ context.Context, taskId sqlddl.ID, d *models.UpdateTask) error {
+	task := mtr.tasks[taskId]
+	if d.ColumnID != nil {
+		task.ColumnID = *d.ColumnID
+	}
+	if d.Status != nil {
+		task.Status = *d.Status
+	}
+	if d.Position != nil {
+		task.Position = *d.Position
+	}
+	return nil
+}
+
+// columnTasks gives identifiers of tasks placed into column by their positions,
+// positions must go from 1 without gaps and repeats
+func (mtr *memoryTaskRepository) columnTasks(t *testing.T, columnId sqlddl.ID) []sqlddl.ID {
+	var placed []*models.Task
+	for _, task := range mtr.tasks {
+		if task.ColumnID == columnId && task.Position > 0 {
+			placed = append(placed, task)
+		}
+	}
+	slices.SortFunc(placed, func(a, b *models.Task) int {
+		return a.Position - b.Position
+	})
+	taskIds := make([]sqlddl.ID, 0, len(placed))
+	for i, task := range placed {
+		if task.Position != i+1 {
+			t.Fatalf("got task %s at position %d of column %s, expected %d", task.ID, task.Position, columnId, i+1)
+		}
+		taskIds = append(taskIds, task.ID)
+	}
+	return taskIds
+}
+
+// memoryColumnRepository keeps board columns in memory, locks aren't needed without concurrency
+type memoryColumnRepository struct {
+	interfaces.BoardColumnRepository
+	columns map[sqlddl.ID]*models.BoardColumn
+}
+
+func (mcr memoryColumnRepository) FindByID(_ context.Context, columnId sqlddl.ID) (*models.BoardColumn, error) {
+	column, ok := mcr.columns[columnId]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	found := *column
+	return &found, nil
+}
+
+func (mcr memoryColumnRepository) Lock(context.Context, sqlddl.ID) error {
+	return nil
+}
+
+// emptyLabels is storage of labels without any
+type emptyLabels struct {
+	interfaces.LabelRepository
+}
+
+func (emptyLabels) FindAllByTaskIDs(context.Context, []sqlddl.ID) (map[sqlddl.ID][]models.Label, error) {
+	return nil, nil
+}
+
+// emptyChecklists is storage of checklist items without any
+type emptyChecklists struct {
+	interfaces.ChecklistItemRepository
+}
+
+func (emptyChecklists) CountProgressByTaskIDs(
+	context.Context,
+	[]sqlddl.ID,
+) (map[sqlddl.ID]models.ChecklistProgress, error) {
+	return nil, nil
+}
+
+// emptyDependencies is storage of task dependencies without any
+type emptyDependencies struct {
+	interfaces.TaskDependencyRepository
+}
+
+func (emptyDependencies) FindBlockers(context.Context, []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error) {
+	return nil, nil
+}
+
+func (emptyDependencies) FindBlocked(context.Context, []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error) {
+	return nil, nil
+}
+
+// discardedHistory is storage of task history which drops new entries
+type discardedHistory struct {
+	interfaces.TaskHistoryRepository
+}
+
+func (discardedHistory) Create(context.Context, *models.TaskHistoryEntry) error {
+	return nil
+}
+
+// newMoveService creates service for board with todo column of tasks a, b, c, d, doing column of tasks x, y
+// and archived task h of todo column
+func newMoveService() (*TaskService, *memoryTaskRepository) {
+	columns := map[sqlddl.ID]*models.BoardColumn{
+		"todo":  {Model: models.Model{ID: "todo"}, BoardID: "board", Status: models.TaskStatusBacklog},
+		"doing": {Model: models.Model{ID: "doing"}, BoardID: "board", Status: models.TaskStatusProcess},
+	}
+	repo := &memoryTaskRepository{tasks: make(map[sqlddl.ID]*models.Task)}
+	place := func(taskId, columnId sqlddl.ID, position int) {
+		repo.tasks[taskId] = &models.Task{
+			Model:    models.Model{ID: taskId},
+			BoardID:  "board",
+			Name:     string(taskId),
+			ColumnID: columnId,
+			Status:   columns[columnId].Status,
+			Position: position,
+		}
+	}
+	for i, taskId := range []sqlddl.ID{"a", "b", "c", "d"} {
+		place(taskId, "todo", i+1)
+	}
+	for i, taskId := range []sqlddl.ID{"x", "y"} {
+		place(taskId, "doing", i+1)
+	}
+	place("h", "todo", 0)
+	archivedAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.tasks["h"].ArchivedAt = &archivedAt
+	return NewTaskService(
+		repo,
+		memoryColumnRepository{columns: columns},
+		emptyLabels{},
+		emptyChecklists{},
+		discardedHistory{},
+		emptyDependencies{},
+		nil,
+		immediateTransactor{},
+		SystemClock{},
+		NewBoardEventHub(SystemClock{}, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout),
+	), repo
+}
+
+func TestCheckWIPLimit(t *testing.T) {
+	cases := []struct {
+		name               string
+		limit, tasksCount  int
+		override           bool
+		expectedOverridden bool
+		expectedErr        error
+	}{
+		{"Column without limit", 0, 100, false, false, nil},
+		{"Column has room", 3, 2, false, false, nil},
+		{"Full column", 3, 3, false, false, ErrorWIPLimitExceeded},
+		{"Overfilled column", 3, 5, false, false, ErrorWIPLimitExceeded},
+		{"Override of full column", 3, 3, true, true, nil},
+		{"Override isn't used when column has room", 3, 1, true, false, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			column := &models.BoardColumn{WIPLimit: c.limit}
+			overridden, limitErr := checkWIPLimit(column, c.tasksCount, c.override)
+			if !errors.Is(limitErr, c.expectedErr) {
+				t.Fatalf("got error %v, expected %v", limitErr, c.expectedErr)
+			}
+			if overridden != c.expectedOverridden {
+				t.Fatalf("got overridden %t, expected %t", overridden, c.expectedOverridden)
+			}
+		})
+	}
+}
+
+func TestTaskServiceMoveTask(t *testing.T) {
+	ctx := withUser("alice")
+	cases := []struct {
+		name          string
+		taskId        sqlddl.ID
+		data          MoveTaskData
+		expectedTodo  []sqlddl.ID
+		expectedDoing []sqlddl.ID
+	}{
+		{"Up within column", "d", MoveTaskData{ColumnID: "todo", Position: 2}, []sqlddl.ID{"a", "d", "b", "c"}, nil},
+		{"Down within column", "a", MoveTaskData{ColumnID: "todo", Position: 3}, []sqlddl.ID{"b", "c", "a", "d"}, nil},
+		{"To the same place", "b", MoveTaskData{ColumnID: "todo", Position: 2}, []sqlddl.ID{"a", "b", "c", "d"}, nil},
+		{
+			"Across columns",
+			"b",
+			MoveTaskData{ColumnID: "doing", Position: 1},
+			[]sqlddl.ID{"a", "c", "d"},
+			[]sqlddl.ID{"b", "x", "y"},
+		},
+		{
+			"To the end of another column",
+			"a",
+			MoveTaskData{ColumnID: "doing"},
+			[]sqlddl.ID{"b", "c", "d"},
+			[]sqlddl.ID{"x", "y", "a"},
+		},
+		{
+			"Past the end of column",
+			"x",
+			MoveTaskData{ColumnID: "todo", Position: 10},
+			[]sqlddl.ID{"a", "b", "c", "d", "x"},
+			[]sqlddl.ID{"y"},
+		},
+		{"To the end of own column", "b", MoveTaskData{ColumnID: "todo"}, []sqlddl.ID{"a", "c", "d", "b"}, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ts, repo := newMoveService()
+			if c.expectedDoing == nil {
+				c.expectedDoing = []sqlddl.ID{"x", "y"}
+			}
+			movedTask, moveErr := ts.MoveTask(ctx, c.taskId, &c.data)
+			if moveErr != nil {
+				t.Fatal(moveErr)
+			}
+			if todo := repo.columnTasks(t, "todo"); !slices.Equal(todo, c.expectedTodo) {
+				t.Fatalf("got todo column %v, expected %v", todo, c.expectedTodo)
+			}
+			if doing := repo.columnTasks(t, "doing"); !slices.Equal(doing, c.expectedDoing) {
+				t.Fatalf("got doing column %v, expected %v", doing, c.expectedDoing)
+			}
+			if movedTask.ColumnID != c.data.ColumnID || movedTask.Status != repo.tasks[c.taskId].Status {
+				t.Fatalf("got moved task %+v", movedTask)
+			}
+			if hidden := repo.tasks["h"]; hidden.Position != 0 || hidden.ColumnID != "todo" {
+				t.Fatalf("got archived task %+v", hidden)
+			}
+		})
+	}
+
+	t.Run("Moved task takes status of column", func(t *testing.T) {
+		ts, repo := newMoveService()
+		if _, moveErr := ts.MoveTask(ctx, "a", &MoveTaskData{ColumnID: "doing"}); moveErr != nil {
+			t.Fatal(moveErr)
+		}
+		if status := repo.tasks["a"].Status; status != models.TaskStatusProcess {
+			t.Fatalf("got status %d, expected %d", status, models.TaskStatusProcess)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"just-kanban/internal/access"
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+type (
+	// TrashService lists boards and tasks moved to trash and restores them on behalf of requester
+	TrashService struct {
+		*BoardService
+		*BoardMemberService
+	}
+	// Trash is boards and tasks moved to trash which requester is allowed to restore
+	Trash struct {
+		Boards []models.Board `json:"boards"`
+		Tasks  []models.Task  `json:"tasks"`
+	}
+)
+
+var (
+	ErrorNotInTrash        = errors.New("item is not in trash")
+	ErrorRestoreNotAllowed = errors.New("requester is not allowed to restore item")
+	ErrorBoardInTrash      = errors.New("board of task is in trash, it has to be restored first")
+)
+
+func NewTrashService(bs *BoardService, bms *BoardMemberService) *TrashService {
+	return &TrashService{bs, bms}
+}
+
+// ListTrash returns boards and tasks moved to trash which requester is allowed to restore,
+// the latest deleted first
+func (trs *TrashService) ListTrash(ctx context.Context) (*Trash, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	boards, searchErr := trs.BoardService.ListDeletedBoards(ctx, userId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	tasks, searchErr := trs.BoardService.TaskService.ListDeletedTasks(ctx, userId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	trash := Trash{Boards: make([]models.Board, 0, len(boards)), Tasks: make([]models.Task, 0, len(tasks))}
+	for _, board := range boards {
+		if trs.BoardMemberService.Can(ctx, userId, board.ID, access.PermissionBoardDelete) {
+			trash.Boards = append(trash.Boards, board)
+		}
+	}
+	for _, task := range tasks {
+		if trs.canRestoreTask(ctx, userId, &task) {
+			trash.Tasks = append(trash.Tasks, task)
+		}
+	}
+	return &trash, nil
+}
+
+// RestoreBoard returns board from trash, only members allowed to delete board may restore it
+func (trs *TrashService) RestoreBoard(ctx context.Context, boardId sqlddl.ID) (*models.Board, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	if _, searchErr := trs.BoardService.BoardRepository.FindDeletedByID(ctx, boardId); searchErr != nil {
+		return nil, ErrorNotInTrash
+	}
+	if !trs.BoardMemberService.Can(ctx, userId, boardId, access.PermissionBoardDelete) {
+		return nil, ErrorRestoreNotAllowed
+	}
+	return trs.BoardService.RestoreBoard(ctx, boardId)
+}
+
+// RestoreTask returns task from trash, only members allowed to delete task may restore it
+// and task of board moved to trash can't be restored before its board
+func (trs *TrashService) RestoreTask(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	task, searchErr := trs.BoardService.TaskService.TaskRepository.FindDeletedByID(ctx, taskId)
+	if searchErr != nil {
+		return nil, ErrorNotInTrash
+	}
+	if !trs.canRestoreTask(ctx, userId, task) {
+		return nil, ErrorRestoreNotAllowed
+	}
+	if _, boardErr := trs.BoardService.FindBoardByID(ctx, task.BoardID); boardErr != nil {
+		return nil, ErrorBoardInTrash
+	}
+	return trs.BoardService.TaskService.RestoreTask(ctx, taskId)
+}
+
+// canRestoreTask checks user is task creator or is allowed to delete any task of its board
+func (trs *TrashService) canRestoreTask(ctx context.Context, userId sqlddl.ID, task *models.Task) bool {
+	if task.CreatorID == userId {
+		_, memberErr := trs.BoardMemberService.FindBoardMemberByUserID(ctx, task.BoardID, userId)
+		return memberErr == nil
+	}
+	return trs.BoardMemberService.Can(ctx, userId, task.BoardID, access.PermissionTaskDeleteAny)
+}
@@ -18,11 +18,20 @@ type (
 		UpdateUser(ctx context.Context, id sqlddl.ID, d *UpdateUserData) (*models.User, error)
 		IsUpdateAllowed(ctx context.Context, userId, targetId sqlddl.ID) bool
 		ListUsers(ctx context.Context) ([]models.User, error)
+		ListUsersPage(ctx context.Context, d *KeysetPageData) (*UsersPage, error)
 		DeleteUser(ctx context.Context, id sqlddl.ID) error
 	}
 
 	userService struct {
 		interfaces.UserRepository
+		Paginator *KeysetPaginator
+	}
+
+	// UsersPage is page of users listing
+	UsersPage struct {
+		Users []models.User
+		// NextCursor is cursor of the next page, empty for the last page
+		NextCursor string
 	}
 
 	CreateUserData struct {
@@ -47,8 +56,8 @@ var (
 	updateNotAllowedErr = errors.New("not allowed")
 )
 
-func NewUserService(userRepository interfaces.UserRepository) UserService {
-	return &userService{userRepository}
+func NewUserService(userRepository interfaces.UserRepository, paginator *KeysetPaginator) UserService {
+	return &userService{userRepository, paginator}
 }
 
 func (us *userService) CreateUser(ctx context.Context, d *CreateUserData) (*models.User, error) {
@@ -101,6 +110,7 @@ func (us *userService) IsUpdateAllowed(ctx context.Context, userId, targetId sql
 	return userId == targetId
 }
 
+// ListUsers searches for all users, it's meant for internal callers only, clients get users by pages
 func (us *userService) ListUsers(ctx context.Context) ([]models.User, error) {
 	users, searchErr := us.UserRepository.FindAll(ctx)
 	if searchErr != nil {
@@ -109,6 +119,29 @@ func (us *userService) ListUsers(ctx context.Context) ([]models.User, error) {
 	return users, nil
 }
 
+func (us *userService) ListUsersPage(ctx context.Context, d *KeysetPageData) (*UsersPage, error) {
+	after, cursorErr := us.Paginator.after(d)
+	if cursorErr != nil {
+		return nil, cursorErr
+	}
+	limit := us.Paginator.limit(d)
+	// one more user is requested to know if the next page exists
+	users, searchErr := us.UserRepository.FindPage(ctx, after, limit+1)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	page := UsersPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		nextCursor, encodeErr := us.Paginator.next(&page.Users[limit-1].Model)
+		if encodeErr != nil {
+			return nil, encodeErr
+		}
+		page.NextCursor = nextCursor
+	}
+	return &page, nil
+}
+
 func (us *userService) DeleteUser(ctx context.Context, id sqlddl.ID) error {
 	_, searchErr := us.UserRepository.FindByID(ctx, id)
 	if searchErr != nil {
@@ -0,0 +1,424 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"just-kanban/internal/contextkeys"
+	"just-kanban/internal/models"
+	"just-kanban/internal/repositories/interfaces"
+	"just-kanban/pkg/identifier"
+	"just-kanban/pkg/sqlddl"
+	"just-kanban/pkg/tcp"
+)
+
+const (
+	// DefaultWebhookMaxAttempts is count of attempts to deliver event to webhook before delivery is failed
+	DefaultWebhookMaxAttempts = 5
+	// DefaultWebhookRetryDelay is pause before the first retry, every next pause is twice as long
+	DefaultWebhookRetryDelay = 2 * time.Second
+	// DefaultWebhookMaxRetryDelay is the longest pause between retries
+	DefaultWebhookMaxRetryDelay = 5 * time.Minute
+	// DefaultWebhookDisableAfter is count of consecutive failed deliveries which disables webhook
+	DefaultWebhookDisableAfter = 10
+	// DefaultWebhookTimeout is how long webhook may take to respond
+	DefaultWebhookTimeout = 10 * time.Second
+	// webhookQueueSize is count of events waiting for dispatch, later events are dropped while queue is full
+	webhookQueueSize = 1024
+	// webhookResponseLimit is how much of response body is read, so connection may be reused
+	webhookResponseLimit = 64 * 1024
+	// webhookSignaturePrefix names algorithm of signature in HeaderWebhookSignature
+	webhookSignaturePrefix = "sha256="
+)
+
+// Headers of webhook requests
+const (
+	// HeaderWebhookSignature is hex encoded HMAC-SHA256 of request body keyed with webhook secret,
+	// prefixed with "sha256="
+	HeaderWebhookSignature = "X-Kanban-Signature"
+	// HeaderWebhookEvent is type of delivered event
+	HeaderWebhookEvent = "X-Kanban-Event"
+	// HeaderWebhookEventID is identifier of delivered event, it's the same for all attempts of delivery
+	HeaderWebhookEventID = "X-Kanban-Event-Id"
+)
+
+type (
+	// WebhookService manages webhooks of boards and shows logs of their deliveries
+	WebhookService struct {
+		interfaces.WebhookRepository
+	}
+	CreateWebhookData struct {
+		URL string `json:"url" validate:"required,http_url,max=2048"`
+		// Events are types of board events sent to webhook, all events are sent if it's empty
+		Events []models.BoardEventType `json:"events" validate:"max=20,dive,oneof=task.created task.updated task.moved task.deleted member.added member.removed"`
+		// Secret is shared key of payload signatures, receiver uses it to check requests come from board
+		Secret string `json:"secret" validate:"required,min=16,max=255"`
+	}
+	UpdateWebhookData struct {
+		URL string `json:"url" validate:"required,http_url,max=2048"`
+		// Events are types of board events sent to webhook, all events are sent if it's empty
+		Events []models.BoardEventType `json:"events" validate:"max=20,dive,oneof=task.created task.updated task.moved task.deleted member.added member.removed"`
+		// Secret is new shared key of payload signatures, current one is kept if it's empty
+		Secret string `json:"secret" validate:"omitempty,min=16,max=255"`
+		// Enable resets failures of webhook and enables it again if it has been disabled
+		Enable bool `json:"enable"`
+	}
+
+	// WebhookDispatcher is BoardEventPublisher which posts board events to active webhooks of their boards.
+	// Publishing only queues event, deliveries run in background once Start is called, each of them is retried
+	// with exponential backoff and every attempt is logged. Webhook is disabled after DisableAfter
+	// consecutive deliveries fail
+	WebhookDispatcher struct {
+		interfaces.WebhookRepository
+		Clock
+		Client *http.Client
+		// MaxAttempts is count of attempts to deliver event before delivery is failed
+		MaxAttempts int
+		// RetryDelay is pause before the first retry, every next pause is twice as long
+		RetryDelay time.Duration
+		// MaxRetryDelay is the longest pause between retries
+		MaxRetryDelay time.Duration
+		// DisableAfter is count of consecutive failed deliveries which disables webhook
+		DisableAfter int
+		queue        chan models.BoardEvent
+		deliveries   sync.WaitGroup
+		cancel       context.CancelFunc
+		done         chan struct{}
+	}
+)
+
+var (
+	ErrorWebhookNotExists = errors.New("webhook does not exist")
+	webhookStatusErr      = errors.New("webhook responded with unsuccessful status")
+	webhookTargetErr      = errors.New("webhook target address is not public")
+)
+
+func NewWebhookService(repo interfaces.WebhookRepository) *WebhookService {
+	return &WebhookService{repo}
+}
+
+// CreateWebhook registers webhook of board on behalf of requester
+func (ws *WebhookService) CreateWebhook(
+	ctx context.Context,
+	boardId sqlddl.ID,
+	d *CreateWebhookData,
+) (*models.Webhook, error) {
+	userId, userIdErr := contextkeys.GetUserId(ctx)
+	if userIdErr != nil {
+		return nil, userIdErr
+	}
+	webhook := &models.Webhook{
+		Model:     models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		BoardID:   boardId,
+		CreatorID: &userId,
+		URL:       d.URL,
+		Events:    d.Events,
+		Secret:    d.Secret,
+	}
+	if creationErr := ws.WebhookRepository.Create(ctx, webhook); creationErr != nil {
+		return nil, creationErr
+	}
+	return ws.WebhookRepository.FindByID(ctx, webhook.ID)
+}
+
+func (ws *WebhookService) ListBoardWebhooks(ctx context.Context, boardId sqlddl.ID) ([]models.Webhook, error) {
+	return ws.WebhookRepository.FindAllByBoardID(ctx, boardId)
+}
+
+// FindBoardWebhookByID searches for webhook of board, webhooks of other boards are treated as not existing
+func (ws *WebhookService) FindBoardWebhookByID(
+	ctx context.Context,
+	boardId,
+	webhookId sqlddl.ID,
+) (*models.Webhook, error) {
+	webhook, searchErr := ws.WebhookRepository.FindByID(ctx, webhookId)
+	if searchErr != nil || webhook.BoardID != boardId {
+		return nil, ErrorWebhookNotExists
+	}
+	return webhook, nil
+}
+
+// UpdateWebhook changes target and events of webhook of board, disabled webhook is enabled again if requested
+func (ws *WebhookService) UpdateWebhook(
+	ctx context.Context,
+	boardId,
+	webhookId sqlddl.ID,
+	d *UpdateWebhookData,
+) (*models.Webhook, error) {
+	webhook, searchErr := ws.FindBoardWebhookByID(ctx, boardId, webhookId)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	webhook.URL = d.URL
+	webhook.Events = d.Events
+	if d.Secret != "" {
+		webhook.Secret = d.Secret
+	}
+	if updateErr := ws.WebhookRepository.Update(ctx, webhook, d.Enable); updateErr != nil {
+		return nil, updateErr
+	}
+	return ws.WebhookRepository.FindByID(ctx, webhookId)
+}
+
+func (ws *WebhookService) DeleteWebhook(ctx context.Context, boardId, webhookId sqlddl.ID) error {
+	if _, searchErr := ws.FindBoardWebhookByID(ctx, boardId, webhookId); searchErr != nil {
+		return searchErr
+	}
+	return ws.WebhookRepository.Delete(ctx, webhookId)
+}
+
+// ListWebhookDeliveries returns the latest delivery attempts of webhook of board, newest first.
+// Only limit of page is used, offset is ignored
+func (ws *WebhookService) ListWebhookDeliveries(
+	ctx context.Context,
+	boardId,
+	webhookId sqlddl.ID,
+	d *PageData,
+) ([]models.WebhookDelivery, error) {
+	if _, searchErr := ws.FindBoardWebhookByID(ctx, boardId, webhookId); searchErr != nil {
+		return nil, searchErr
+	}
+	return ws.WebhookRepository.FindDeliveries(ctx, webhookId, d.limit())
+}
+
+// SignWebhookPayload computes value of HeaderWebhookSignature for request body, receivers compute it the same way
+// with their copy of secret and compare results with hmac.Equal
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewWebhookDispatcher creates new instance of WebhookDispatcher with default retry policy,
+// its client refuses addresses which aren't public, see NewWebhookClient
+func NewWebhookDispatcher(repo interfaces.WebhookRepository, clock Clock) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		WebhookRepository: repo,
+		Clock:             clock,
+		Client:            NewWebhookClient(false),
+		MaxAttempts:       DefaultWebhookMaxAttempts,
+		RetryDelay:        DefaultWebhookRetryDelay,
+		MaxRetryDelay:     DefaultWebhookMaxRetryDelay,
+		DisableAfter:      DefaultWebhookDisableAfter,
+		queue:             make(chan models.BoardEvent, webhookQueueSize),
+	}
+}
+
+// NewWebhookClient creates client for webhook deliveries which doesn't follow redirects, so redirected deliveries
+// fail. Unless allowPrivateTargets is set, connections to loopback, private, link-local, multicast and unspecified
+// addresses are refused. Address is checked after name is resolved, so names pointing to internal hosts are refused
+// as well. Proxy from environment isn't used, otherwise only address of proxy would be checked
+func NewWebhookClient(allowPrivateTargets bool) *http.Client {
+	dialer := &net.Dialer{}
+	if !allowPrivateTargets {
+		dialer.Control = refuseNonPublicAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		Timeout:   DefaultWebhookTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// refuseNonPublicAddress is net.Dialer Control which fails connection to address which isn't public
+func refuseNonPublicAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, parseErr := netip.ParseAddrPort(address)
+	if parseErr != nil {
+		return fmt.Errorf("%w: %s", webhookTargetErr, address)
+	}
+	ip := addrPort.Addr().Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s", webhookTargetErr, ip)
+	}
+	return nil
+}
+
+// PublishBoardEvent queues event for delivery to webhooks, event is dropped if queue is full.
+// Event must be numbered already, so dispatcher goes after BoardEventHub in BoardEventPublishers
+func (wd *WebhookDispatcher) PublishBoardEvent(event *models.BoardEvent) {
+	select {
+	case wd.queue <- *event:
+	default:
+		log.Printf("Webhook queue is full, event %d of board %s is dropped", event.ID, event.BoardID)
+	}
+}
+
+// Start runs goroutine which dispatches queued events until Stop is called or ctx is done
+func (wd *WebhookDispatcher) Start(ctx context.Context) {
+	ctx, wd.cancel = context.WithCancel(ctx)
+	wd.done = make(chan struct{})
+	go func() {
+		defer close(wd.done)
+		for {
+			select {
+			case <-ctx.Done():
+				wd.deliveries.Wait()
+				return
+			case event := <-wd.queue:
+				wd.dispatch(ctx, &event)
+			}
+		}
+	}()
+}
+
+// Stop stops dispatching and waits for running deliveries, their pending retries are abandoned
+func (wd *WebhookDispatcher) Stop() {
+	if wd.cancel == nil {
+		return
+	}
+	wd.cancel()
+	<-wd.done
+}
+
+// dispatch starts delivery of event to every active webhook of its board which accepts it
+func (wd *WebhookDispatcher) dispatch(ctx context.Context, event *models.BoardEvent) {
+	webhooks, searchErr := wd.FindActiveByBoardID(ctx, event.BoardID)
+	if searchErr != nil {
+		log.Printf("Searching for webhooks of board %s failed: %v", event.BoardID, searchErr)
+		return
+	}
+	for _, webhook := range webhooks {
+		if !webhook.Accepts(event.Type) {
+			continue
+		}
+		wd.deliveries.Add(1)
+		go func() {
+			defer wd.deliveries.Done()
+			wd.deliver(ctx, &webhook, event)
+		}()
+	}
+}
+
+// deliver posts event to webhook until it's accepted or attempts are over, then counts result of delivery
+func (wd *WebhookDispatcher) deliver(ctx context.Context, webhook *models.Webhook, event *models.BoardEvent) {
+	body, marshalErr := json.Marshal(models.WebhookPayload{
+		Version:   models.WebhookPayloadVersion,
+		WebhookID: webhook.ID,
+		Event:     event,
+	})
+	if marshalErr != nil {
+		log.Printf("Encoding event %d for webhook %s failed: %v", event.ID, webhook.ID, marshalErr)
+		return
+	}
+	for attempt := 1; attempt <= wd.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(wd.retryDelay(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		if wd.attempt(ctx, webhook, event, attempt, body) {
+			if resetErr := wd.ResetFailures(ctx, webhook.ID); resetErr != nil {
+				log.Printf("Resetting failures of webhook %s failed: %v", webhook.ID, resetErr)
+			}
+			return
+		}
+		// attempt interrupted by shutdown isn't failure of webhook
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	disabled, recordErr := wd.RecordFailure(ctx, webhook.ID, wd.DisableAfter, wd.Now())
+	if recordErr != nil {
+		log.Printf("Recording failure of webhook %s failed: %v", webhook.ID, recordErr)
+		return
+	}
+	if disabled {
+		log.Printf(
+			"Webhook %s of board %s is disabled after %d failed deliveries",
+			webhook.ID,
+			webhook.BoardID,
+			wd.DisableAfter,
+		)
+	}
+}
+
+// attempt posts payload to webhook once and logs result, it reports whether webhook has accepted payload
+func (wd *WebhookDispatcher) attempt(
+	ctx context.Context,
+	webhook *models.Webhook,
+	event *models.BoardEvent,
+	attempt int,
+	body []byte,
+) bool {
+	startedAt := wd.Now()
+	statusCode, postErr := wd.post(ctx, webhook, event, body)
+	delivery := &models.WebhookDelivery{
+		Model:      models.Model{ID: sqlddl.ID(identifier.GenerateUUID())},
+		WebhookID:  webhook.ID,
+		EventID:    event.ID,
+		EventType:  event.Type,
+		Attempt:    attempt,
+		StatusCode: statusCode,
+		Succeeded:  postErr == nil,
+		Duration:   wd.Now().Sub(startedAt).Milliseconds(),
+	}
+	if postErr != nil {
+		delivery.Error = postErr.Error()
+	}
+	// attempt is logged even if delivery is interrupted by shutdown
+	if logErr := wd.CreateDelivery(context.WithoutCancel(ctx), delivery); logErr != nil {
+		log.Printf("Logging delivery of event %d to webhook %s failed: %v", event.ID, webhook.ID, logErr)
+	}
+	return postErr == nil
+}
+
+// post sends signed payload to webhook, any status besides 2xx is error
+func (wd *WebhookDispatcher) post(
+	ctx context.Context,
+	webhook *models.Webhook,
+	event *models.BoardEvent,
+	body []byte,
+) (int, error) {
+	request, requestErr := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if requestErr != nil {
+		return 0, requestErr
+	}
+	request.Header.Set(tcp.HeaderContentType, tcp.ContentTypeJSON)
+	request.Header.Set(HeaderWebhookSignature, SignWebhookPayload(webhook.Secret, body))
+	request.Header.Set(HeaderWebhookEvent, string(event.Type))
+	request.Header.Set(HeaderWebhookEventID, strconv.FormatUint(event.ID, 10))
+	response, responseErr := wd.Client.Do(request)
+	if responseErr != nil {
+		return 0, responseErr
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseLimit))
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("%w %s", webhookStatusErr, response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// retryDelay gives pause before retry with provided number, pauses double up to MaxRetryDelay
+func (wd *WebhookDispatcher) retryDelay(retry int) time.Duration {
+	delay := wd.RetryDelay
+	for i := 1; i < retry && delay < wd.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, wd.MaxRetryDelay)
+}
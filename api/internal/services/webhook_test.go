@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"just-kanban/internal/models"
+	"just-kanban/pkg/sqlddl"
+)
+
+// memoryWebhookRepository keeps webhooks and their deliveries in memory, it's safe for concurrent deliveries
+type memoryWebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   map[sqlddl.ID]*models.Webhook
+	deliveries []models.WebhookDelivery
+}
+
+func newMemoryWebhookRepository(webhooks ...models.Webhook) *memoryWebhookRepository {
+	repo := &memoryWebhookRepository{webhooks: make(map[sqlddl.ID]*models.Webhook)}
+	for _, webhook := range webhooks {
+		repo.webhooks[webhook.ID] = &webhook
+	}
+	return repo
+}
+
+func (mwr *memoryWebhookRepository) Create(_ context.Context, webhook *models.Webhook) error {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	created := *webhook
+	mwr.webhooks[webhook.ID] = &created
+	return nil
+}
+
+func (mwr *memoryWebhookRepository) Update(_ context.Context, webhook *models.Webhook, enable bool) error {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	stored := mwr.webhooks[webhook.ID]
+	stored.URL, stored.Events, stored.Secret = webhook.URL, webhook.Events, webhook.Secret
+	if enable {
+		stored.FailureCount, stored.DisabledAt = 0, nil
+	}
+	return nil
+}
+
+func (mwr *memoryWebhookRepository) Delete(_ context.Context, webhookId sqlddl.ID) error {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	delete(mwr.webhooks, webhookId)
+	return nil
+}
+
+func (mwr *memoryWebhookRepository) FindByID(_ context.Context, webhookId sqlddl.ID) (*models.Webhook, error) {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	webhook, ok := mwr.webhooks[webhookId]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	found := *webhook
+	return &found, nil
+}
+
+func (mwr *memoryWebhookRepository) FindAllByBoardID(_ context.Context, boardId sqlddl.ID) ([]models.Webhook, error) {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	var webhooks []models.Webhook
+	for _, webhook := range mwr.webhooks {
+		if webhook.BoardID == boardId {
+			webhooks = append(webhooks, *webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (mwr *memoryWebhookRepository) FindActiveByBoardID(
+	ctx context.Context,
+	boardId sqlddl.ID,
+) ([]models.Webhook, error) {
+	webhooks, _ := mwr.FindAllByBoardID(ctx, boardId)
+	var active []models.Webhook
+	for _, webhook := range webhooks {
+		if webhook.DisabledAt == nil {
+			active = append(active, webhook)
+		}
+	}
+	return active, nil
+}
+
+func (mwr *memoryWebhookRepository) RecordFailure(
+	_ context.Context,
+	webhookId sqlddl.ID,
+	limit int,
+	disabledAt time.Time,
+) (bool, error) {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	webhook := mwr.webhooks[webhookId]
+	webhook.FailureCount++
+	if webhook.DisabledAt == nil && webhook.FailureCount >= limit {
+		webhook.DisabledAt = &disabledAt
+	}
+	return webhook.DisabledAt != nil, nil
+}
+
+func (mwr *memoryWebhookRepository) ResetFailures(_ context.Context, webhookId sqlddl.ID) error {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	mwr.webhooks[webhookId].FailureCount = 0
+	return nil
+}
+
+func (mwr *memoryWebhookRepository) CreateDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	mwr.deliveries = append(mwr.deliveries, *delivery)
+	return nil
+}
+
+func (mwr *memoryWebhookRepository) FindDeliveries(
+	_ context.Context,
+	webhookId sqlddl.ID,
+	limit int,
+) ([]models.WebhookDelivery, error) {
+	mwr.mu.Lock()
+	defer mwr.mu.Unlock()
+	var deliveries []models.WebhookDelivery
+	for i := len(mwr.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if mwr.deliveries[i].WebhookID == webhookId {
+			deliveries = append(deliveries, mwr.deliveries[i])
+		}
+	}
+	return deliveries, nil
+}
+
+// webhookReceiver is test server of webhook which answers with queued statuses, 200 once they're over
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests chan *http.Request
+	bodies   chan []byte
+}
+
+func newWebhookReceiver(statuses ...int) *webhookReceiver {
+	receiver := &webhookReceiver{
+		statuses: statuses,
+		requests: make(chan *http.Request, 16),
+		bodies:   make(chan []byte, 16),
+	}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.requests <- r
+		receiver.bodies <- body
+		receiver.mu.Lock()
+		status := http.StatusOK
+		if len(receiver.statuses) > 0 {
+			status, receiver.statuses = receiver.statuses[0], receiver.statuses[1:]
+		}
+		receiver.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	return receiver
+}
+
+func newTestWebhookDispatcher(repo *memoryWebhookRepository) *WebhookDispatcher {
+	dispatcher := NewWebhookDispatcher(repo, &movingClock{now: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)})
+	// receiver listens on loopback
+	dispatcher.Client = NewWebhookClient(true)
+	dispatcher.RetryDelay = time.Millisecond
+	dispatcher.MaxRetryDelay = 4 * time.Millisecond
+	return dispatcher
+}
+
+// dispatchAndWait delivers event to webhooks of its board and waits until deliveries end
+func dispatchAndWait(dispatcher *WebhookDispatcher, event *models.BoardEvent) {
+	dispatcher.dispatch(context.Background(), event)
+	dispatcher.deliveries.Wait()
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	const secret = "0123456789abcdef"
+	event := &models.BoardEvent{
+		ID:      42,
+		Type:    models.BoardEventTaskCreated,
+		BoardID: "board",
+		ActorID: "alice",
+		Data:    &models.Task{Name: "Release"},
+	}
+
+	t.Run("Signed payload is posted to webhooks accepting event", func(t *testing.T) {
+		receiver := newWebhookReceiver()
+		defer receiver.Close()
+		repo := newMemoryWebhookRepository(
+			models.Webhook{Model: models.Model{ID: "all"}, BoardID: "board", URL: receiver.URL, Secret: secret},
+			models.Webhook{
+				Model:   models.Model{ID: "members"},
+				BoardID: "board",
+				URL:     receiver.URL,
+				Events:  []models.BoardEventType{models.BoardEventMemberAdded},
+				Secret:  secret,
+			},
+		)
+		dispatchAndWait(newTestWebhookDispatcher(repo), event)
+		request, body := <-receiver.requests, <-receiver.bodies
+		signature := []byte(request.Header.Get(HeaderWebhookSignature))
+		if !hmac.Equal(signature, []byte(SignWebhookPayload(secret, body))) {
+			t.Fatalf("got signature %s", signature)
+		}
+		if request.Header.Get(HeaderWebhookEvent) != string(event.Type) ||
+			request.Header.Get(HeaderWebhookEventID) != strconv.FormatUint(event.ID, 10) {
+			t.Fatalf("got headers %v", request.Header)
+		}
+		var payload struct {
+			Version   int               `json:"version"`
+			WebhookID sqlddl.ID         `json:"webhook_id"`
+			Event     models.BoardEvent `json:"event"`
+		}
+		if decodeErr := json.Unmarshal(body, &payload); decodeErr != nil {
+			t.Fatal(decodeErr)
+		}
+		if payload.Version != models.WebhookPayloadVersion || payload.WebhookID != "all" ||
+			payload.Event.ID != event.ID {
+			t.Fatalf("got payload %+v", payload)
+		}
+		select {
+		case request := <-receiver.requests:
+			t.Fatalf("got request %v of webhook which doesn't accept event", request.Header)
+		default:
+		}
+		if len(repo.deliveries) != 1 || !repo.deliveries[0].Succeeded ||
+			repo.deliveries[0].StatusCode != http.StatusOK {
+			t.Fatalf("got deliveries %+v", repo.deliveries)
+		}
+	})
+
+	t.Run("Failed attempts are retried and logged", func(t *testing.T) {
+		receiver := newWebhookReceiver(http.StatusInternalServerError, http.StatusBadGateway, http.StatusNoContent)
+		defer receiver.Close()
+		repo := newMemoryWebhookRepository(models.Webhook{
+			Model:        models.Model{ID: "webhook"},
+			BoardID:      "board",
+			URL:          receiver.URL,
+			Secret:       secret,
+			FailureCount: 3,
+		})
+		dispatchAndWait(newTestWebhookDispatcher(repo), event)
+		deliveries, _ := repo.FindDeliveries(context.Background(), "webhook", 10)
+		expectedStatuses := []int{http.StatusNoContent, http.StatusBadGateway, http.StatusInternalServerError}
+		if len(deliveries) != len(expectedStatuses) {
+			t.Fatalf("got deliveries %+v", deliveries)
+		}
+		for i, delivery := range deliveries {
+			if delivery.StatusCode != expectedStatuses[i] || delivery.Attempt != len(deliveries)-i ||
+				delivery.Succeeded != (i == 0) || (delivery.Error == "") != delivery.Succeeded {
+				t.Fatalf("got delivery %+v", delivery)
+			}
+		}
+		if repo.webhooks["webhook"].FailureCount != 0 {
+			t.Fatalf("got %d failures after successful delivery", repo.webhooks["webhook"].FailureCount)
+		}
+	})
+
+	t.Run("Webhook is disabled after failed deliveries", func(t *testing.T) {
+		receiver := newWebhookReceiver(
+			http.StatusInternalServerError,
+			http.StatusInternalServerError,
+			http.StatusInternalServerError,
+			http.StatusInternalServerError,
+		)
+		defer receiver.Close()
+		repo := newMemoryWebhookRepository(
+			models.Webhook{Model: models.Model{ID: "webhook"}, BoardID: "board", URL: receiver.URL, Secret: secret},
+		)
+		dispatcher := newTestWebhookDispatcher(repo)
+		dispatcher.MaxAttempts = 2
+		dispatcher.DisableAfter = 2
+		dispatchAndWait(dispatcher, event)
+		if webhook := repo.webhooks["webhook"]; webhook.FailureCount != 1 || webhook.DisabledAt != nil {
+			t.Fatalf("got webhook %+v after first failed delivery", webhook)
+		}
+		dispatchAndWait(dispatcher, event)
+		if webhook := repo.webhooks["webhook"]; webhook.DisabledAt == nil {
+			t.Fatalf("got webhook %+v after last failed delivery", webhook)
+		}
+		dispatchAndWait(dispatcher, event)
+		if len(receiver.requests) != 4 || len(repo.deliveries) != 4 {
+			t.Fatalf("got %d requests and %d deliveries", len(receiver.requests), len(repo.deliveries))
+		}
+	})
+
+	t.Run("Retry delay doubles up to limit", func(t *testing.T) {
+		dispatcher := NewWebhookDispatcher(nil, SystemClock{})
+		dispatcher.RetryDelay = time.Second
+		dispatcher.MaxRetryDelay = 5 * time.Second
+		for retry, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+			if delay := dispatcher.retryDelay(retry + 1); delay != expected {
+				t.Fatalf("got delay %s of retry %d, expected %s", delay, retry+1, expected)
+			}
+		}
+	})
+
+	t.Run("Addresses which aren't public are refused", func(t *testing.T) {
+		receiver := newWebhookReceiver()
+		defer receiver.Close()
+		repo := newMemoryWebhookRepository(
+			models.Webhook{Model: models.Model{ID: "webhook"}, BoardID: "board", URL: receiver.URL, Secret: secret},
+		)
+		dispatcher := newTestWebhookDispatcher(repo)
+		dispatcher.Client = NewWebhookClient(false)
+		dispatcher.MaxAttempts = 1
+		dispatchAndWait(dispatcher, event)
+		if len(receiver.requests) != 0 || len(repo.deliveries) != 1 ||
+			!strings.Contains(repo.deliveries[0].Error, webhookTargetErr.Error()) {
+			t.Fatalf("got %d requests and deliveries %+v", len(receiver.requests), repo.deliveries)
+		}
+		for _, address := range []string{"10.0.0.1:80", "169.254.169.254:80", "[::1]:443", "0.0.0.0:80"} {
+			if controlErr := refuseNonPublicAddress("tcp", address, nil); !errors.Is(controlErr, webhookTargetErr) {
+				t.Fatalf("got %v for %s", controlErr, address)
+			}
+		}
+		if controlErr := refuseNonPublicAddress("tcp", "93.184.216.34:443", nil); controlErr != nil {
+			t.Fatalf("got %v for public address", controlErr)
+		}
+	})
+
+	t.Run("Published events are delivered in background", func(t *testing.T) {
+		receiver := newWebhookReceiver()
+		defer receiver.Close()
+		repo := newMemoryWebhookRepository(
+			models.Webhook{Model: models.Model{ID: "webhook"}, BoardID: "board", URL: receiver.URL, Secret: secret},
+		)
+		dispatcher := newTestWebhookDispatcher(repo)
+		hub := NewBoardEventHub(SystemClock{}, DefaultBoardEventBufferSize, DefaultBoardEventIdleTimeout)
+		publisher := BoardEventPublishers{hub, dispatcher}
+		published := &models.BoardEvent{Type: models.BoardEventMemberRemoved, BoardID: "board"}
+		publisher.PublishBoardEvent(published)
+		dispatcher.Start(context.Background())
+		defer dispatcher.Stop()
+		select {
+		case request := <-receiver.requests:
+			eventId := request.Header.Get(HeaderWebhookEventID)
+			if eventId != strconv.FormatUint(published.ID, 10) || published.ID == 0 {
+				t.Fatalf("got event %s, expected numbered event %d", eventId, published.ID)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("event isn't delivered")
+		}
+	})
+
+	t.Run("Full queue doesn't block publishing", func(t *testing.T) {
+		dispatcher := newTestWebhookDispatcher(newMemoryWebhookRepository())
+		for i := 0; i <= webhookQueueSize; i++ {
+			dispatcher.PublishBoardEvent(event)
+		}
+		if len(dispatcher.queue) != webhookQueueSize {
+			t.Fatalf("got %d queued events", len(dispatcher.queue))
+		}
+	})
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: BoardColumnRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/board_column_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces BoardColumnRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBoardColumnRepository is a mock of BoardColumnRepository interface.
+type MockBoardColumnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardColumnRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBoardColumnRepositoryMockRecorder is the mock recorder for MockBoardColumnRepository.
+type MockBoardColumnRepositoryMockRecorder struct {
+	mock *MockBoardColumnRepository
+}
+
+// NewMockBoardColumnRepository creates a new mock instance.
+func NewMockBoardColumnRepository(ctrl *gomock.Controller) *MockBoardColumnRepository {
+	mock := &MockBoardColumnRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardColumnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardColumnRepository) EXPECT() *MockBoardColumnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardColumnRepository) Create(ctx context.Context, column *models.BoardColumn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, column)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardColumnRepositoryMockRecorder) Create(ctx, column any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardColumnRepository)(nil).Create), ctx, column)
+}
+
+// Delete mocks base method.
+func (m *MockBoardColumnRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardColumnRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardColumnRepository)(nil).Delete), ctx, id)
+}
+
+// FindAllByBoardID mocks base method.
+func (m *MockBoardColumnRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBoardID", ctx, boardId)
+	ret0, _ := ret[0].([]models.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBoardID indicates an expected call of FindAllByBoardID.
+func (mr *MockBoardColumnRepositoryMockRecorder) FindAllByBoardID(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBoardID", reflect.TypeOf((*MockBoardColumnRepository)(nil).FindAllByBoardID), ctx, boardId)
+}
+
+// FindByID mocks base method.
+func (m *MockBoardColumnRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.BoardColumn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*models.BoardColumn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBoardColumnRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBoardColumnRepository)(nil).FindByID), ctx, id)
+}
+
+// Lock mocks base method.
+func (m *MockBoardColumnRepository) Lock(ctx context.Context, boardId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, boardId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockBoardColumnRepositoryMockRecorder) Lock(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockBoardColumnRepository)(nil).Lock), ctx, boardId)
+}
+
+// Rename mocks base method.
+func (m *MockBoardColumnRepository) Rename(ctx context.Context, id sqlddl.ID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", ctx, id, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockBoardColumnRepositoryMockRecorder) Rename(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockBoardColumnRepository)(nil).Rename), ctx, id, name)
+}
+
+// Reorder mocks base method.
+func (m *MockBoardColumnRepository) Reorder(ctx context.Context, boardId sqlddl.ID, columnIds []sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, boardId, columnIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockBoardColumnRepositoryMockRecorder) Reorder(ctx, boardId, columnIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockBoardColumnRepository)(nil).Reorder), ctx, boardId, columnIds)
+}
+
+// SetWIPLimit mocks base method.
+func (m *MockBoardColumnRepository) SetWIPLimit(ctx context.Context, id sqlddl.ID, limit int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWIPLimit", ctx, id, limit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWIPLimit indicates an expected call of SetWIPLimit.
+func (mr *MockBoardColumnRepositoryMockRecorder) SetWIPLimit(ctx, id, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWIPLimit", reflect.TypeOf((*MockBoardColumnRepository)(nil).SetWIPLimit), ctx, id, limit)
+}
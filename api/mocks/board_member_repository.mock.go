@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: BoardMemberRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/board_member_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces BoardMemberRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	access "just-kanban/internal/access"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBoardMemberRepository is a mock of BoardMemberRepository interface.
+type MockBoardMemberRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardMemberRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBoardMemberRepositoryMockRecorder is the mock recorder for MockBoardMemberRepository.
+type MockBoardMemberRepositoryMockRecorder struct {
+	mock *MockBoardMemberRepository
+}
+
+// NewMockBoardMemberRepository creates a new mock instance.
+func NewMockBoardMemberRepository(ctrl *gomock.Controller) *MockBoardMemberRepository {
+	mock := &MockBoardMemberRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardMemberRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardMemberRepository) EXPECT() *MockBoardMemberRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ChangeMemberRole mocks base method.
+func (m *MockBoardMemberRepository) ChangeMemberRole(ctx context.Context, memberId sqlddl.ID, role access.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMemberRole", ctx, memberId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeMemberRole indicates an expected call of ChangeMemberRole.
+func (mr *MockBoardMemberRepositoryMockRecorder) ChangeMemberRole(ctx, memberId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMemberRole", reflect.TypeOf((*MockBoardMemberRepository)(nil).ChangeMemberRole), ctx, memberId, role)
+}
+
+// CountByRole mocks base method.
+func (m *MockBoardMemberRepository) CountByRole(ctx context.Context, boardId sqlddl.ID, role access.Role) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByRole", ctx, boardId, role)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByRole indicates an expected call of CountByRole.
+func (mr *MockBoardMemberRepositoryMockRecorder) CountByRole(ctx, boardId, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByRole", reflect.TypeOf((*MockBoardMemberRepository)(nil).CountByRole), ctx, boardId, role)
+}
+
+// Create mocks base method.
+func (m *MockBoardMemberRepository) Create(ctx context.Context, member *models.BoardMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardMemberRepositoryMockRecorder) Create(ctx, member any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardMemberRepository)(nil).Create), ctx, member)
+}
+
+// Delete mocks base method.
+func (m *MockBoardMemberRepository) Delete(ctx context.Context, memberId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, memberId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardMemberRepositoryMockRecorder) Delete(ctx, memberId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardMemberRepository)(nil).Delete), ctx, memberId)
+}
+
+// FindBoardMembers mocks base method.
+func (m *MockBoardMemberRepository) FindBoardMembers(ctx context.Context, boardId sqlddl.ID) ([]models.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBoardMembers", ctx, boardId)
+	ret0, _ := ret[0].([]models.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBoardMembers indicates an expected call of FindBoardMembers.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindBoardMembers(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBoardMembers", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindBoardMembers), ctx, boardId)
+}
+
+// FindBoardUser mocks base method.
+func (m *MockBoardMemberRepository) FindBoardUser(ctx context.Context, boardID, userID sqlddl.ID) (*models.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBoardUser", ctx, boardID, userID)
+	ret0, _ := ret[0].(*models.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBoardUser indicates an expected call of FindBoardUser.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindBoardUser(ctx, boardID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBoardUser", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindBoardUser), ctx, boardID, userID)
+}
+
+// FindByID mocks base method.
+func (m *MockBoardMemberRepository) FindByID(ctx context.Context, memberId sqlddl.ID) (*models.BoardMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, memberId)
+	ret0, _ := ret[0].(*models.BoardMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBoardMemberRepositoryMockRecorder) FindByID(ctx, memberId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBoardMemberRepository)(nil).FindByID), ctx, memberId)
+}
+
+// Lock mocks base method.
+func (m *MockBoardMemberRepository) Lock(ctx context.Context, boardId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, boardId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockBoardMemberRepositoryMockRecorder) Lock(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockBoardMemberRepository)(nil).Lock), ctx, boardId)
+}
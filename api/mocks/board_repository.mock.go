@@ -0,0 +1,219 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: BoardRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/board_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces BoardRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBoardRepository is a mock of BoardRepository interface.
+type MockBoardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBoardRepositoryMockRecorder is the mock recorder for MockBoardRepository.
+type MockBoardRepositoryMockRecorder struct {
+	mock *MockBoardRepository
+}
+
+// NewMockBoardRepository creates a new mock instance.
+func NewMockBoardRepository(ctrl *gomock.Controller) *MockBoardRepository {
+	mock := &MockBoardRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardRepository) EXPECT() *MockBoardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardRepository) Create(ctx context.Context, board *models.Board) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, board)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardRepositoryMockRecorder) Create(ctx, board any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardRepository)(nil).Create), ctx, board)
+}
+
+// Delete mocks base method.
+func (m *MockBoardRepository) Delete(ctx context.Context, id sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockBoardRepository) FindAll(ctx context.Context) ([]models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBoardRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBoardRepository)(nil).FindAll), ctx)
+}
+
+// FindByID mocks base method.
+func (m *MockBoardRepository) FindByID(ctx context.Context, id sqlddl.ID) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBoardRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBoardRepository)(nil).FindByID), ctx, id)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockBoardRepository) FindDeletedByID(ctx context.Context, id sqlddl.ID) (*models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", ctx, id)
+	ret0, _ := ret[0].(*models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockBoardRepositoryMockRecorder) FindDeletedByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockBoardRepository)(nil).FindDeletedByID), ctx, id)
+}
+
+// FindDeletedByUserID mocks base method.
+func (m *MockBoardRepository) FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByUserID", ctx, userId)
+	ret0, _ := ret[0].([]models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByUserID indicates an expected call of FindDeletedByUserID.
+func (mr *MockBoardRepositoryMockRecorder) FindDeletedByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByUserID", reflect.TypeOf((*MockBoardRepository)(nil).FindDeletedByUserID), ctx, userId)
+}
+
+// FindPage mocks base method.
+func (m *MockBoardRepository) FindPage(ctx context.Context, archived bool, after *models.PageKey, limit int) ([]models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", ctx, archived, after, limit)
+	ret0, _ := ret[0].([]models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockBoardRepositoryMockRecorder) FindPage(ctx, archived, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockBoardRepository)(nil).FindPage), ctx, archived, after, limit)
+}
+
+// FindTemplatesByUserID mocks base method.
+func (m *MockBoardRepository) FindTemplatesByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTemplatesByUserID", ctx, userId)
+	ret0, _ := ret[0].([]models.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTemplatesByUserID indicates an expected call of FindTemplatesByUserID.
+func (mr *MockBoardRepositoryMockRecorder) FindTemplatesByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTemplatesByUserID", reflect.TypeOf((*MockBoardRepository)(nil).FindTemplatesByUserID), ctx, userId)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockBoardRepository) PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", ctx, deadline)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockBoardRepositoryMockRecorder) PurgeDeletedBefore(ctx, deadline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockBoardRepository)(nil).PurgeDeletedBefore), ctx, deadline)
+}
+
+// SetArchivedAt mocks base method.
+func (m *MockBoardRepository) SetArchivedAt(ctx context.Context, id sqlddl.ID, archivedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchivedAt", ctx, id, archivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArchivedAt indicates an expected call of SetArchivedAt.
+func (mr *MockBoardRepositoryMockRecorder) SetArchivedAt(ctx, id, archivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchivedAt", reflect.TypeOf((*MockBoardRepository)(nil).SetArchivedAt), ctx, id, archivedAt)
+}
+
+// SetDeletedAt mocks base method.
+func (m *MockBoardRepository) SetDeletedAt(ctx context.Context, id sqlddl.ID, deletedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeletedAt", ctx, id, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeletedAt indicates an expected call of SetDeletedAt.
+func (mr *MockBoardRepositoryMockRecorder) SetDeletedAt(ctx, id, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeletedAt", reflect.TypeOf((*MockBoardRepository)(nil).SetDeletedAt), ctx, id, deletedAt)
+}
+
+// Update mocks base method.
+func (m *MockBoardRepository) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateBoard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBoardRepositoryMockRecorder) Update(ctx, id, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBoardRepository)(nil).Update), ctx, id, d)
+}
@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: BoardRoleRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/board_role_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces BoardRoleRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	access "just-kanban/internal/access"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBoardRoleRepository is a mock of BoardRoleRepository interface.
+type MockBoardRoleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardRoleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBoardRoleRepositoryMockRecorder is the mock recorder for MockBoardRoleRepository.
+type MockBoardRoleRepositoryMockRecorder struct {
+	mock *MockBoardRoleRepository
+}
+
+// NewMockBoardRoleRepository creates a new mock instance.
+func NewMockBoardRoleRepository(ctrl *gomock.Controller) *MockBoardRoleRepository {
+	mock := &MockBoardRoleRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardRoleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardRoleRepository) EXPECT() *MockBoardRoleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBoardRoleRepository) Create(ctx context.Context, role *models.BoardRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBoardRoleRepositoryMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBoardRoleRepository)(nil).Create), ctx, role)
+}
+
+// Delete mocks base method.
+func (m *MockBoardRoleRepository) Delete(ctx context.Context, roleId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, roleId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBoardRoleRepositoryMockRecorder) Delete(ctx, roleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBoardRoleRepository)(nil).Delete), ctx, roleId)
+}
+
+// FindAllByBoardID mocks base method.
+func (m *MockBoardRoleRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.BoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBoardID", ctx, boardId)
+	ret0, _ := ret[0].([]models.BoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBoardID indicates an expected call of FindAllByBoardID.
+func (mr *MockBoardRoleRepositoryMockRecorder) FindAllByBoardID(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBoardID", reflect.TypeOf((*MockBoardRoleRepository)(nil).FindAllByBoardID), ctx, boardId)
+}
+
+// FindByID mocks base method.
+func (m *MockBoardRoleRepository) FindByID(ctx context.Context, roleId sqlddl.ID) (*models.BoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, roleId)
+	ret0, _ := ret[0].(*models.BoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBoardRoleRepositoryMockRecorder) FindByID(ctx, roleId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBoardRoleRepository)(nil).FindByID), ctx, roleId)
+}
+
+// FindByName mocks base method.
+func (m *MockBoardRoleRepository) FindByName(ctx context.Context, boardId sqlddl.ID, name access.Role) (*models.BoardRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, boardId, name)
+	ret0, _ := ret[0].(*models.BoardRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockBoardRoleRepositoryMockRecorder) FindByName(ctx, boardId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockBoardRoleRepository)(nil).FindByName), ctx, boardId, name)
+}
+
+// UpdatePermissions mocks base method.
+func (m *MockBoardRoleRepository) UpdatePermissions(ctx context.Context, roleId sqlddl.ID, permissions []access.Permission) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePermissions", ctx, roleId, permissions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePermissions indicates an expected call of UpdatePermissions.
+func (mr *MockBoardRoleRepositoryMockRecorder) UpdatePermissions(ctx, roleId, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePermissions", reflect.TypeOf((*MockBoardRoleRepository)(nil).UpdatePermissions), ctx, roleId, permissions)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: ChecklistItemRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/checklist_item_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces ChecklistItemRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChecklistItemRepository is a mock of ChecklistItemRepository interface.
+type MockChecklistItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChecklistItemRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChecklistItemRepositoryMockRecorder is the mock recorder for MockChecklistItemRepository.
+type MockChecklistItemRepositoryMockRecorder struct {
+	mock *MockChecklistItemRepository
+}
+
+// NewMockChecklistItemRepository creates a new mock instance.
+func NewMockChecklistItemRepository(ctrl *gomock.Controller) *MockChecklistItemRepository {
+	mock := &MockChecklistItemRepository{ctrl: ctrl}
+	mock.recorder = &MockChecklistItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChecklistItemRepository) EXPECT() *MockChecklistItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountProgressByTaskIDs mocks base method.
+func (m *MockChecklistItemRepository) CountProgressByTaskIDs(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID]models.ChecklistProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProgressByTaskIDs", ctx, taskIds)
+	ret0, _ := ret[0].(map[sqlddl.ID]models.ChecklistProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProgressByTaskIDs indicates an expected call of CountProgressByTaskIDs.
+func (mr *MockChecklistItemRepositoryMockRecorder) CountProgressByTaskIDs(ctx, taskIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProgressByTaskIDs", reflect.TypeOf((*MockChecklistItemRepository)(nil).CountProgressByTaskIDs), ctx, taskIds)
+}
+
+// Create mocks base method.
+func (m *MockChecklistItemRepository) Create(ctx context.Context, item *models.ChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockChecklistItemRepositoryMockRecorder) Create(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockChecklistItemRepository)(nil).Create), ctx, item)
+}
+
+// Delete mocks base method.
+func (m *MockChecklistItemRepository) Delete(ctx context.Context, itemId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockChecklistItemRepositoryMockRecorder) Delete(ctx, itemId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockChecklistItemRepository)(nil).Delete), ctx, itemId)
+}
+
+// FindAllByTaskID mocks base method.
+func (m *MockChecklistItemRepository) FindAllByTaskID(ctx context.Context, taskId sqlddl.ID) ([]models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByTaskID", ctx, taskId)
+	ret0, _ := ret[0].([]models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByTaskID indicates an expected call of FindAllByTaskID.
+func (mr *MockChecklistItemRepositoryMockRecorder) FindAllByTaskID(ctx, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByTaskID", reflect.TypeOf((*MockChecklistItemRepository)(nil).FindAllByTaskID), ctx, taskId)
+}
+
+// FindByID mocks base method.
+func (m *MockChecklistItemRepository) FindByID(ctx context.Context, itemId sqlddl.ID) (*models.ChecklistItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, itemId)
+	ret0, _ := ret[0].(*models.ChecklistItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockChecklistItemRepositoryMockRecorder) FindByID(ctx, itemId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockChecklistItemRepository)(nil).FindByID), ctx, itemId)
+}
+
+// Reorder mocks base method.
+func (m *MockChecklistItemRepository) Reorder(ctx context.Context, taskId sqlddl.ID, itemIds []sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, taskId, itemIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockChecklistItemRepositoryMockRecorder) Reorder(ctx, taskId, itemIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockChecklistItemRepository)(nil).Reorder), ctx, taskId, itemIds)
+}
+
+// Update mocks base method.
+func (m *MockChecklistItemRepository) Update(ctx context.Context, itemId sqlddl.ID, d *models.UpdateChecklistItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, itemId, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockChecklistItemRepositoryMockRecorder) Update(ctx, itemId, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockChecklistItemRepository)(nil).Update), ctx, itemId, d)
+}
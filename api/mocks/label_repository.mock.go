@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: LabelRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/label_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces LabelRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLabelRepository is a mock of LabelRepository interface.
+type MockLabelRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLabelRepositoryMockRecorder is the mock recorder for MockLabelRepository.
+type MockLabelRepositoryMockRecorder struct {
+	mock *MockLabelRepository
+}
+
+// NewMockLabelRepository creates a new mock instance.
+func NewMockLabelRepository(ctrl *gomock.Controller) *MockLabelRepository {
+	mock := &MockLabelRepository{ctrl: ctrl}
+	mock.recorder = &MockLabelRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabelRepository) EXPECT() *MockLabelRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLabelRepository) Create(ctx context.Context, label *models.Label) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, label)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelRepositoryMockRecorder) Create(ctx, label any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabelRepository)(nil).Create), ctx, label)
+}
+
+// Delete mocks base method.
+func (m *MockLabelRepository) Delete(ctx context.Context, labelId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLabelRepositoryMockRecorder) Delete(ctx, labelId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLabelRepository)(nil).Delete), ctx, labelId)
+}
+
+// FindAllByBoardID mocks base method.
+func (m *MockLabelRepository) FindAllByBoardID(ctx context.Context, boardId sqlddl.ID) ([]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBoardID", ctx, boardId)
+	ret0, _ := ret[0].([]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBoardID indicates an expected call of FindAllByBoardID.
+func (mr *MockLabelRepositoryMockRecorder) FindAllByBoardID(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBoardID", reflect.TypeOf((*MockLabelRepository)(nil).FindAllByBoardID), ctx, boardId)
+}
+
+// FindAllByTaskIDs mocks base method.
+func (m *MockLabelRepository) FindAllByTaskIDs(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByTaskIDs", ctx, taskIds)
+	ret0, _ := ret[0].(map[sqlddl.ID][]models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByTaskIDs indicates an expected call of FindAllByTaskIDs.
+func (mr *MockLabelRepositoryMockRecorder) FindAllByTaskIDs(ctx, taskIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByTaskIDs", reflect.TypeOf((*MockLabelRepository)(nil).FindAllByTaskIDs), ctx, taskIds)
+}
+
+// FindByID mocks base method.
+func (m *MockLabelRepository) FindByID(ctx context.Context, labelId sqlddl.ID) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, labelId)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockLabelRepositoryMockRecorder) FindByID(ctx, labelId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockLabelRepository)(nil).FindByID), ctx, labelId)
+}
+
+// FindByName mocks base method.
+func (m *MockLabelRepository) FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, boardId, name)
+	ret0, _ := ret[0].(*models.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockLabelRepositoryMockRecorder) FindByName(ctx, boardId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockLabelRepository)(nil).FindByName), ctx, boardId, name)
+}
+
+// ReplaceTaskLabels mocks base method.
+func (m *MockLabelRepository) ReplaceTaskLabels(ctx context.Context, taskId sqlddl.ID, labelIds []sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTaskLabels", ctx, taskId, labelIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTaskLabels indicates an expected call of ReplaceTaskLabels.
+func (mr *MockLabelRepositoryMockRecorder) ReplaceTaskLabels(ctx, taskId, labelIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTaskLabels", reflect.TypeOf((*MockLabelRepository)(nil).ReplaceTaskLabels), ctx, taskId, labelIds)
+}
+
+// Update mocks base method.
+func (m *MockLabelRepository) Update(ctx context.Context, labelId sqlddl.ID, d *models.UpdateLabel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, labelId, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelRepositoryMockRecorder) Update(ctx, labelId, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabelRepository)(nil).Update), ctx, labelId, d)
+}
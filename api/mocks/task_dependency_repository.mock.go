@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: TaskDependencyRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/task_dependency_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces TaskDependencyRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaskDependencyRepository is a mock of TaskDependencyRepository interface.
+type MockTaskDependencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskDependencyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskDependencyRepositoryMockRecorder is the mock recorder for MockTaskDependencyRepository.
+type MockTaskDependencyRepositoryMockRecorder struct {
+	mock *MockTaskDependencyRepository
+}
+
+// NewMockTaskDependencyRepository creates a new mock instance.
+func NewMockTaskDependencyRepository(ctrl *gomock.Controller) *MockTaskDependencyRepository {
+	mock := &MockTaskDependencyRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskDependencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskDependencyRepository) EXPECT() *MockTaskDependencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaskDependencyRepository) Create(ctx context.Context, dependency *models.TaskDependency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dependency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskDependencyRepositoryMockRecorder) Create(ctx, dependency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskDependencyRepository)(nil).Create), ctx, dependency)
+}
+
+// Delete mocks base method.
+func (m *MockTaskDependencyRepository) Delete(ctx context.Context, blockerId, blockedId sqlddl.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, blockerId, blockedId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskDependencyRepositoryMockRecorder) Delete(ctx, blockerId, blockedId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskDependencyRepository)(nil).Delete), ctx, blockerId, blockedId)
+}
+
+// FindBlocked mocks base method.
+func (m *MockTaskDependencyRepository) FindBlocked(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlocked", ctx, taskIds)
+	ret0, _ := ret[0].(map[sqlddl.ID][]models.TaskReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlocked indicates an expected call of FindBlocked.
+func (mr *MockTaskDependencyRepositoryMockRecorder) FindBlocked(ctx, taskIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlocked", reflect.TypeOf((*MockTaskDependencyRepository)(nil).FindBlocked), ctx, taskIds)
+}
+
+// FindBlockers mocks base method.
+func (m *MockTaskDependencyRepository) FindBlockers(ctx context.Context, taskIds []sqlddl.ID) (map[sqlddl.ID][]models.TaskReference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlockers", ctx, taskIds)
+	ret0, _ := ret[0].(map[sqlddl.ID][]models.TaskReference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlockers indicates an expected call of FindBlockers.
+func (mr *MockTaskDependencyRepositoryMockRecorder) FindBlockers(ctx, taskIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlockers", reflect.TypeOf((*MockTaskDependencyRepository)(nil).FindBlockers), ctx, taskIds)
+}
+
+// Lock mocks base method.
+func (m *MockTaskDependencyRepository) Lock(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockTaskDependencyRepositoryMockRecorder) Lock(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockTaskDependencyRepository)(nil).Lock), ctx)
+}
@@ -0,0 +1,293 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: just-kanban/internal/repositories/interfaces (interfaces: TaskRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination ./mocks/task_repository.mock.go -package=mocks just-kanban/internal/repositories/interfaces TaskRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "just-kanban/internal/models"
+	sqlddl "just-kanban/pkg/sqlddl"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaskRepository is a mock of TaskRepository interface.
+type MockTaskRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskRepositoryMockRecorder is the mock recorder for MockTaskRepository.
+type MockTaskRepositoryMockRecorder struct {
+	mock *MockTaskRepository
+}
+
+// NewMockTaskRepository creates a new mock instance.
+func NewMockTaskRepository(ctrl *gomock.Controller) *MockTaskRepository {
+	mock := &MockTaskRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskRepository) EXPECT() *MockTaskRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByColumnID mocks base method.
+func (m *MockTaskRepository) CountByColumnID(ctx context.Context, columnId sqlddl.ID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByColumnID", ctx, columnId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByColumnID indicates an expected call of CountByColumnID.
+func (mr *MockTaskRepositoryMockRecorder) CountByColumnID(ctx, columnId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByColumnID", reflect.TypeOf((*MockTaskRepository)(nil).CountByColumnID), ctx, columnId)
+}
+
+// Create mocks base method.
+func (m *MockTaskRepository) Create(ctx context.Context, task *models.Task) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskRepositoryMockRecorder) Create(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskRepository)(nil).Create), ctx, task)
+}
+
+// FindAllByBoardId mocks base method.
+func (m *MockTaskRepository) FindAllByBoardId(ctx context.Context, boardId sqlddl.ID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBoardId", ctx, boardId)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBoardId indicates an expected call of FindAllByBoardId.
+func (mr *MockTaskRepositoryMockRecorder) FindAllByBoardId(ctx, boardId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBoardId", reflect.TypeOf((*MockTaskRepository)(nil).FindAllByBoardId), ctx, boardId)
+}
+
+// FindAllDueBefore mocks base method.
+func (m *MockTaskRepository) FindAllDueBefore(ctx context.Context, deadline time.Time) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDueBefore", ctx, deadline)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDueBefore indicates an expected call of FindAllDueBefore.
+func (mr *MockTaskRepositoryMockRecorder) FindAllDueBefore(ctx, deadline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDueBefore", reflect.TypeOf((*MockTaskRepository)(nil).FindAllDueBefore), ctx, deadline)
+}
+
+// FindByID mocks base method.
+func (m *MockTaskRepository) FindByID(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, taskId)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockTaskRepositoryMockRecorder) FindByID(ctx, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTaskRepository)(nil).FindByID), ctx, taskId)
+}
+
+// FindByName mocks base method.
+func (m *MockTaskRepository) FindByName(ctx context.Context, boardId sqlddl.ID, name string) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, boardId, name)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockTaskRepositoryMockRecorder) FindByName(ctx, boardId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockTaskRepository)(nil).FindByName), ctx, boardId, name)
+}
+
+// FindByOrder mocks base method.
+func (m *MockTaskRepository) FindByOrder(ctx context.Context, boardId sqlddl.ID, order uint) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrder", ctx, boardId, order)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByOrder indicates an expected call of FindByOrder.
+func (mr *MockTaskRepositoryMockRecorder) FindByOrder(ctx, boardId, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrder", reflect.TypeOf((*MockTaskRepository)(nil).FindByOrder), ctx, boardId, order)
+}
+
+// FindDeletedByID mocks base method.
+func (m *MockTaskRepository) FindDeletedByID(ctx context.Context, taskId sqlddl.ID) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByID", ctx, taskId)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByID indicates an expected call of FindDeletedByID.
+func (mr *MockTaskRepositoryMockRecorder) FindDeletedByID(ctx, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByID", reflect.TypeOf((*MockTaskRepository)(nil).FindDeletedByID), ctx, taskId)
+}
+
+// FindDeletedByUserID mocks base method.
+func (m *MockTaskRepository) FindDeletedByUserID(ctx context.Context, userId sqlddl.ID) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedByUserID", ctx, userId)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedByUserID indicates an expected call of FindDeletedByUserID.
+func (mr *MockTaskRepositoryMockRecorder) FindDeletedByUserID(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedByUserID", reflect.TypeOf((*MockTaskRepository)(nil).FindDeletedByUserID), ctx, userId)
+}
+
+// FindPageByBoardID mocks base method.
+func (m *MockTaskRepository) FindPageByBoardID(ctx context.Context, boardId sqlddl.ID, filter *models.TaskFilter) ([]models.Task, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPageByBoardID", ctx, boardId, filter)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindPageByBoardID indicates an expected call of FindPageByBoardID.
+func (mr *MockTaskRepositoryMockRecorder) FindPageByBoardID(ctx, boardId, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPageByBoardID", reflect.TypeOf((*MockTaskRepository)(nil).FindPageByBoardID), ctx, boardId, filter)
+}
+
+// MoveHiddenTasks mocks base method.
+func (m *MockTaskRepository) MoveHiddenTasks(ctx context.Context, fromColumnId sqlddl.ID, to *models.BoardColumn) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveHiddenTasks", ctx, fromColumnId, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveHiddenTasks indicates an expected call of MoveHiddenTasks.
+func (mr *MockTaskRepositoryMockRecorder) MoveHiddenTasks(ctx, fromColumnId, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveHiddenTasks", reflect.TypeOf((*MockTaskRepository)(nil).MoveHiddenTasks), ctx, fromColumnId, to)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockTaskRepository) PurgeDeletedBefore(ctx context.Context, deadline time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", ctx, deadline)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockTaskRepositoryMockRecorder) PurgeDeletedBefore(ctx, deadline any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockTaskRepository)(nil).PurgeDeletedBefore), ctx, deadline)
+}
+
+// SetArchivedAt mocks base method.
+func (m *MockTaskRepository) SetArchivedAt(ctx context.Context, taskId sqlddl.ID, archivedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchivedAt", ctx, taskId, archivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArchivedAt indicates an expected call of SetArchivedAt.
+func (mr *MockTaskRepositoryMockRecorder) SetArchivedAt(ctx, taskId, archivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchivedAt", reflect.TypeOf((*MockTaskRepository)(nil).SetArchivedAt), ctx, taskId, archivedAt)
+}
+
+// SetDeletedAt mocks base method.
+func (m *MockTaskRepository) SetDeletedAt(ctx context.Context, taskId sqlddl.ID, deletedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeletedAt", ctx, taskId, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeletedAt indicates an expected call of SetDeletedAt.
+func (mr *MockTaskRepositoryMockRecorder) SetDeletedAt(ctx, taskId, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeletedAt", reflect.TypeOf((*MockTaskRepository)(nil).SetDeletedAt), ctx, taskId, deletedAt)
+}
+
+// SetRemindedAt mocks base method.
+func (m *MockTaskRepository) SetRemindedAt(ctx context.Context, taskId sqlddl.ID, remindedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRemindedAt", ctx, taskId, remindedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRemindedAt indicates an expected call of SetRemindedAt.
+func (mr *MockTaskRepositoryMockRecorder) SetRemindedAt(ctx, taskId, remindedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRemindedAt", reflect.TypeOf((*MockTaskRepository)(nil).SetRemindedAt), ctx, taskId, remindedAt)
+}
+
+// ShiftPositions mocks base method.
+func (m *MockTaskRepository) ShiftPositions(ctx context.Context, columnId sqlddl.ID, fromPosition, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShiftPositions", ctx, columnId, fromPosition, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShiftPositions indicates an expected call of ShiftPositions.
+func (mr *MockTaskRepositoryMockRecorder) ShiftPositions(ctx, columnId, fromPosition, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShiftPositions", reflect.TypeOf((*MockTaskRepository)(nil).ShiftPositions), ctx, columnId, fromPosition, delta)
+}
+
+// Update mocks base method.
+func (m *MockTaskRepository) Update(ctx context.Context, taskId sqlddl.ID, d *models.UpdateTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, taskId, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskRepositoryMockRecorder) Update(ctx, taskId, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepository)(nil).Update), ctx, taskId, d)
+}
@@ -145,6 +145,36 @@ func (mr *MockUserServiceMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserService)(nil).FindByID), ctx, id)
 }
 
+// FindByUsername mocks base method.
+func (m *MockUserService) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUsername", ctx, username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUsername indicates an expected call of FindByUsername.
+func (mr *MockUserServiceMockRecorder) FindByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserService)(nil).FindByUsername), ctx, username)
+}
+
+// FindPage mocks base method.
+func (m *MockUserService) FindPage(ctx context.Context, after *models.PageKey, limit int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", ctx, after, limit)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockUserServiceMockRecorder) FindPage(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockUserService)(nil).FindPage), ctx, after, limit)
+}
+
 // IsUpdateAllowed mocks base method.
 func (m *MockUserService) IsUpdateAllowed(ctx context.Context, userId, targetId sqlddl.ID) bool {
 	m.ctrl.T.Helper()
@@ -174,6 +204,21 @@ func (mr *MockUserServiceMockRecorder) ListUsers(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserService)(nil).ListUsers), ctx)
 }
 
+// ListUsersPage mocks base method.
+func (m *MockUserService) ListUsersPage(ctx context.Context, d *services.KeysetPageData) (*services.UsersPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersPage", ctx, d)
+	ret0, _ := ret[0].(*services.UsersPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersPage indicates an expected call of ListUsersPage.
+func (mr *MockUserServiceMockRecorder) ListUsersPage(ctx, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersPage", reflect.TypeOf((*MockUserService)(nil).ListUsersPage), ctx, d)
+}
+
 // Update mocks base method.
 func (m *MockUserService) Update(ctx context.Context, id sqlddl.ID, d *models.UpdateUser) error {
 	m.ctrl.T.Helper()
@@ -5,4 +5,6 @@ import "errors"
 var (
 	UnauthorizedErr = errors.New("unauthorized")
 	TokenHeader     = "Authorization"
+	// TokenQueryParam is query parameter of access token for clients which can't set TokenHeader
+	TokenQueryParam = "access_token"
 )
@@ -10,8 +10,21 @@ const (
 	HeaderAllowOrigin      = "Access-Control-Allow-Origin"
 	HeaderAllowHeaders     = "Access-Control-Allow-Headers"
 	HeaderAllowCredentials = "Access-Control-Allow-Credentials"
+	HeaderExposeHeaders    = "Access-Control-Expose-Headers"
+	HeaderOrigin           = "Origin"
+	HeaderVary             = "Vary"
 )
 
 func SetHeaderAllowedMethods(w http.ResponseWriter, methods ...string) {
 	w.Header().Set(HeaderAllowMethods, http.MethodOptions+", "+strings.Join(methods, ", "))
 }
+
+// IsOriginAllowed checks whether origin is one of allowed origins, scheme and host are compared ignoring case
+func IsOriginAllowed(origin string, allowedOrigins []string) bool {
+	for _, allowedOrigin := range allowedOrigins {
+		if strings.EqualFold(origin, allowedOrigin) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrInvalid is returned for cursor which wasn't issued by codec or was changed by client
+var ErrInvalid = errors.New("invalid cursor")
+
+// Codec issues opaque cursor tokens which keep position of page in listing,
+// tokens are signed so client can't forge position
+type Codec struct {
+	secret []byte
+}
+
+// NewCodec creates codec which signs tokens with secret
+func NewCodec(secret string) *Codec {
+	return &Codec{[]byte(secret)}
+}
+
+// Encode serializes position into signed url-safe token
+func (c *Codec) Encode(position interface{}) (string, error) {
+	payload, marshalErr := json.Marshal(position)
+	if marshalErr != nil {
+		return "", marshalErr
+	}
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(c.sign(encodedPayload)), nil
+}
+
+// Decode checks token signature and deserializes its position into provided pointer
+func (c *Codec) Decode(token string, position interface{}) error {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return ErrInvalid
+	}
+	signature, decodeErr := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if decodeErr != nil || !hmac.Equal(signature, c.sign(encodedPayload)) {
+		return ErrInvalid
+	}
+	payload, decodeErr := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if decodeErr != nil {
+		return ErrInvalid
+	}
+	if unmarshalErr := json.Unmarshal(payload, position); unmarshalErr != nil {
+		return ErrInvalid
+	}
+	return nil
+}
+
+func (c *Codec) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
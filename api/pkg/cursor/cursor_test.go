@@ -0,0 +1,57 @@
+package cursor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type position struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+func TestCodec(t *testing.T) {
+	codec := NewCodec("secret")
+	original := position{CreatedAt: time.Date(2024, time.March, 1, 12, 0, 0, 123456000, time.UTC), ID: "uuid"}
+	token, encodeErr := codec.Encode(original)
+	if encodeErr != nil {
+		t.Fatalf("unexpected error: %v", encodeErr)
+	}
+
+	t.Run("Success decode issued token", func(t *testing.T) {
+		var decoded position
+		if decodeErr := codec.Decode(token, &decoded); decodeErr != nil {
+			t.Fatalf("unexpected error: %v", decodeErr)
+		}
+		if !decoded.CreatedAt.Equal(original.CreatedAt) || decoded.ID != original.ID {
+			t.Fatalf("got %+v, expected %+v", decoded, original)
+		}
+	})
+
+	t.Run("Failed decode token signed with another secret", func(t *testing.T) {
+		var decoded position
+		foreignToken, _ := NewCodec("another").Encode(original)
+		if decodeErr := codec.Decode(foreignToken, &decoded); !errors.Is(decodeErr, ErrInvalid) {
+			t.Fatalf("got %v, expected %v", decodeErr, ErrInvalid)
+		}
+	})
+
+	t.Run("Failed decode changed token", func(t *testing.T) {
+		var decoded position
+		forged, _ := NewCodec("secret").Encode(position{ID: "another"})
+		forgedPayload, _, _ := strings.Cut(forged, ".")
+		_, signature, _ := strings.Cut(token, ".")
+		if decodeErr := codec.Decode(forgedPayload+"."+signature, &decoded); !errors.Is(decodeErr, ErrInvalid) {
+			t.Fatalf("got %v, expected %v", decodeErr, ErrInvalid)
+		}
+	})
+
+	t.Run("Failed decode malformed token", func(t *testing.T) {
+		var decoded position
+		if decodeErr := codec.Decode("garbage", &decoded); !errors.Is(decodeErr, ErrInvalid) {
+			t.Fatalf("got %v, expected %v", decodeErr, ErrInvalid)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package identifier
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenSize is count of random bytes in generated tokens
+const tokenSize = 32
+
+// GenerateToken returns url-safe random string which is hard to guess, e.g. secret part of shareable link
+func GenerateToken() (string, error) {
+	bytes := make([]byte, tokenSize)
+	if _, readErr := rand.Read(bytes); readErr != nil {
+		return "", readErr
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken returns digest of token which may be stored instead of token itself
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -23,12 +23,23 @@ const (
 	dirTemplates         = "templates"
 )
 
+const (
+	// kindTable is kind of migration which creates new table
+	kindTable = "table"
+	// kindAlterTable is kind of migration which adds columns to already created table
+	kindAlterTable = "alter_table"
+)
+
 func GenerateTable(n int, tableName string, direction Direction, data sqlddl.SchemaTable) {
 	tmplDir := filepath.Join(dirMigrations, dirTemplates)
-	tmplName := fmt.Sprintf("%s_table.%[1]s.sql.tmpl", direction)
+	kind := kindTable
+	if data.Alter {
+		kind = kindAlterTable
+	}
+	tmplName := fmt.Sprintf("%s_%s.%[1]s.sql.tmpl", direction, kind)
 	tmplPath := filepath.Join(tmplDir, tmplName)
 	outputDir := filepath.Join(dirMigrations, dirOutput)
-	outputName := fmt.Sprintf("%04d_%s_table.%s.sql", n, tableName, direction)
+	outputName := fmt.Sprintf("%04d_%s_%s.%s.sql", n, tableName, kind, direction)
 	outputPath := filepath.Join(outputDir, outputName)
 	tmp := template.Must(
 		template.New(tmplName).Funcs(template.FuncMap{"join": strings.Join}).ParseFiles(tmplPath),
@@ -47,6 +58,7 @@ func GenerateTable(n int, tableName string, direction Direction, data sqlddl.Sch
 		"ColumnID":        sqlddl.ColumnID,
 		"Columns":         data.Columns,
 		"ForeignKeys":     data.ForeignKeys,
+		"Statements":      data.Statements,
 		"ColumnCreatedAt": sqlddl.ColumnCreatedAt,
 		"ColumnUpdatedAt": sqlddl.ColumnUpdatedAt,
 	})
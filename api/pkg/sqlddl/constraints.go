@@ -9,3 +9,13 @@ const (
 	ConstraintNotNull            = "NOT NULL"
 	ConstraintUnique             = "UNIQUE"
 )
+
+// ConstraintDefault defines value of column used when no one provided
+func ConstraintDefault(value string) string {
+	return "DEFAULT " + value
+}
+
+// ConstraintGeneratedStored defines column which value is computed from expression and kept up to date by database
+func ConstraintGeneratedStored(expression string) string {
+	return "GENERATED ALWAYS AS (" + expression + ") STORED"
+}
@@ -5,6 +5,11 @@ type (
 		Name        string
 		Columns     []SchemaColumn
 		ForeignKeys []SchemaForeignKey
+		// Alter marks that table is already created by previous schema,
+		// so migration only adds Columns and ForeignKeys to it
+		Alter bool
+		// Statements are raw sql statements executed after table migration, e.g. for seeding existing data
+		Statements []string
 	}
 	SchemaColumn struct {
 		Name        string
@@ -5,8 +5,13 @@ import "fmt"
 type ID string
 
 const (
-	TypeText = "TEXT"
-	TypeInt  = "INT"
+	TypeText        = "TEXT"
+	TypeInt         = "INT"
+	TypeBigInt      = "BIGINT"
+	TypeTimestampTZ = "TIMESTAMPTZ"
+	TypeBoolean     = "BOOLEAN"
+	TypeTSVector    = "TSVECTOR"
+	TypeTextArray   = "TEXT[]"
 )
 
 func TypeVarchar(n int) string {
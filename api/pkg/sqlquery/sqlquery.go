@@ -2,7 +2,6 @@ package sqlquery
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -27,7 +26,7 @@ type DynamicUpdateParams struct {
 }
 
 // DynamicUpdate updates relational database with DynamicUpdateParams
-func DynamicUpdate(ctx context.Context, db *sql.DB, dup *DynamicUpdateParams) error {
+func DynamicUpdate(ctx context.Context, db Executor, dup *DynamicUpdateParams) error {
 	const queryStart = "UPDATE %s SET %s WHERE %s = $%d"
 	var clausesStrings []string
 	argsIndex := 0
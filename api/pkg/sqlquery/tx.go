@@ -0,0 +1,46 @@
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor is common interface of *sql.DB and *sql.Tx for running queries
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type txKey struct{}
+
+// WithTx returns copy of context which carries transaction
+func WithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// Conn returns transaction carried by context, if there is no one returns db
+func Conn(ctx context.Context, db *sql.DB) Executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// RunInTx runs fn within transaction which is committed if fn succeeds and rolled back otherwise.
+// Queries have to be run through Conn with context passed to fn to take part in transaction.
+// If context already carries transaction then fn joins it
+func RunInTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, beginErr := db.BeginTx(ctx, nil)
+	if beginErr != nil {
+		return beginErr
+	}
+	if fnErr := fn(WithTx(ctx, tx)); fnErr != nil {
+		tx.Rollback()
+		return fnErr
+	}
+	return tx.Commit()
+}
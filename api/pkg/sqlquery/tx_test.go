@@ -0,0 +1,61 @@
+package sqlquery
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestRunInTx(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	t.Run("Commit on success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE table SET status = 1").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		err := RunInTx(context.Background(), db, func(ctx context.Context) error {
+			_, execErr := Conn(ctx, db).ExecContext(ctx, "UPDATE table SET status = 1")
+			return execErr
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+			t.Fatal(expectErr)
+		}
+	})
+	t.Run("Rollback on failure", func(t *testing.T) {
+		fnErr := errors.New("failed")
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		err := RunInTx(context.Background(), db, func(ctx context.Context) error {
+			return fnErr
+		})
+		if !errors.Is(err, fnErr) {
+			t.Fatalf("expected %v but got %v", fnErr, err)
+		}
+		if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+			t.Fatal(expectErr)
+		}
+	})
+	t.Run("Nested call joins outer transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectCommit()
+		err := RunInTx(context.Background(), db, func(ctx context.Context) error {
+			return RunInTx(ctx, db, func(ctx context.Context) error {
+				if _, isTx := Conn(ctx, db).(*sql.Tx); !isTx {
+					return errors.New("nested call is not run within transaction")
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expectErr := mock.ExpectationsWereMet(); expectErr != nil {
+			t.Fatal(expectErr)
+		}
+	})
+}
@@ -1,6 +1,16 @@
 package tcp
 
 var (
-	HeaderContentType = "Content-Type"
-	ContentTypeJSON   = "application/json"
+	HeaderContentType         = "Content-Type"
+	HeaderContentLength       = "Content-Length"
+	HeaderContentDisposition  = "Content-Disposition"
+	HeaderContentTypeOptions  = "X-Content-Type-Options"
+	HeaderLink                = "Link"
+	HeaderTotalCount          = "X-Total-Count"
+	HeaderCacheControl        = "Cache-Control"
+	HeaderLastEventID         = "Last-Event-ID"
+	ContentTypeJSON           = "application/json"
+	ContentTypeEventStream    = "text/event-stream"
+	ContentTypeOptionsNoSniff = "nosniff"
+	CacheControlNoCache       = "no-cache"
 )
@@ -13,6 +13,7 @@ const tagTrimmed = "trimmed"
 
 type (
 	Validate          = validator.Validate
+	ValidationErrors  = validator.ValidationErrors
 	ErrorHTTPResponse struct {
 		Message string            `json:"message"`
 		Fields  map[string]string `json:"fields"`
@@ -34,11 +35,16 @@ func RegisterValidationTagTrimmed(v *validator.Validate) error {
 	return err
 }
 
-func FormatValidationErr(err error) ErrorHTTPResponse {
-	response := ErrorHTTPResponse{
+// NewFieldsErr creates response of failed validation with provided messages of fields
+func NewFieldsErr(fields map[string]string) ErrorHTTPResponse {
+	return ErrorHTTPResponse{
 		Message: "validation failed",
-		Fields:  make(map[string]string),
+		Fields:  fields,
 	}
+}
+
+func FormatValidationErr(err error) ErrorHTTPResponse {
+	response := NewFieldsErr(make(map[string]string))
 	// todo: get clear understanding what here happens with validationErrors errorsInsert
 	var validationErrors validator.ValidationErrors
 	if errors.As(err, &validationErrors) {
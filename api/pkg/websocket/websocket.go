@@ -0,0 +1,446 @@
+// Package websocket implements WebSocket protocol (RFC 6455) over hijacked http connections.
+// Only what app needs is supported: text and binary messages, ping/pong and closing handshake, no extensions
+package websocket
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"just-kanban/pkg/cors"
+)
+
+// MessageType is opcode of WebSocket frame
+type MessageType byte
+
+const (
+	continuationFrame MessageType = 0
+	TextMessage       MessageType = 1
+	BinaryMessage     MessageType = 2
+	CloseMessage      MessageType = 8
+	PingMessage       MessageType = 9
+	PongMessage       MessageType = 10
+)
+
+// Status codes of close frames
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocolError   = 1002
+	CloseNoStatus        = 1005
+	ClosePolicyViolation = 1008
+	CloseMessageTooBig   = 1009
+	CloseInternalError   = 1011
+	CloseTryAgainLater   = 1013
+)
+
+const (
+	// DefaultReadLimit is max size of message read by connection
+	DefaultReadLimit = 64 * 1024
+	// maxControlPayload is max size of control frame payload defined by protocol
+	maxControlPayload = 125
+	// acceptGUID is concatenated with handshake key to prove that server speaks WebSocket
+	acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+const (
+	headerUpgrade    = "Upgrade"
+	headerConnection = "Connection"
+	headerKey        = "Sec-WebSocket-Key"
+	headerVersion    = "Sec-WebSocket-Version"
+	headerAccept     = "Sec-WebSocket-Accept"
+	protocolVersion  = "13"
+)
+
+var (
+	ErrorBadHandshake  = errors.New("bad websocket handshake")
+	ErrorOriginDenied  = errors.New("websocket origin is not allowed")
+	ErrorMessageTooBig = errors.New("websocket message is too big")
+	ErrorCloseSent     = errors.New("websocket close frame is already sent")
+	protocolErr        = errors.New("websocket protocol violation")
+)
+
+// CloseError is returned by Conn.ReadMessage when peer closes connection
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (ce *CloseError) Error() string {
+	return fmt.Sprintf("websocket closed with code %d %s", ce.Code, ce.Reason)
+}
+
+// Conn is WebSocket connection. Messages are read by single goroutine, writes are safe for concurrent use
+type Conn struct {
+	// ReadLimit is max size of message, bigger messages close connection
+	ReadLimit int64
+	// WriteTimeout limits every write if it's set, so peer which doesn't read can't block writer forever
+	WriteTimeout time.Duration
+	conn         net.Conn
+	reader       *bufio.Reader
+	client       bool
+	pongHandler  func(data []byte)
+	writeMu      sync.Mutex
+	closeSent    bool
+}
+
+type frame struct {
+	fin     bool
+	opcode  MessageType
+	payload []byte
+}
+
+// IsUpgrade reports whether request asks for WebSocket connection
+func IsUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, headerConnection, "upgrade") &&
+		headerContainsToken(r.Header, headerUpgrade, "websocket")
+}
+
+// IsOriginAllowed reports whether page which opens connection may do it. Browsers always send Origin header,
+// so requests without it come from other clients and are allowed. Origin matching host of request is allowed too,
+// other origins must be in allowedOrigins
+func IsOriginAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get(cors.HeaderOrigin)
+	if origin == "" {
+		return true
+	}
+	if u, parseErr := url.Parse(origin); parseErr == nil && strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	return cors.IsOriginAllowed(origin, allowedOrigins)
+}
+
+// Upgrade completes WebSocket handshake and takes over connection of request.
+// If request isn't valid handshake, error response is written and ErrorBadHandshake is returned.
+// Handshake from origin which isn't allowed by IsOriginAllowed is refused with ErrorOriginDenied,
+// otherwise any page could open connection on behalf of user
+func Upgrade(w http.ResponseWriter, r *http.Request, allowedOrigins []string) (*Conn, error) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return nil, ErrorBadHandshake
+	}
+	if !IsUpgrade(r) {
+		http.Error(w, ErrorBadHandshake.Error(), http.StatusBadRequest)
+		return nil, ErrorBadHandshake
+	}
+	if !IsOriginAllowed(r, allowedOrigins) {
+		http.Error(w, ErrorOriginDenied.Error(), http.StatusForbidden)
+		return nil, ErrorOriginDenied
+	}
+	if r.Header.Get(headerVersion) != protocolVersion {
+		w.Header().Set(headerVersion, protocolVersion)
+		http.Error(w, http.StatusText(http.StatusUpgradeRequired), http.StatusUpgradeRequired)
+		return nil, ErrorBadHandshake
+	}
+	key := r.Header.Get(headerKey)
+	if key == "" {
+		http.Error(w, ErrorBadHandshake.Error(), http.StatusBadRequest)
+		return nil, ErrorBadHandshake
+	}
+	netConn, buffered, hijackErr := http.NewResponseController(w).Hijack()
+	if hijackErr != nil {
+		http.Error(w, hijackErr.Error(), http.StatusInternalServerError)
+		return nil, hijackErr
+	}
+	// client mustn't send frames before handshake response
+	if buffered.Reader.Buffered() > 0 {
+		netConn.Close()
+		return nil, ErrorBadHandshake
+	}
+	// deadlines of http server don't apply to long-lived connection
+	if deadlineErr := netConn.SetDeadline(time.Time{}); deadlineErr != nil {
+		netConn.Close()
+		return nil, deadlineErr
+	}
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		headerUpgrade + ": websocket\r\n" +
+		headerConnection + ": Upgrade\r\n" +
+		headerAccept + ": " + acceptKey(key) + "\r\n\r\n"
+	if _, writeErr := io.WriteString(netConn, response); writeErr != nil {
+		netConn.Close()
+		return nil, writeErr
+	}
+	return &Conn{ReadLimit: DefaultReadLimit, conn: netConn, reader: buffered.Reader}, nil
+}
+
+// Dial opens client connection to ws:// url, it's used by tests and tools talking to app
+func Dial(rawURL string, header http.Header) (*Conn, *http.Response, error) {
+	u, parseErr := url.Parse(rawURL)
+	if parseErr != nil {
+		return nil, nil, parseErr
+	}
+	if u.Scheme != "ws" {
+		return nil, nil, fmt.Errorf("unsupported websocket url scheme %q", u.Scheme)
+	}
+	netConn, dialErr := net.Dial("tcp", u.Host)
+	if dialErr != nil {
+		return nil, nil, dialErr
+	}
+	nonce := make([]byte, 16)
+	if _, randErr := rand.Read(nonce); randErr != nil {
+		netConn.Close()
+		return nil, nil, randErr
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	requestURL := *u
+	requestURL.Scheme = "http"
+	request := &http.Request{
+		Method: http.MethodGet,
+		URL:    &requestURL,
+		Host:   u.Host,
+		Header: header.Clone(),
+	}
+	if request.Header == nil {
+		request.Header = make(http.Header)
+	}
+	request.Header.Set(headerUpgrade, "websocket")
+	request.Header.Set(headerConnection, "Upgrade")
+	request.Header.Set(headerKey, key)
+	request.Header.Set(headerVersion, protocolVersion)
+	if writeErr := request.Write(netConn); writeErr != nil {
+		netConn.Close()
+		return nil, nil, writeErr
+	}
+	reader := bufio.NewReader(netConn)
+	response, readErr := http.ReadResponse(reader, request)
+	if readErr != nil {
+		netConn.Close()
+		return nil, nil, readErr
+	}
+	if response.StatusCode != http.StatusSwitchingProtocols || response.Header.Get(headerAccept) != acceptKey(key) {
+		netConn.Close()
+		return nil, response, ErrorBadHandshake
+	}
+	return &Conn{ReadLimit: DefaultReadLimit, conn: netConn, reader: reader, client: true}, response, nil
+}
+
+// SetPongHandler sets function called with payload of every received pong, it must be set before reading
+func (c *Conn) SetPongHandler(handler func(data []byte)) {
+	c.pongHandler = handler
+}
+
+// ReadMessage reads next data message. Pings are answered and pongs are passed to pong handler meanwhile.
+// *CloseError is returned when peer closes connection, close frame is echoed then
+func (c *Conn) ReadMessage() (MessageType, []byte, error) {
+	var messageType MessageType
+	var message []byte
+	for {
+		f, readErr := c.readFrame(c.ReadLimit - int64(len(message)))
+		if readErr != nil {
+			switch {
+			case errors.Is(readErr, ErrorMessageTooBig):
+				c.WriteClose(CloseMessageTooBig, "")
+			case errors.Is(readErr, protocolErr):
+				c.WriteClose(CloseProtocolError, "")
+			}
+			return 0, nil, readErr
+		}
+		switch f.opcode {
+		case PingMessage:
+			// pong isn't sent once closing handshake is started, but peer's close frame is still awaited
+			writeErr := c.writeFrame(PongMessage, f.payload)
+			if writeErr != nil && !errors.Is(writeErr, ErrorCloseSent) {
+				return 0, nil, writeErr
+			}
+			continue
+		case PongMessage:
+			if c.pongHandler != nil {
+				c.pongHandler(f.payload)
+			}
+			continue
+		case CloseMessage:
+			closeErr := &CloseError{Code: CloseNoStatus}
+			if len(f.payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(f.payload))
+				closeErr.Reason = string(f.payload[2:])
+			}
+			if closeErr.Code == CloseNoStatus {
+				c.writeFrame(CloseMessage, nil)
+			} else {
+				c.WriteClose(closeErr.Code, "")
+			}
+			return 0, nil, closeErr
+		case continuationFrame:
+			if messageType == 0 {
+				c.WriteClose(CloseProtocolError, "")
+				return 0, nil, protocolErr
+			}
+		case TextMessage, BinaryMessage:
+			if messageType != 0 {
+				c.WriteClose(CloseProtocolError, "")
+				return 0, nil, protocolErr
+			}
+			messageType = f.opcode
+		default:
+			c.WriteClose(CloseProtocolError, "")
+			return 0, nil, protocolErr
+		}
+		message = append(message, f.payload...)
+		if f.fin {
+			return messageType, message, nil
+		}
+	}
+}
+
+// WriteMessage writes data message as single frame
+func (c *Conn) WriteMessage(messageType MessageType, data []byte) error {
+	if messageType != TextMessage && messageType != BinaryMessage {
+		return fmt.Errorf("%d isn't websocket data message type", messageType)
+	}
+	return c.writeFrame(messageType, data)
+}
+
+// WritePing writes ping frame, peer answers it with pong of the same payload
+func (c *Conn) WritePing(data []byte) error {
+	if len(data) > maxControlPayload {
+		return ErrorMessageTooBig
+	}
+	return c.writeFrame(PingMessage, data)
+}
+
+// WriteClose starts closing handshake, connection mustn't be written after it
+func (c *Conn) WriteClose(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > maxControlPayload {
+		payload = payload[:maxControlPayload]
+	}
+	return c.writeFrame(CloseMessage, payload)
+}
+
+// Close closes underlying connection without closing handshake
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Conn) readFrame(limit int64) (*frame, error) {
+	var header [2]byte
+	if _, readErr := io.ReadFull(c.reader, header[:]); readErr != nil {
+		return nil, readErr
+	}
+	f := &frame{fin: header[0]&0x80 != 0, opcode: MessageType(header[0] & 0x0f)}
+	// no extensions are negotiated, so reserved bits must be clear
+	if header[0]&0x70 != 0 {
+		return nil, protocolErr
+	}
+	// clients mask every frame, servers never do
+	if masked := header[1]&0x80 != 0; masked == c.client {
+		return nil, protocolErr
+	}
+	length := uint64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, readErr := io.ReadFull(c.reader, extended[:]); readErr != nil {
+			return nil, readErr
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, readErr := io.ReadFull(c.reader, extended[:]); readErr != nil {
+			return nil, readErr
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if f.opcode >= CloseMessage {
+		if length > maxControlPayload || !f.fin {
+			return nil, protocolErr
+		}
+	} else if limit < 0 || length > uint64(limit) {
+		return nil, ErrorMessageTooBig
+	}
+	var mask [4]byte
+	if !c.client {
+		if _, readErr := io.ReadFull(c.reader, mask[:]); readErr != nil {
+			return nil, readErr
+		}
+	}
+	f.payload = make([]byte, length)
+	if _, readErr := io.ReadFull(c.reader, f.payload); readErr != nil {
+		return nil, readErr
+	}
+	if !c.client {
+		maskBytes(mask, f.payload)
+	}
+	return f, nil
+}
+
+func (c *Conn) writeFrame(opcode MessageType, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if c.closeSent {
+		return ErrorCloseSent
+	}
+	data := []byte{0x80 | byte(opcode)}
+	var maskBit byte
+	if c.client {
+		maskBit = 0x80
+	}
+	switch length := len(payload); {
+	case length < 126:
+		data = append(data, maskBit|byte(length))
+	case length <= 0xffff:
+		data = binary.BigEndian.AppendUint16(append(data, maskBit|126), uint16(length))
+	default:
+		data = binary.BigEndian.AppendUint64(append(data, maskBit|127), uint64(length))
+	}
+	if c.client {
+		var mask [4]byte
+		if _, randErr := rand.Read(mask[:]); randErr != nil {
+			return randErr
+		}
+		data = append(data, mask[:]...)
+		start := len(data)
+		data = append(data, payload...)
+		maskBytes(mask, data[start:])
+	} else {
+		data = append(data, payload...)
+	}
+	if c.WriteTimeout > 0 {
+		if deadlineErr := c.conn.SetWriteDeadline(time.Now().Add(c.WriteTimeout)); deadlineErr != nil {
+			return deadlineErr
+		}
+	}
+	if _, writeErr := c.conn.Write(data); writeErr != nil {
+		return writeErr
+	}
+	if opcode == CloseMessage {
+		c.closeSent = true
+	}
+	return nil
+}
+
+// acceptKey computes value of Sec-WebSocket-Accept header for key of handshake request
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func maskBytes(mask [4]byte, data []byte) {
+	for i := range data {
+		data[i] ^= mask[i%4]
+	}
+}
+
+// headerContainsToken checks comma separated values of header for token ignoring case
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package websocket
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// echoServer upgrades requests and sends every received message back until client closes connection
+func echoServer(t *testing.T, readLimit int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, upgradeErr := Upgrade(w, r, []string{"https://app.example.com"})
+		if upgradeErr != nil {
+			return
+		}
+		defer conn.Close()
+		conn.ReadLimit = readLimit
+		for {
+			messageType, data, readErr := conn.ReadMessage()
+			if readErr != nil {
+				return
+			}
+			if writeErr := conn.WriteMessage(messageType, data); writeErr != nil {
+				t.Error(writeErr)
+				return
+			}
+		}
+	}))
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestConn(t *testing.T) {
+	server := echoServer(t, 70000)
+	defer server.Close()
+
+	t.Run("Messages of all sizes are echoed", func(t *testing.T) {
+		conn, _, dialErr := Dial(wsURL(server), nil)
+		if dialErr != nil {
+			t.Fatal(dialErr)
+		}
+		defer conn.Close()
+		for _, size := range []int{0, 125, 126, 65535, 65536} {
+			sent := strings.Repeat("a", size)
+			if writeErr := conn.WriteMessage(TextMessage, []byte(sent)); writeErr != nil {
+				t.Fatal(writeErr)
+			}
+			messageType, data, readErr := conn.ReadMessage()
+			if readErr != nil {
+				t.Fatal(readErr)
+			}
+			if messageType != TextMessage || string(data) != sent {
+				t.Fatalf("got message of type %d and size %d, expected size %d", messageType, len(data), size)
+			}
+		}
+	})
+
+	t.Run("Ping is answered with pong", func(t *testing.T) {
+		conn, _, dialErr := Dial(wsURL(server), nil)
+		if dialErr != nil {
+			t.Fatal(dialErr)
+		}
+		defer conn.Close()
+		var pong string
+		conn.SetPongHandler(func(data []byte) {
+			pong = string(data)
+		})
+		if pingErr := conn.WritePing([]byte("keepalive")); pingErr != nil {
+			t.Fatal(pingErr)
+		}
+		if writeErr := conn.WriteMessage(BinaryMessage, []byte("after")); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		if _, _, readErr := conn.ReadMessage(); readErr != nil {
+			t.Fatal(readErr)
+		}
+		if pong != "keepalive" {
+			t.Fatalf("got pong %q", pong)
+		}
+	})
+
+	t.Run("Close is echoed", func(t *testing.T) {
+		conn, _, dialErr := Dial(wsURL(server), nil)
+		if dialErr != nil {
+			t.Fatal(dialErr)
+		}
+		defer conn.Close()
+		if closeErr := conn.WriteClose(CloseNormal, "bye"); closeErr != nil {
+			t.Fatal(closeErr)
+		}
+		var closeErr *CloseError
+		if _, _, readErr := conn.ReadMessage(); !errors.As(readErr, &closeErr) || closeErr.Code != CloseNormal {
+			t.Fatalf("got error %v, expected normal close", readErr)
+		}
+		if writeErr := conn.WriteMessage(TextMessage, nil); !errors.Is(writeErr, ErrorCloseSent) {
+			t.Fatalf("got error %v after close", writeErr)
+		}
+	})
+
+	t.Run("Too big message closes connection", func(t *testing.T) {
+		conn, _, dialErr := Dial(wsURL(server), nil)
+		if dialErr != nil {
+			t.Fatal(dialErr)
+		}
+		defer conn.Close()
+		if writeErr := conn.WriteMessage(TextMessage, make([]byte, 70001)); writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		var closeErr *CloseError
+		if _, _, readErr := conn.ReadMessage(); !errors.As(readErr, &closeErr) || closeErr.Code != CloseMessageTooBig {
+			t.Fatalf("got error %v, expected close of too big message", readErr)
+		}
+	})
+
+	t.Run("Handshake from allowed origins is accepted", func(t *testing.T) {
+		for _, origin := range []string{server.URL, "HTTPS://app.example.com"} {
+			conn, _, dialErr := Dial(wsURL(server), http.Header{"Origin": {origin}})
+			if dialErr != nil {
+				t.Fatalf("got error %v for origin %s", dialErr, origin)
+			}
+			conn.Close()
+		}
+	})
+
+	t.Run("Handshake from another origin is rejected", func(t *testing.T) {
+		_, response, dialErr := Dial(wsURL(server), http.Header{"Origin": {"https://evil.example.com"}})
+		if !errors.Is(dialErr, ErrorBadHandshake) || response.StatusCode != http.StatusForbidden {
+			t.Fatalf("got error %v", dialErr)
+		}
+	})
+
+	t.Run("Request without handshake is rejected", func(t *testing.T) {
+		response, getErr := http.Get(server.URL)
+		if getErr != nil {
+			t.Fatal(getErr)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("got status %d", response.StatusCode)
+		}
+	})
+}